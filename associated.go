@@ -1,5 +1,7 @@
 package fail
 
+import "io"
+
 // ErrorAssociated is an error type that provides a list of associated errors.
 //
 // Associated errors are errors that are related to the current error, but are not
@@ -80,3 +82,118 @@ func WithAssociated(err error, associated ...error) error {
 
 	return From(err).Associate(associated...).asFail()
 }
+
+// Common roles of associated errors, see Builder.AssociateRole.
+const (
+	// RoleCleanup is the role of an error that occurred while releasing resources after the failure.
+	RoleCleanup = "cleanup"
+	// RoleLogging is the role of an error that occurred while logging or reporting the failure.
+	RoleLogging = "logging"
+	// RoleCompensation is the role of an error that occurred while compensating or rolling back the failed operation.
+	RoleCompensation = "compensation"
+)
+
+// ErrorAssociatedRoles is an error type that provides roles for its associated errors.
+//
+// A role is a short string, such as RoleCleanup or RoleCompensation, that describes why an
+// associated error is attached. The returned slice is aligned by index with the slice returned
+// by ErrorAssociated, and an empty role means the associated error has no role.
+type ErrorAssociatedRoles interface {
+	error
+
+	// ErrorAssociatedRoles returns the roles of the associated errors of this error, aligned with ErrorAssociated.
+	ErrorAssociatedRoles() []string
+}
+
+// AssociatedRoles returns the roles of the associated errors of the provided error.
+//
+// The returned slice has the same length as the slice returned by Associated, so that the role of
+// Associated(err)[i] is AssociatedRoles(err)[i]. Associated errors without a role have an empty role.
+// If err is nil, does not implement ErrorAssociatedRoles, or none of its associated errors has a role,
+// AssociatedRoles returns nil.
+//
+// Example:
+//
+//	roles := fail.AssociatedRoles(err)
+func AssociatedRoles(err error) []string {
+	r, ok := err.(ErrorAssociatedRoles)
+	if !ok {
+		return nil
+	}
+
+	return labelList(r.ErrorAssociatedRoles()).aligned(len(Associated(err)))
+}
+
+// AssociatedWithRole returns the associated errors of the provided error that have the given role.
+//
+// This allows downstream handlers to distinguish why an error is attached, for example to report
+// cleanup failures separately. If no associated error has the role, AssociatedWithRole returns nil.
+//
+// Example:
+//
+//	for _, cleanupErr := range fail.AssociatedWithRole(err, fail.RoleCleanup) {
+//		log.Printf("cleanup failed: %v", cleanupErr)
+//	}
+func AssociatedWithRole(err error, role string) []error {
+	roles := labelList(AssociatedRoles(err))
+	if roles == nil {
+		return nil
+	}
+
+	var res []error
+	for i, associated := range Associated(err) {
+		if roles.at(i) == role {
+			res = append(res, associated)
+		}
+	}
+
+	return res
+}
+
+// CloseAndAssociate closes closer and records a close error in the error pointed to by errp.
+//
+// It is designed to be deferred in functions with a named error result. If closing fails while
+// *errp is not nil, the close error is associated with *errp using the RoleCleanup role, so that the
+// primary error is kept as the returned error. If *errp is nil, the close error becomes the returned
+// error. If errp is nil, the close error is discarded.
+//
+// Example:
+//
+//	func writeReport(path string) (err error) {
+//		f, err := os.Create(path)
+//		if err != nil {
+//			return err
+//		}
+//		defer fail.CloseAndAssociate(&err, f)
+//
+//		_, err = f.Write(report)
+//		return err
+//	}
+func CloseAndAssociate(errp *error, closer io.Closer) {
+	CleanupAndAssociate(errp, closer.Close)
+}
+
+// CleanupAndAssociate calls cleanup and records its error in the error pointed to by errp.
+//
+// It behaves like CloseAndAssociate, for cleanups that are not an io.Closer.
+//
+// Example:
+//
+//	lock, err := locker.Acquire(ctx, key)
+//	if err != nil {
+//		return err
+//	}
+//	defer fail.CleanupAndAssociate(&err, lock.Release)
+func CleanupAndAssociate(errp *error, cleanup func() error) {
+	cErr := cleanup()
+	if cErr == nil || errp == nil {
+		return
+	}
+
+	if *errp == nil {
+		*errp = cErr
+		return
+	}
+
+	*errp = From(*errp).AssociateRole(RoleCleanup, cErr).asFail()
+}
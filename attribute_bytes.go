@@ -0,0 +1,84 @@
+package fail
+
+import (
+	"bytes"
+	"io"
+)
+
+// AttributeBytes adds a bounded copy of the provided payload as an attribute to the builder.
+//
+// At most maxLen bytes of data are copied, so the error never retains the underlying buffer, which makes
+// AttributeBytes suitable for capturing snippets of request or response bodies for debugging. If maxLen is
+// less than or equal to zero or greater than MaxAttributeSize, MaxAttributeSize is used. If data is cut,
+// the attribute is recorded under TruncatedAttribute as "attributes.<key>".
+//
+// The value is stored as a []byte, so the JSON printer renders it base64-encoded, and the other printers
+// render it as text if it is printable UTF-8, and in hexadecimal otherwise (see FormatValue).
+//
+// Example:
+//
+//	err := fail.New().
+//		AttributeBytes("response.body", body, 512).
+//		Msg("unexpected response from payment provider")
+func (b Builder) AttributeBytes(key string, data []byte, maxLen int) Builder {
+	if maxLen <= 0 || maxLen > MaxAttributeSize() {
+		maxLen = MaxAttributeSize()
+	}
+
+	b = b.Attribute(key, bytes.Clone(data[:min(len(data), maxLen)]))
+	if len(data) > maxLen {
+		b = b.markTruncated("attributes." + key)
+	}
+
+	return b
+}
+
+// AttributeReaderPeek adds up to n bytes from the provided reader as an attribute without consuming them.
+//
+// The bytes are peeked if r has a Peek(n int) ([]byte, error) method, as *bufio.Reader does, or read and
+// then unread by seeking back if r implements io.Seeker. Other readers are left untouched and no attribute
+// is added, since reading from them would lose the data for the caller; wrap them using bufio.NewReaderSize
+// to make them peekable. Fewer than n bytes are captured if the reader holds less data, or if n exceeds
+// the buffer size of a peekable reader. If n is less than or equal to zero or greater than
+// MaxAttributeSize, MaxAttributeSize is used. The value is stored as in AttributeBytes.
+// The bytes must be peeked before anything reads from r, and r, not the reader it wraps, must be used
+// for reading afterwards.
+//
+// Example:
+//
+//	body := bufio.NewReaderSize(r.Body, 4096)
+//	b := fail.New().AttributeReaderPeek("request.body", body, 1024)
+//	if err := json.NewDecoder(body).Decode(&req); err != nil {
+//		return b.Cause(err).Msg("invalid request body")
+//	}
+func (b Builder) AttributeReaderPeek(key string, r io.Reader, n int) Builder {
+	if r == nil {
+		return b
+	}
+
+	if n <= 0 || n > MaxAttributeSize() {
+		n = MaxAttributeSize()
+	}
+
+	if p, ok := r.(interface{ Peek(n int) ([]byte, error) }); ok {
+		data, _ := p.Peek(n)
+		return b.Attribute(key, bytes.Clone(data))
+	}
+
+	if s, ok := r.(io.ReadSeeker); ok {
+		offset, err := s.Seek(0, io.SeekCurrent)
+		if err != nil {
+			return b
+		}
+
+		data := make([]byte, n)
+		read, _ := io.ReadFull(s, data)
+		if _, err := s.Seek(offset, io.SeekStart); err != nil {
+			return b
+		}
+
+		return b.Attribute(key, data[:read:read])
+	}
+
+	return b
+}
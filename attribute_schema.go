@@ -0,0 +1,194 @@
+package fail
+
+import (
+	"fmt"
+	"reflect"
+	"strconv"
+	"sync/atomic"
+)
+
+// SchemaViolationsAttribute is the attribute key under which violations of the attribute schema are recorded.
+//
+// The value is a []string with one description per violation. It is only set with SchemaPolicyReport.
+const SchemaViolationsAttribute = "schema_violations"
+
+// SchemaPolicy determines what happens to attributes that violate the attribute schema.
+type SchemaPolicy int
+
+const (
+	// SchemaPolicyDrop drops attributes that violate the schema.
+	SchemaPolicyDrop SchemaPolicy = iota
+	// SchemaPolicyCoerce converts attributes to the type required by the schema where possible,
+	// for example an int to an int64 or a fmt.Stringer to a string, and drops them otherwise.
+	SchemaPolicyCoerce
+	// SchemaPolicyReport keeps attributes that violate the schema, and records a description
+	// of each violation under SchemaViolationsAttribute.
+	SchemaPolicyReport
+)
+
+// AttributeRule constrains the values of an attribute.
+type AttributeRule struct {
+	// Type is the type values must be assignable to. If nil, any type is accepted.
+	Type reflect.Type
+	// Validate is called with values of the correct type and returns an error if the value is invalid.
+	// If nil, all values of the correct type are accepted.
+	Validate func(value any) error
+}
+
+// AttributeSchema maps attribute keys to the rules their values must satisfy.
+//
+// Attributes whose keys are not part of the schema are not constrained.
+type AttributeSchema map[string]AttributeRule
+
+// AttributeOfType returns an AttributeRule requiring values to be assignable to T.
+//
+// Example:
+//
+//	fail.SetAttributeSchema(fail.AttributeSchema{
+//		"user_id": fail.AttributeOfType[string](),
+//	}, fail.SchemaPolicyCoerce)
+func AttributeOfType[T any]() AttributeRule {
+	return AttributeRule{Type: reflect.TypeFor[T]()}
+}
+
+// attributeSchema is the schema and policy enforced by Builder.Attribute and Builder.AttributeMap.
+type attributeSchema struct {
+	schema AttributeSchema
+	policy SchemaPolicy
+}
+
+var currentAttributeSchema atomic.Pointer[attributeSchema]
+
+// SetAttributeSchema sets the schema enforced on attributes added using Builder.Attribute and Builder.AttributeMap.
+//
+// Teams can use a schema to guarantee consistent attribute keys and types across an application,
+// for example that "user_id" is always a string. Values violating the schema are handled according
+// to the provided policy. Passing a nil or empty schema disables schema enforcement (the default).
+// The schema is copied, so later changes to it have no effect.
+// It is safe to call SetAttributeSchema concurrently.
+//
+// Example:
+//
+//	fail.SetAttributeSchema(fail.AttributeSchema{
+//		"user_id": fail.AttributeOfType[string](),
+//		"attempt": {
+//			Type: reflect.TypeFor[int](),
+//			Validate: func(v any) error {
+//				if v.(int) < 1 {
+//					return errors.New("must be positive")
+//				}
+//				return nil
+//			},
+//		},
+//	}, fail.SchemaPolicyDrop)
+func SetAttributeSchema(schema AttributeSchema, policy SchemaPolicy) {
+	if len(schema) == 0 {
+		currentAttributeSchema.Store(nil)
+		return
+	}
+
+	copied := make(AttributeSchema, len(schema))
+	for k, v := range schema {
+		copied[k] = v
+	}
+
+	currentAttributeSchema.Store(&attributeSchema{schema: copied, policy: policy})
+}
+
+// applyAttributeSchema checks the attribute against the current schema.
+//
+// It returns the value to store, whether the attribute should be stored at all,
+// and a description of the violation, if any, to be recorded.
+func applyAttributeSchema(key string, value any) (any, bool, string) {
+	s := currentAttributeSchema.Load()
+	if s == nil || value == nil {
+		return value, true, ""
+	}
+
+	rule, ok := s.schema[key]
+	if !ok {
+		return value, true, ""
+	}
+
+	violation := checkAttributeRule(rule, value)
+	if violation == "" {
+		return value, true, ""
+	}
+
+	switch s.policy {
+	case SchemaPolicyCoerce:
+		if coerced, ok := coerceAttribute(value, rule.Type); ok && checkAttributeRule(rule, coerced) == "" {
+			return coerced, true, ""
+		}
+		return nil, false, ""
+	case SchemaPolicyReport:
+		return value, true, fmt.Sprintf("%s: %s", key, violation)
+	default:
+		return nil, false, ""
+	}
+}
+
+// checkAttributeRule returns a description of how value violates rule, or an empty string if it does not.
+func checkAttributeRule(rule AttributeRule, value any) string {
+	if rule.Type != nil && !reflect.TypeOf(value).AssignableTo(rule.Type) {
+		return fmt.Sprintf("expected %s, got %T", rule.Type, value)
+	}
+
+	if rule.Validate != nil {
+		if err := rule.Validate(value); err != nil {
+			return err.Error()
+		}
+	}
+
+	return ""
+}
+
+// coerceAttribute converts value to type t, if a sensible conversion exists.
+func coerceAttribute(value any, t reflect.Type) (any, bool) {
+	if t == nil {
+		return nil, false
+	}
+
+	v := reflect.ValueOf(value)
+	switch t.Kind() {
+	case reflect.String:
+		// reflect converts integers to strings as runes, which is never intended here.
+		switch value := value.(type) {
+		case fmt.Stringer:
+			return reflect.ValueOf(value.String()).Convert(t).Interface(), true
+		case error:
+			return reflect.ValueOf(value.Error()).Convert(t).Interface(), true
+		}
+
+		switch v.Kind() {
+		case reflect.String, reflect.Bool, reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+			reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Float32, reflect.Float64:
+			return reflect.ValueOf(fmt.Sprint(value)).Convert(t).Interface(), true
+		}
+
+		return nil, false
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Float32, reflect.Float64:
+		if v.Kind() == reflect.String {
+			f, err := strconv.ParseFloat(v.String(), 64)
+			if err != nil {
+				return nil, false
+			}
+			v = reflect.ValueOf(f)
+		}
+
+		switch v.Kind() {
+		case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+			reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Float32, reflect.Float64:
+			return v.Convert(t).Interface(), true
+		}
+
+		return nil, false
+	}
+
+	if v.Type().ConvertibleTo(t) {
+		return v.Convert(t).Interface(), true
+	}
+
+	return nil, false
+}
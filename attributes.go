@@ -1,6 +1,10 @@
 package fail
 
-import "context"
+import (
+	"context"
+	"sort"
+	"strings"
+)
 
 // ErrorAttributes is an error type that provides a set of key-value attributes associated with the error.
 //
@@ -129,3 +133,75 @@ func AttributesFromContext(ctx context.Context) map[string]any {
 	}
 	return attrs
 }
+
+// AttributeGroupSeparator separates the prefix of a grouped attribute from its key.
+//
+// Attributes added using Builder.AttributeGroup are stored under "prefix.key", and renderers
+// that support nesting, such as the JSON printer and LogValue, split keys on this separator.
+const AttributeGroupSeparator = "."
+
+// NestAttributes returns the attributes with grouped keys nested into maps.
+//
+// Keys are split on AttributeGroupSeparator, so that {"http.method": "GET", "http.url": "/"}
+// becomes {"http": {"method": "GET", "url": "/"}}. If a key is both a value and a group prefix,
+// such as "http" and "http.method", the value is kept under "http" and the grouped attribute
+// stays flat under "http.method". The input map is not modified.
+//
+// Example:
+//
+//	nested := fail.NestAttributes(fail.Attributes(err))
+func NestAttributes(attrs map[string]any) map[string]any {
+	keys := make([]string, 0, len(attrs))
+	for key := range attrs {
+		keys = append(keys, key)
+	}
+	// Sorting guarantees that a value is placed before any group sharing its key.
+	sort.Strings(keys)
+
+	nested := make(map[string]any, len(attrs))
+	for _, key := range keys {
+		m := nested
+		path := strings.Split(key, AttributeGroupSeparator)
+		for i, part := range path[:len(path)-1] {
+			group, ok := m[part].(map[string]any)
+			if !ok {
+				if _, exists := m[part]; exists {
+					path = append(path[:i:i], strings.Join(path[i:], AttributeGroupSeparator))
+					break
+				}
+
+				group = map[string]any{}
+				m[part] = group
+			}
+			m = group
+		}
+
+		m[path[len(path)-1]] = attrs[key]
+	}
+
+	return nested
+}
+
+// flattenAttributes reverses NestAttributes, joining the keys of nested maps with AttributeGroupSeparator.
+func flattenAttributes(attrs map[string]any) map[string]any {
+	flat := make(map[string]any, len(attrs))
+	flattenAttributesInto(flat, "", attrs)
+
+	return flat
+}
+
+// flattenAttributesInto adds attrs to flat, prefixing each key with prefix.
+func flattenAttributesInto(flat map[string]any, prefix string, attrs map[string]any) {
+	for key, value := range attrs {
+		if prefix != "" {
+			key = prefix + AttributeGroupSeparator + key
+		}
+
+		if group, ok := value.(map[string]any); ok && len(group) > 0 {
+			flattenAttributesInto(flat, key, group)
+			continue
+		}
+
+		flat[key] = value
+	}
+}
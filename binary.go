@@ -0,0 +1,108 @@
+package fail
+
+import (
+	"encoding/json"
+	"errors"
+)
+
+// MarshalBinary encodes the provided error and its causes and associated errors as CBOR (RFC 8949).
+//
+// The encoding is a compact binary representation of the Snapshot returned by fail.Details, using the
+// same field names as its JSON representation, and therefore covers all metadata fields of the error
+// tree. Times are encoded as RFC 3339 text strings, and attribute values as their JSON representation.
+// Errors that are not Fail errors are encoded with the information available through the accessor
+// functions of this package, which for plain errors is only their message. Use UnmarshalBinary to
+// decode the result.
+//
+// Example:
+//
+//	data, err := fail.MarshalBinary(err)
+func MarshalBinary(err error) ([]byte, error) {
+	return cborMarshalJSON(Details(err))
+}
+
+// MarshalBinary encodes the Fail as CBOR. See fail.MarshalBinary for details.
+//
+// This method implements encoding.BinaryMarshaler.
+func (f Fail) MarshalBinary() ([]byte, error) {
+	return MarshalBinary(f)
+}
+
+// UnmarshalBinary decodes an error tree encoded with MarshalBinary back into a Fail.
+//
+// All fields of the encoded tree are restored, including nested causes and associated errors,
+// which are always restored as Fail errors. Nesting beyond MaxDepth is dropped. Like FromJson,
+// UnmarshalBinary accepts data of any SchemaVersion: unknown fields are ignored and fields with an
+// unexpected type are skipped.
+//
+// Example:
+//
+//	f, err := fail.UnmarshalBinary(data)
+func UnmarshalBinary(data []byte) (Fail, error) {
+	// The decoder decodes all other fields before reporting a field with an unexpected type.
+	var s Snapshot
+	var typeErr *json.UnmarshalTypeError
+	if err := cborUnmarshalJSON(data, &s); err != nil && !errors.As(err, &typeErr) {
+		return Fail{}, err
+	}
+
+	return fromSnapshot(s, 0), nil
+}
+
+// fromSnapshot converts a Snapshot back into a Fail, tracking the nesting depth of causes and associated errors.
+func fromSnapshot(s Snapshot, depth int) Fail {
+	b := New().
+		UserMsg(s.UserMsg).
+		Op(s.Op).
+		HelpURL(s.HelpURL).
+		FieldErrors(s.Fields...).
+		Code(s.Code).
+		Domain(s.Domain).
+		TagSlice(s.Tags).
+		AttributeMap(s.Attrs).
+		TraceId(s.TraceId).
+		SpanId(s.SpanId).
+		CorrelationId(s.CorrelationId).
+		Id(s.Id).
+		Time(s.Time).
+		Duration(s.Duration).
+		Retryable(s.Retryable).
+		Transience(s.Transience).
+		RetryAfter(s.RetryAfter)
+
+	b.msg = s.Msg
+	if b.msg == "" {
+		b.msg = EmptyMessage
+	}
+
+	b.source = s.Source
+
+	// Codes derived from the code, domain or defaults are restored without marking them as set explicitly.
+	if s.ExitCodeInferred {
+		if s.ExitCode > 0 {
+			b.exitCode = s.ExitCode
+		}
+	} else {
+		b = b.ExitCode(s.ExitCode)
+	}
+
+	if s.HTTPStatusInferred {
+		if validHttpStatusCode(s.HTTPStatus) {
+			b.httpStatusCode = s.HTTPStatus
+		}
+	} else {
+		b = b.HttpStatusCode(s.HTTPStatus)
+	}
+
+	if depth < MaxDepth() {
+		for _, cause := range limitWidth(s.Causes) {
+			b = b.CauseLabeled(cause.Label, fromSnapshot(cause, depth+1))
+		}
+
+		for _, associated := range limitWidth(s.Associated) {
+			b = b.AssociateRole(associated.Role, fromSnapshot(associated, depth+1))
+		}
+	}
+
+	return b.asFail()
+}
@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"time"
 
+	"github.com/FlowSeer/wz/slices"
 	"go.opentelemetry.io/otel/trace"
 )
 
@@ -31,6 +32,7 @@ import (
 //		Associate(loggingError).
 //		TraceId("abcdef1234567890").
 //		SpanId("1234567890abcdef").
+//		Caller(0).
 //		Msg("database connection failed")
 type Builder Fail
 
@@ -73,34 +75,82 @@ func NewC(ctx context.Context) Builder {
 // including: message, user message, code, exit code, HTTP status code, causes, associated errors,
 // tags, and attributes. Panics if err is nil.
 //
+// Errors created by fmt.Errorf with the %w verb are decomposed into a structured tree: the wrapped
+// errors become the causes, and the message only keeps the text added by the wrapping error, as long
+// as it ends with ": " followed by the message of its single cause. Wrapped errors that were themselves
+// created by fmt.Errorf are decomposed recursively, up to MaxDepth levels. Other error types are never
+// decomposed, so that errors.Is and errors.As still find them among the causes (see Fail.Unwrap).
+//
 // Example:
 //
 //	err := someFunction()
 //	failErr := fail.From(err).Msg("operation failed")
+//
+//	// A Fail with the message "load config" and the cause os.ErrNotExist
+//	b := fail.From(fmt.Errorf("load config: %w", os.ErrNotExist))
 func From(err error) Builder {
 	if err == nil {
 		panic("cannot create a Fail from a nil error")
 	}
 
+	return from(err, MaxDepth())
+}
+
+// fromOrigin creates a new Builder initialized from an existing error, like From, and keeps an error
+// that is not a Fail as the origin returned by Fail.Unwrap.
+//
+// It is used by functions that only add information to an error, so that errors.Is and errors.As
+// still match the original error, such as a sentinel error, after the conversion.
+func fromOrigin(err error) Builder {
+	b := From(err)
+	if _, ok := err.(Fail); !ok {
+		b.origin = err
+	}
+
+	return b
+}
+
+// from implements From, decomposing fmt.Errorf wrapping chains up to depth levels.
+func from(err error, depth int) Builder {
 	if f, ok := err.(Fail); ok {
 		return Builder(f.Clone())
 	}
 
-	attrs := make(map[string]struct{})
-	for _, t := range Tags(err) {
-		attrs[t] = struct{}{}
+	msg := Message(err)
+	causes := Causes(err)
+	if depth > 0 && isFmtWrap(err) {
+		msg, causes = decomposeFmtWrap(msg, causes, depth)
 	}
 
+	_, exitCodeSet := explicitExitCode(err)
+	_, httpStatusCodeSet := explicitHttpStatusCode(err)
+
 	return Builder(Fail{
-		msg:            Message(err),
-		userMsg:        UserMessage(err),
-		code:           Code(err),
-		exitCode:       ExitCode(err),
-		httpStatusCode: HttpStatusCode(err),
-		causes:         Causes(err),
-		associated:     Associated(err),
-		tags:           attrs,
-		attrs:          Attributes(err),
+		id:                ownId(err),
+		msg:               msg,
+		userMsg:           ownUserMessage(err),
+		op:                Op(err),
+		helpURL:           HelpURL(err),
+		fields:            Fields(err),
+		correlationId:     CorrelationId(err),
+		domain:            ownDomain(err),
+		code:              code(err, 0, newVisitSet()),
+		exitCode:          ExitCode(err),
+		exitCodeSet:       exitCodeSet,
+		httpStatusCode:    HttpStatusCode(err),
+		httpStatusCodeSet: httpStatusCodeSet,
+		causes:            causes,
+		causeLabels:       CauseLabels(err),
+		associated:        Associated(err),
+		assocRoles:        AssociatedRoles(err),
+		tags:              tagList{}.add(Tags(err)...),
+		attrs:             attrList{}.set(Attributes(err)),
+		source:            Source(err),
+		duration:          Duration(err),
+		transience:        Transience(err),
+		retryAfter:        RetryAfter(err),
+		handled:           ownHandled(err),
+		reported:          ownReported(err),
 	})
 }
 
@@ -122,6 +172,192 @@ func (b Builder) Time(t time.Time) Builder {
 	return b
 }
 
+// Caller sets the source location of the error to the caller skip frames above the call to Caller.
+//
+// A skip of 0 records the location of the function calling Caller, 1 the location of its caller, and so on.
+// This is useful for helper functions that construct errors on behalf of their callers.
+// If Caller is not used and automatic capture is enabled (see SetCaptureSource), the source location
+// is set to the first caller outside of this package when the error is built using Msg() or Msgf().
+//
+// Example:
+//
+//	func notFound(what string) error {
+//		return fail.New().
+//			Caller(1).
+//			Msgf("%s not found", what)
+//	}
+func (b Builder) Caller(skip int) Builder {
+	if skip >= 0 {
+		if source := callerSource(skip + 1); !source.IsZero() {
+			b.source = source
+		}
+	}
+
+	return b
+}
+
+// Duration sets how long the failed operation had been running when the error occurred.
+//
+// Only positive durations are accepted; zero or negative values are ignored.
+//
+// Example:
+//
+//	err := fail.New().
+//		Duration(1500 * time.Millisecond).
+//		Msg("request failed")
+func (b Builder) Duration(d time.Duration) Builder {
+	if d > 0 {
+		b.duration = d
+	}
+
+	return b
+}
+
+// Since sets the duration of the failed operation to the time elapsed since start.
+//
+// This is a convenience wrapper around Duration() for the common pattern of recording
+// the start time of an operation and building an error when it fails.
+// A zero start time is ignored.
+//
+// Example:
+//
+//	start := time.Now()
+//	if err := doWork(); err != nil {
+//		return fail.New().
+//			Since(start).
+//			Cause(err).
+//			Msg("work failed")
+//	}
+func (b Builder) Since(start time.Time) Builder {
+	if start.IsZero() {
+		return b
+	}
+
+	return b.Duration(time.Since(start))
+}
+
+// Retryable marks whether the failed operation may succeed when retried.
+//
+// Transient failures, such as timeouts, dropped connections or serialization conflicts, should be
+// marked as retryable so that callers can decide to retry them using fail.Retryable.
+// Retryable(true) is equivalent to Transient(). Retryable(false) removes a transient classification,
+// but does not mark the error as permanent; use Permanent() for that.
+//
+// Example:
+//
+//	err := fail.New().
+//		Retryable(true).
+//		Msg("connection reset by peer")
+func (b Builder) Retryable(retryable bool) Builder {
+	if retryable {
+		b.transience = TransienceTransient
+	} else if b.transience == TransienceTransient {
+		b.transience = TransienceUnknown
+	}
+
+	return b
+}
+
+// Transient marks the failure as transient, meaning the operation may succeed when retried.
+//
+// Transient errors are retried by fail.Retry and reported as retryable by fail.Retryable.
+//
+// Example:
+//
+//	err := fail.New().
+//		Transient().
+//		Msg("connection reset by peer")
+func (b Builder) Transient() Builder {
+	b.transience = TransienceTransient
+	return b
+}
+
+// Permanent marks the failure as permanent, meaning the operation will fail again when retried unchanged.
+//
+// A permanent error is never retryable, even if some of its causes are transient, and it makes
+// errors wrapping it non-retryable as well.
+//
+// Example:
+//
+//	err := fail.New().
+//		Permanent().
+//		Cause(validationErr).
+//		Msg("invalid order")
+func (b Builder) Permanent() Builder {
+	b.transience = TransiencePermanent
+	return b
+}
+
+// Handled marks the error as handled, meaning a lower layer has already dealt with it.
+//
+// Handled errors are reported as such by fail.Handled, also when they are wrapped by other errors.
+// See fail.MarkHandled for details.
+//
+// Example:
+//
+//	err := fail.New().
+//		Handled().
+//		Cause(err).
+//		Msg("falling back to cached prices")
+func (b Builder) Handled() Builder {
+	b.handled = true
+	return b
+}
+
+// Reported marks the error as reported, meaning it has already been logged or sent to an error tracker.
+//
+// Reported errors are reported as such by fail.Reported, also when they are wrapped by other errors.
+// See fail.MarkReported for details.
+//
+// Example:
+//
+//	logger.Error("failed to charge card", "error", err)
+//	return fail.From(err).Reported().Msg("failed to charge card")
+func (b Builder) Reported() Builder {
+	b.reported = true
+	return b
+}
+
+// Transience sets the transience of the failure.
+//
+// This is equivalent to calling Transient() or Permanent(), or resetting the transience
+// if TransienceUnknown is provided. Unknown values are ignored.
+//
+// Example:
+//
+//	err := fail.New().
+//		Transience(fail.TransiencePermanent).
+//		Msg("invalid order")
+func (b Builder) Transience(transience TransienceKind) Builder {
+	switch transience {
+	case TransienceUnknown, TransienceTransient, TransiencePermanent:
+		b.transience = transience
+	}
+
+	return b
+}
+
+// RetryAfter sets the minimum duration callers should wait before retrying the failed operation.
+//
+// This gives rate-limit and unavailability errors machine-readable backoff guidance, which is
+// emitted as the Retry-After header by the failhttp package.
+// Only positive durations are accepted; zero or negative values are ignored.
+//
+// Example:
+//
+//	err := fail.New().
+//		Code(fail.ErrCodeRateLimited).
+//		HttpStatusCode(429).
+//		RetryAfter(30 * time.Second).
+//		Msg("rate limit exceeded")
+func (b Builder) RetryAfter(d time.Duration) Builder {
+	if d > 0 {
+		b.retryAfter = d
+	}
+
+	return b
+}
+
 // Associate adds one or more associated errors to the builder.
 // Associated errors are related errors that provide additional context but are not direct causes.
 //
@@ -167,6 +403,29 @@ func (b Builder) AssociateSlice(errs []error) Builder {
 	return b
 }
 
+// AssociateRole adds an associated error with a role to the builder.
+//
+// A role is a short string, such as RoleCleanup, RoleLogging or RoleCompensation, that describes
+// why the error is attached. Roles can be retrieved using AssociatedRoles, and associated errors
+// with a given role using AssociatedWithRole. If err is nil, the builder is returned unchanged.
+//
+// Example:
+//
+//	err := fail.New().
+//		Cause(writeErr).
+//		AssociateRole(fail.RoleCleanup, closeErr).
+//		Msg("file upload failed")
+func (b Builder) AssociateRole(role string, err error) Builder {
+	if err == nil {
+		return b
+	}
+
+	b.assocRoles = b.assocRoles.with(len(b.associated), role)
+	b.associated = append(b.associated, err)
+
+	return b
+}
+
 // Cause adds one or more cause errors to the builder.
 //
 // A cause error is an error that directly led to this error and represent the underlying reasons for the current error.
@@ -203,6 +462,82 @@ func (b Builder) CauseSlice(errs []error) Builder {
 	return b
 }
 
+// CauseLabeled adds a cause error with a label to the builder.
+//
+// A label is a short string, such as "primary", "rollback" or "node-3", that describes the role
+// of the cause. Printers and the JSON output render the label next to the cause, which improves
+// the readability of errors with several causes. Labels can be retrieved using CauseLabels.
+// If err is nil, the builder is returned unchanged.
+//
+// Example:
+//
+//	err := fail.New().
+//		CauseLabeled("primary", writeErr).
+//		CauseLabeled("rollback", rollbackErr).
+//		Msg("transaction failed")
+func (b Builder) CauseLabeled(label string, err error) Builder {
+	if err == nil {
+		return b
+	}
+
+	b.causeLabels = b.causeLabels.with(len(b.causes), label)
+	b.causes = append(b.causes, err)
+
+	return b
+}
+
+// DedupeCauses enables or disables the removal of identical causes when the error is built.
+//
+// Two causes are considered identical if errors.Is reports them as equal, or if they share the
+// same message, code and domain. Only the first occurrence of each cause is kept.
+// This keeps errors aggregated from fan-out operations, where many branches fail for the same reason, small.
+//
+// Example:
+//
+//	err := fail.New().
+//		DedupeCauses(true).
+//		CauseSlice(workerErrors).
+//		Msg("batch failed")
+func (b Builder) DedupeCauses(dedupe bool) Builder {
+	b.dedupeCauses = dedupe
+	return b
+}
+
+// Verbose enables or disables verbose mode for the error string of this error.
+//
+// In verbose mode, Error renders the message of the error and its causes on a single line in the
+// style of fmt.Errorf wrapping, as "msg: cause1: cause2". See SetVerbose to enable verbose mode
+// for all errors; an error is rendered verbosely if either is enabled.
+//
+// Example:
+//
+//	err := fail.New().
+//		Verbose(true).
+//		Cause(io.ErrUnexpectedEOF).
+//		Msg("failed to read config")
+//	// err.Error() == "failed to read config: unexpected EOF"
+func (b Builder) Verbose(verbose bool) Builder {
+	b.verbose = verbose
+	return b
+}
+
+// MaxCauses limits the number of causes kept when the error is built.
+//
+// Causes beyond the limit are dropped, and the number of dropped causes is recorded as the
+// OmittedCausesAttribute attribute, so that printers can render an "and N more" summary.
+// A limit of zero or less disables the limit. The limit is applied after deduplication.
+//
+// Example:
+//
+//	err := fail.New().
+//		MaxCauses(10).
+//		CauseSlice(workerErrors).
+//		Msg("batch failed")
+func (b Builder) MaxCauses(n int) Builder {
+	b.maxCauses = max(n, 0)
+	return b
+}
+
 // Tag adds one or more tags to the builder.
 //
 // A tag is a string label that can be used for categorization or filtering and provide a way to categorize errors for logging, monitoring, or error handling purposes.
@@ -228,11 +563,7 @@ func (b Builder) Tag(tags ...string) Builder {
 //		TagSlice(tags).
 //		Msg("database connection failed")
 func (b Builder) TagSlice(tags []string) Builder {
-	for _, tag := range tags {
-		if tag != "" {
-			b.tags[tag] = struct{}{}
-		}
-	}
+	b.tags = b.tags.add(tags...)
 	return b
 }
 
@@ -255,11 +586,78 @@ func (b Builder) Domain(domain string) Builder {
 	return b
 }
 
+// Op sets the name of the operation that failed, such as "userdb.Get".
+//
+// The operation is kept separate from the message so that it can be matched programmatically.
+// The pretty printer renders it as a prefix of the message, producing compact chains like
+// "api.CreateUser: failed to create user" followed by "userdb.Insert: duplicate key" for the cause.
+// If the provided operation is an empty string, the builder's operation is not changed.
+//
+// Example:
+//
+//	err := fail.New().
+//		Op("userdb.Get").
+//		Msg("user not found")
+func (b Builder) Op(op string) Builder {
+	if op != "" {
+		b.op = op
+	}
+
+	return b
+}
+
+// HelpURL sets a link to documentation about the error, such as a runbook or a support article.
+//
+// The link is rendered by the printers and included in JSON and problem+json output,
+// so that users and operators can find out how to resolve the error.
+//
+// Example:
+//
+//	err := fail.New().
+//		Code("ERR_QUOTA_EXCEEDED").
+//		HelpURL("https://docs.example.com/errors/quota").
+//		Msg("quota exceeded")
+func (b Builder) HelpURL(url string) Builder {
+	if url != "" {
+		b.helpURL = url
+	}
+
+	return b
+}
+
+// FieldErrors adds field-level details of a validation failure to the error.
+//
+// Field errors describe which fields of a form or request are invalid and why. They are rendered
+// by the printers and included as a "fields" array in JSON and problem+json output, so that clients
+// can display them next to the respective fields. Field errors with neither a field nor a message
+// are ignored.
+//
+// Example:
+//
+//	err := fail.New().
+//		Code(fail.ErrCodeValidation).
+//		FieldErrors(
+//			fail.FieldError{Field: "email", Code: "required", Msg: "must not be empty"},
+//			fail.FieldError{Field: "age", Code: "out_of_range", Msg: "must be at least 18"},
+//		).
+//		Msg("invalid registration")
+func (b Builder) FieldErrors(fields ...FieldError) Builder {
+	for _, field := range fields {
+		if field.Field != "" || field.Msg != "" {
+			// Clip the slice so that builders derived from the same builder do not share additions.
+			b.fields = append(b.fields[:len(b.fields):len(b.fields)], field)
+		}
+	}
+
+	return b
+}
+
 // Attribute adds a key-value attribute to the builder.
 //
 // An attribute is a key-value pair that provides additional structured context and allow you to attach arbitrary data to errors for debugging, logging, or monitoring purposes.
 //
 // Attributes can contain any type of value (interface{}), making them flexible for storing various types of contextual information such as request IDs, user IDs, timestamps, or other relevant data.
+// If an attribute schema is set using SetAttributeSchema, the value is checked against it.
 //
 // Example:
 //
@@ -269,12 +667,22 @@ func (b Builder) Domain(domain string) Builder {
 //		Attribute("attempt_count", 3).
 //		Msg("user authentication failed")
 func (b Builder) Attribute(key string, value any) Builder {
-	return b.AttributeMap(map[string]any{key: value})
+	value, ok, violation := applyAttributeSchema(key, value)
+	if ok {
+		b.attrs = b.attrs.with(key, value)
+	}
+
+	if violation != "" {
+		b = b.schemaViolation(violation)
+	}
+
+	return b
 }
 
 // AttributeMap adds a map of key-value attributes to the builder.
 //
 // An attribute is a key-value pair that provides additional structured context.
+// If an attribute schema is set using SetAttributeSchema, each value is checked against it.
 //
 // Example:
 //
@@ -287,11 +695,47 @@ func (b Builder) Attribute(key string, value any) Builder {
 //		AttributeMap(attrs).
 //		Msg("user authentication failed")
 func (b Builder) AttributeMap(attrs map[string]any) Builder {
+	if currentAttributeSchema.Load() == nil {
+		b.attrs = b.attrs.set(attrs)
+		return b
+	}
+
 	for key, value := range attrs {
-		if key != "" && value != nil {
-			b.attrs[key] = value
-		}
+		b = b.Attribute(key, value)
 	}
+
+	return b
+}
+
+// AttributeGroup adds a group of related attributes to the builder.
+//
+// Each attribute is stored under prefix and its key joined by AttributeGroupSeparator, so that
+// related attributes such as "http.method" and "http.url" can be grouped. Values that are
+// themselves of type map[string]any are added as nested groups. The JSON printer and LogValue
+// render groups as nested objects. If prefix is empty, the attributes are added ungrouped.
+//
+// Example:
+//
+//	err := fail.New().
+//		AttributeGroup("http", map[string]any{
+//			"method": r.Method,
+//			"url":    r.URL.String(),
+//		}).
+//		Msg("request failed")
+func (b Builder) AttributeGroup(prefix string, attrs map[string]any) Builder {
+	flat := make(map[string]any, len(attrs))
+	flattenAttributesInto(flat, prefix, attrs)
+
+	return b.AttributeMap(flat)
+}
+
+// schemaViolation records a violation of the attribute schema under SchemaViolationsAttribute.
+func (b Builder) schemaViolation(violation string) Builder {
+	violations, _ := b.attrs.get(SchemaViolationsAttribute)
+	list, _ := violations.([]string)
+	list = append(list[:len(list):len(list)], violation)
+
+	b.attrs = b.attrs.with(SchemaViolationsAttribute, list)
 	return b
 }
 
@@ -324,7 +768,7 @@ func (b Builder) Code(code string) Builder {
 //		Msg("configuration file not found")
 func (b Builder) ExitCode(exitCode int) Builder {
 	if exitCode > 0 {
-		b.exitCode = exitCode
+		b.exitCode, b.exitCodeSet = exitCode, true
 	}
 	return b
 }
@@ -332,7 +776,8 @@ func (b Builder) ExitCode(exitCode int) Builder {
 // HttpStatusCode sets an HTTP status code for the error, if in the 400-599 range.
 //
 // The HTTP status code represents the HTTP response status that should be returned when this error occurs in an HTTP context.
-// Only status codes in the 400-599 range (client and server errors) are accepted.
+// Only status codes in the 400-599 range (client and server errors) are accepted, unless relaxed using
+// SetRelaxedHttpStatusCodes, in which case status codes in the 200-599 range are accepted.
 //
 // Example:
 //
@@ -340,8 +785,8 @@ func (b Builder) ExitCode(exitCode int) Builder {
 //		HttpStatusCode(404).
 //		Msg("user not found")
 func (b Builder) HttpStatusCode(httpStatusCode int) Builder {
-	if httpStatusCode >= 400 && httpStatusCode < 600 {
-		b.httpStatusCode = httpStatusCode
+	if validHttpStatusCode(httpStatusCode) {
+		b.httpStatusCode, b.httpStatusCodeSet = httpStatusCode, true
 	}
 	return b
 }
@@ -382,13 +827,66 @@ func (b Builder) SpanId(spanId string) Builder {
 	return b
 }
 
-// Context extracts tags, attributes, span ID, and trace ID from the provided context.Context and adds them to the builder, if present.
+// CorrelationId sets the correlation ID of the request or job that failed.
+//
+// A correlation ID, often called request ID, identifies a unit of work across services and log lines.
+// It is independent of distributed tracing and useful for systems that correlate by request ID only.
+// Empty IDs are ignored.
+//
+// Example:
+//
+//	err := fail.New().
+//		CorrelationId(r.Header.Get("X-Request-Id")).
+//		Msg("request failed")
+func (b Builder) CorrelationId(correlationId string) Builder {
+	if correlationId != "" {
+		b.correlationId = correlationId
+	}
+
+	return b
+}
+
+// Id sets the unique ID of the error instance.
+//
+// Every error is assigned a new time-ordered ID when it is built using Msg() or Msgf(), so Id is only
+// needed to preserve the ID of an error that is restored or converted, for example when decoding it
+// from JSON. Builders created using From keep the ID of the Fail they were created from.
+// Empty IDs are ignored.
+//
+// Example:
+//
+//	err := fail.New().
+//		Id(snapshot.Id).
+//		Msg(snapshot.Msg)
+func (b Builder) Id(id string) Builder {
+	if id != "" {
+		b.id = id
+	}
+
+	return b
+}
+
+// Context extracts tags, attributes, scope, span ID, trace ID, correlation ID and request-scoped defaults from the provided context.Context and adds them to the builder, if present.
 //
 // This method automatically extracts error-related information from the context using the following functions:
 //   - TagsFromContext(): Extracts tags stored in the context
 //   - AttributesFromContext(): Extracts attributes stored in the context
+//   - ScopeFromContext(): Extracts the scope stored in the context and adds it as the ScopeAttribute attribute
 //   - SpanIdFromContext(): Extracts the span ID from OpenTelemetry span in the context
 //   - TraceIdFromContext(): Extracts the trace ID from OpenTelemetry span in the context
+//   - CorrelationIdFromContext(): Extracts the correlation ID from the context or OpenTelemetry baggage
+//
+// The following request-scoped defaults are applied only if the corresponding value has not been set
+// on the builder (or still holds its package default):
+//   - DomainFromContext(): The domain stored in the context
+//   - CodeFromContext(): The error code stored in the context
+//   - UserMsgFromContext(): The user-facing message stored in the context
+//   - HttpStatusCodeFromContext(): The HTTP status code stored in the context
+//
+// If the context is already done, the reason (see context.Cause) is added as a cause unless an
+// existing cause already matches it. Deadline expiry sets the domain to DomainTimeout (if no domain
+// is set) and adds TagTimeout, cancellation adds TagCanceled, and the context's deadline, if any,
+// is recorded as the DeadlineAttribute attribute.
 //
 // This is useful for propagating error context through request lifecycles or operation
 // chains without manually passing each component.
@@ -415,6 +913,11 @@ func (b Builder) Context(ctx context.Context) Builder {
 		res = res.AttributeMap(attrs)
 	}
 
+	scope := ScopeFromContext(ctx)
+	if scope != "" {
+		res = res.Attribute(ScopeAttribute, scope)
+	}
+
 	spanId := SpanIdFromContext(ctx)
 	if spanId != "" {
 		res = res.SpanId(spanId)
@@ -425,7 +928,29 @@ func (b Builder) Context(ctx context.Context) Builder {
 		res = res.TraceId(traceId)
 	}
 
-	return res
+	correlationId := CorrelationIdFromContext(ctx)
+	if correlationId != "" {
+		res = res.CorrelationId(correlationId)
+	}
+
+	// The following values are request-scoped defaults and do not override values set explicitly.
+	if domain := DomainFromContext(ctx); domain != "" && res.domain == "" {
+		res = res.Domain(domain)
+	}
+
+	if code := CodeFromContext(ctx); code != "" && (res.code == "" || res.code == ErrCodeUnspecified) {
+		res = res.Code(code)
+	}
+
+	if userMsg := UserMsgFromContext(ctx); userMsg != "" && res.userMsg == "" {
+		res = res.UserMsg(userMsg)
+	}
+
+	if status := HttpStatusCodeFromContext(ctx); status != 0 && !res.httpStatusCodeSet {
+		res = res.HttpStatusCode(status)
+	}
+
+	return res.contextDone(ctx)
 }
 
 // UserMsg sets a user-facing message for the error.
@@ -468,6 +993,13 @@ func (b Builder) UserMsgf(format string, args ...any) Builder {
 //
 // The developer message is the main error message and is required.
 // If omitted, the message will be set to fail.EmptyMessage.
+// Unless an ID was set using Id(), the error is assigned a new unique instance ID (see fail.Id).
+// If no source location was set using Caller() and automatic capture is enabled, the location of the first caller outside of this package is recorded,
+// unless the error is not sampled by the Sampler set using SetSampler.
+// The global attributes (see SetGlobalAttributes) are then added and, unless the error is not sampled,
+// the hooks registered using AddHook are applied.
+// Messages, tags and attribute values exceeding the size limits (see SetMaxMessageLength,
+// SetMaxAttributeSize and SetMaxTags) are truncated.
 // It provides a concise, stable, and programmatically useful message that describes only the primary error itself, without including details from any wrapped errors or underlying causes.
 // This method is terminal and completes the error construction.
 //
@@ -487,6 +1019,30 @@ func (b Builder) Msg(msg string) error {
 		b.time = time.Now()
 	}
 
+	if b.id == "" {
+		b.id = NewId()
+	}
+
+	b = b.applyCodeInfo()
+
+	sampled := sample(b.code)
+	if b.source.IsZero() && CaptureSource() && sampled {
+		b.source = externalSource()
+	}
+
+	if b.dedupeCauses {
+		b.causes, b.causeLabels = dedupeCauses(b.causes, b.causeLabels)
+	}
+
+	if b.maxCauses > 0 && len(b.causes) > b.maxCauses {
+		b = b.Attribute(OmittedCausesAttribute, len(b.causes)-b.maxCauses)
+		b.causes = slices.Clip(b.causes[:b.maxCauses])
+		b.causeLabels = labelList(b.causeLabels.aligned(b.maxCauses))
+	}
+
+	b = b.applyHooks(sampled)
+	b = b.applySizeLimits()
+
 	return Fail(b)
 }
 
@@ -0,0 +1,62 @@
+package fail_test
+
+import (
+	"strconv"
+	"testing"
+
+	"github.com/FlowSeer/fail"
+)
+
+func BenchmarkNew(b *testing.B) {
+	b.ReportAllocs()
+	for b.Loop() {
+		_ = fail.New()
+	}
+}
+
+func BenchmarkMsg(b *testing.B) {
+	b.ReportAllocs()
+	for b.Loop() {
+		_ = fail.New().Msg("user not found")
+	}
+}
+
+func BenchmarkAttribute(b *testing.B) {
+	for _, n := range []int{1, 8, 64, 512} {
+		keys := make([]string, n)
+		for i := range keys {
+			keys[i] = "key" + strconv.Itoa(i)
+		}
+
+		b.Run(strconv.Itoa(n), func(b *testing.B) {
+			b.ReportAllocs()
+			for b.Loop() {
+				builder := fail.New()
+				for i, key := range keys {
+					builder = builder.Attribute(key, i)
+				}
+				_ = builder.Msg("user not found")
+			}
+		})
+	}
+}
+
+func BenchmarkTag(b *testing.B) {
+	for _, n := range []int{1, 8, 64, 512} {
+		tags := make([]string, n)
+		for i := range tags {
+			tags[i] = "tag" + strconv.Itoa(i)
+		}
+
+		b.Run(strconv.Itoa(n), func(b *testing.B) {
+			b.ReportAllocs()
+			for b.Loop() {
+				builder := fail.New()
+				for _, tag := range tags {
+					builder = builder.Tag(tag)
+				}
+				_ = builder.Msg("user not found")
+			}
+		})
+	}
+}
@@ -1,5 +1,16 @@
 package fail
 
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"slices"
+	"strings"
+)
+
+// OmittedCausesAttribute is the attribute key under which the number of causes dropped by Builder.MaxCauses is recorded.
+const OmittedCausesAttribute = "causes_omitted"
+
 // ErrorCauses is an error type that provides a list of underlying causes for an error.
 //
 // Implementations of this interface should return a slice of errors representing the
@@ -97,3 +108,164 @@ func WithCauses(err error, causes ...error) error {
 
 	return From(err).Cause(causes...).asFail()
 }
+
+// dedupeCauses returns causes with identical errors removed, keeping the first occurrence of each.
+//
+// The returned slice never shares its backing array with causes. Labels are kept aligned with the remaining causes.
+func dedupeCauses(causes []error, labels labelList) ([]error, labelList) {
+	res := make([]error, 0, len(causes))
+	var resLabels labelList
+	for i, cause := range causes {
+		duplicate := false
+		for _, kept := range res {
+			if sameCause(kept, cause) {
+				duplicate = true
+				break
+			}
+		}
+
+		if !duplicate {
+			resLabels = resLabels.with(len(res), labels.at(i))
+			res = append(res, cause)
+		}
+	}
+
+	return res, resLabels
+}
+
+// sameCause reports whether a and b should be considered the same cause.
+//
+// Errors are identical if errors.Is reports them as equal, or if they have the same message, code and domain.
+func sameCause(a, b error) bool {
+	if errors.Is(b, a) {
+		return true
+	}
+
+	return Message(a) == Message(b) && Code(a) == Code(b) && Domain(a) == Domain(b)
+}
+
+// ErrorCauseLabels is an error type that provides labels for its direct causes.
+//
+// A label is a short string, such as "primary", "rollback" or "node-3", that describes the role
+// of a cause and makes multi-cause errors easier to read. The returned slice is aligned by index
+// with the slice returned by ErrorCauses, and an empty label means the cause is unlabeled.
+type ErrorCauseLabels interface {
+	error
+
+	// ErrorCauseLabels returns the labels of the direct causes of this error, aligned with ErrorCauses.
+	ErrorCauseLabels() []string
+}
+
+// CauseLabels returns the labels of the direct causes of the provided error.
+//
+// The returned slice has the same length as the slice returned by Causes, so that the label of
+// Causes(err)[i] is CauseLabels(err)[i]. Unlabeled causes have an empty label. If err is nil, does
+// not implement ErrorCauseLabels, or none of its causes is labeled, CauseLabels returns nil.
+//
+// Example:
+//
+//	labels := fail.CauseLabels(err)
+//	for i, cause := range fail.Causes(err) {
+//		if i < len(labels) && labels[i] != "" {
+//			fmt.Printf("%s: %v\n", labels[i], cause)
+//		}
+//	}
+func CauseLabels(err error) []string {
+	l, ok := err.(ErrorCauseLabels)
+	if !ok {
+		return nil
+	}
+
+	return labelList(l.ErrorCauseLabels()).aligned(len(Causes(err)))
+}
+
+// CauseLabel returns the label of the i-th direct cause of the provided error, or an empty string if it has none.
+func CauseLabel(err error, i int) string {
+	return labelList(CauseLabels(err)).at(i)
+}
+
+// fmtWrapTypes are the types of the errors created by fmt.Errorf with one or several %w verbs.
+var fmtWrapTypes = []reflect.Type{
+	reflect.TypeOf(fmt.Errorf("%w", errors.ErrUnsupported)),
+	reflect.TypeOf(fmt.Errorf("%w%w", errors.ErrUnsupported, errors.ErrUnsupported)),
+}
+
+// isFmtWrap reports whether err was created by fmt.Errorf with the %w verb.
+func isFmtWrap(err error) bool {
+	t := reflect.TypeOf(err)
+	for _, wrapType := range fmtWrapTypes {
+		if t == wrapType {
+			return true
+		}
+	}
+
+	return false
+}
+
+// decomposeFmtWrap returns the message of an error created by fmt.Errorf without the message of its
+// cause, and its causes with those created by fmt.Errorf converted into Fail errors up to depth levels.
+func decomposeFmtWrap(msg string, causes []error, depth int) (string, []error) {
+	res := make([]error, 0, len(causes))
+	for _, cause := range causes {
+		if cause == nil {
+			continue
+		}
+
+		if depth > 1 && isFmtWrap(cause) {
+			res = append(res, from(cause, depth-1).asFail())
+		} else {
+			res = append(res, cause)
+		}
+	}
+
+	if len(causes) == 1 && causes[0] != nil {
+		if prefix, ok := strings.CutSuffix(msg, ": "+causes[0].Error()); ok && prefix != "" {
+			msg = prefix
+		}
+	}
+
+	return msg, res
+}
+
+// joinErrorType is the type of the errors created by errors.Join.
+var joinErrorType = reflect.TypeOf(errors.Join(errors.ErrUnsupported))
+
+// expandJoined returns causes with the errors created by errors.Join replaced by the errors they join,
+// together with the aligned labels. The errors joined by a labeled cause inherit its label.
+//
+// The message of a joined error is the concatenation of the messages of the errors it joins, so
+// serializers expand them to preserve the individual errors. If no cause is a joined error, causes
+// and labels are returned as-is; otherwise the provided slices are not modified.
+func expandJoined(causes []error, labels labelList) ([]error, labelList) {
+	if !slices.ContainsFunc(causes, isJoined) {
+		return causes, labels
+	}
+
+	res := make([]error, 0, len(causes))
+	var resLabels labelList
+	var expand func(err error, label string, depth int)
+	expand = func(err error, label string, depth int) {
+		if depth < MaxDepth() && isJoined(err) {
+			for _, joined := range Causes(err) {
+				if joined != nil {
+					expand(joined, label, depth+1)
+				}
+			}
+			return
+		}
+
+		resLabels = resLabels.with(len(res), label)
+		res = append(res, err)
+	}
+
+	for i, cause := range causes {
+		expand(cause, labels.at(i), 0)
+	}
+
+	return res, resLabels
+}
+
+// isJoined reports whether err was created by errors.Join.
+func isJoined(err error) bool {
+	return err != nil && reflect.TypeOf(err) == joinErrorType
+}
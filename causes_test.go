@@ -0,0 +1,143 @@
+package fail_test
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"testing"
+
+	"github.com/FlowSeer/fail"
+)
+
+// causer mirrors the errors returned by errors.Wrap of github.com/pkg/errors, which expose their
+// cause through Cause() error as well as Unwrap() error.
+type causer struct {
+	msg   string
+	cause error
+}
+
+func (c causer) Error() string { return c.msg + ": " + c.cause.Error() }
+func (c causer) Cause() error  { return c.cause }
+func (c causer) Unwrap() error { return c.cause }
+
+// node is the shape of an error tree: the message and label of every error along with its causes.
+type node struct {
+	Msg    string
+	Label  string
+	Causes []node
+}
+
+// tree returns the shape of the error tree of err.
+func tree(err error) node {
+	n := node{Msg: fail.Message(err)}
+
+	labels := fail.CauseLabels(err)
+	for i, cause := range fail.Causes(err) {
+		child := tree(cause)
+		if i < len(labels) {
+			child.Label = labels[i]
+		}
+		n.Causes = append(n.Causes, child)
+	}
+
+	return n
+}
+
+func TestSerializedCauses(t *testing.T) {
+	a, b, c := errors.New("a"), errors.New("b"), errors.New("c")
+
+	tests := []struct {
+		name string
+		err  error
+		want node
+	}{
+		{
+			name: "errors.Join cause",
+			err:  fail.New().Cause(errors.Join(a, b)).Msg("outer"),
+			want: node{Msg: "outer", Causes: []node{{Msg: "a"}, {Msg: "b"}}},
+		},
+		{
+			name: "nested errors.Join cause",
+			err:  fail.New().Cause(errors.Join(a, errors.Join(b, c))).Msg("outer"),
+			want: node{Msg: "outer", Causes: []node{{Msg: "a"}, {Msg: "b"}, {Msg: "c"}}},
+		},
+		{
+			name: "errors.Join cause next to other causes",
+			err:  fail.New().Cause(a, errors.Join(b, c)).Msg("outer"),
+			want: node{Msg: "outer", Causes: []node{{Msg: "a"}, {Msg: "b"}, {Msg: "c"}}},
+		},
+		{
+			name: "labeled errors.Join cause",
+			err:  fail.New().CauseLabeled("db", errors.Join(a, b)).Msg("outer"),
+			want: node{Msg: "outer", Causes: []node{{Msg: "a", Label: "db"}, {Msg: "b", Label: "db"}}},
+		},
+		{
+			name: "errors.Join root",
+			err:  errors.Join(a, b),
+			want: node{Msg: "a\nb", Causes: []node{{Msg: "a"}, {Msg: "b"}}},
+		},
+		{
+			name: "fmt.Errorf multi-%w cause",
+			err:  fail.New().Cause(fmt.Errorf("both: %w and %w", a, b)).Msg("outer"),
+			want: node{Msg: "outer", Causes: []node{{Msg: "both: a and b", Causes: []node{{Msg: "a"}, {Msg: "b"}}}}},
+		},
+		{
+			name: "fmt.Errorf multi-%w wrapping errors.Join",
+			err:  fail.New().Cause(fmt.Errorf("all: %w, %w", errors.Join(a, b), c)).Msg("outer"),
+			want: node{Msg: "outer", Causes: []node{{Msg: "all: a\nb, c", Causes: []node{{Msg: "a"}, {Msg: "b"}, {Msg: "c"}}}}},
+		},
+		{
+			name: "pkg/errors cause",
+			err:  fail.New().Cause(causer{msg: "read config", cause: a}).Msg("outer"),
+			want: node{Msg: "outer", Causes: []node{{Msg: "read config: a", Causes: []node{{Msg: "a"}}}}},
+		},
+		{
+			name: "errors.Join of pkg/errors causes",
+			err:  fail.New().Cause(errors.Join(causer{msg: "x", cause: a}, causer{msg: "y", cause: b})).Msg("outer"),
+			want: node{Msg: "outer", Causes: []node{{Msg: "x: a", Causes: []node{{Msg: "a"}}}, {Msg: "y: b", Causes: []node{{Msg: "b"}}}}},
+		},
+	}
+
+	codecs := []struct {
+		name      string
+		roundTrip func(err error) (fail.Fail, error)
+	}{
+		{
+			name: "json",
+			roundTrip: func(err error) (fail.Fail, error) {
+				return fail.FromJson([]byte(fail.PrintsJson(err)))
+			},
+		},
+		{
+			name: "cbor",
+			roundTrip: func(err error) (fail.Fail, error) {
+				data, mErr := fail.MarshalBinary(err)
+				if mErr != nil {
+					return fail.Fail{}, mErr
+				}
+
+				return fail.UnmarshalBinary(data)
+			},
+		},
+	}
+
+	for _, codec := range codecs {
+		for _, tt := range tests {
+			t.Run(codec.name+"/"+tt.name, func(t *testing.T) {
+				got, err := codec.roundTrip(tt.err)
+				if err != nil {
+					t.Fatalf("round trip failed: %v", err)
+				}
+
+				if g := tree(got); !equalTree(g, tt.want) {
+					t.Errorf("got tree %+v, want %+v", g, tt.want)
+				}
+			})
+		}
+	}
+}
+
+// equalTree reports whether the trees a and b have the same shape.
+func equalTree(a, b node) bool {
+	return a.Msg == b.Msg && a.Label == b.Label && slices.EqualFunc(a.Causes, b.Causes, equalTree)
+}
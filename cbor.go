@@ -0,0 +1,353 @@
+package fail
+
+import (
+	"bytes"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"sort"
+	"strconv"
+	"unicode/utf8"
+)
+
+// CBOR major types (RFC 8949, section 3.1).
+const (
+	cborUint     = 0
+	cborNegative = 1
+	cborBytes    = 2
+	cborText     = 3
+	cborArray    = 4
+	cborMap      = 5
+	cborTag      = 6
+	cborSimple   = 7
+)
+
+// cborMaxNesting is the maximum nesting of arrays, maps and tags accepted by cborDecode.
+const cborMaxNesting = 1024
+
+// errCborMalformed is returned by cborDecode for data that is not well-formed CBOR.
+var errCborMalformed = errors.New("malformed CBOR data")
+
+// cborMarshalJSON encodes the JSON representation of v as CBOR.
+//
+// The value is marshaled using encoding/json first, so struct tags and json.Marshaler implementations
+// apply as they do for JSON. Integral numbers are encoded as CBOR integers and all other numbers as
+// double-precision floats.
+func cborMarshalJSON(v any) ([]byte, error) {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+
+	var generic any
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+	if err := dec.Decode(&generic); err != nil {
+		return nil, err
+	}
+
+	return cborEncode(nil, generic)
+}
+
+// cborUnmarshalJSON decodes CBOR data into v via its JSON representation.
+//
+// Like json.Unmarshal, values with an unexpected type are skipped while all other values are decoded;
+// the first such error is returned as a *json.UnmarshalTypeError.
+func cborUnmarshalJSON(data []byte, v any) error {
+	generic, rest, err := cborDecode(data, 0)
+	if err != nil {
+		return err
+	}
+	if len(rest) > 0 {
+		return fmt.Errorf("%w: %d trailing bytes", errCborMalformed, len(rest))
+	}
+
+	encoded, err := json.Marshal(generic)
+	if err != nil {
+		return err
+	}
+
+	return json.Unmarshal(encoded, v)
+}
+
+// cborEncode appends the CBOR encoding of v to buf.
+//
+// Supported are the values produced by decoding JSON using json.Decoder.UseNumber, byte slices,
+// and the basic Go integer and floating-point types.
+func cborEncode(buf []byte, v any) ([]byte, error) {
+	switch v := v.(type) {
+	case nil:
+		return append(buf, 0xf6), nil
+	case bool:
+		if v {
+			return append(buf, 0xf5), nil
+		}
+		return append(buf, 0xf4), nil
+	case string:
+		return append(cborHead(buf, cborText, uint64(len(v))), v...), nil
+	case []byte:
+		return append(cborHead(buf, cborBytes, uint64(len(v))), v...), nil
+	case json.Number:
+		if i, err := strconv.ParseInt(string(v), 10, 64); err == nil {
+			return cborEncodeInt(buf, i), nil
+		}
+		if u, err := strconv.ParseUint(string(v), 10, 64); err == nil {
+			return cborHead(buf, cborUint, u), nil
+		}
+		f, err := strconv.ParseFloat(string(v), 64)
+		if err != nil {
+			return nil, err
+		}
+		return cborEncodeFloat(buf, f), nil
+	case int:
+		return cborEncodeInt(buf, int64(v)), nil
+	case int64:
+		return cborEncodeInt(buf, v), nil
+	case uint64:
+		return cborHead(buf, cborUint, v), nil
+	case float64:
+		return cborEncodeFloat(buf, v), nil
+	case []any:
+		buf = cborHead(buf, cborArray, uint64(len(v)))
+		for _, e := range v {
+			var err error
+			if buf, err = cborEncode(buf, e); err != nil {
+				return nil, err
+			}
+		}
+		return buf, nil
+	case map[string]any:
+		keys := make([]string, 0, len(v))
+		for k := range v {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+
+		buf = cborHead(buf, cborMap, uint64(len(v)))
+		for _, k := range keys {
+			buf = append(cborHead(buf, cborText, uint64(len(k))), k...)
+
+			var err error
+			if buf, err = cborEncode(buf, v[k]); err != nil {
+				return nil, err
+			}
+		}
+		return buf, nil
+	}
+
+	return nil, fmt.Errorf("cannot encode value of type %T as CBOR", v)
+}
+
+// cborEncodeInt appends the CBOR encoding of the integer i to buf.
+func cborEncodeInt(buf []byte, i int64) []byte {
+	if i < 0 {
+		return cborHead(buf, cborNegative, uint64(-(i + 1)))
+	}
+
+	return cborHead(buf, cborUint, uint64(i))
+}
+
+// cborEncodeFloat appends the double-precision CBOR encoding of f to buf.
+func cborEncodeFloat(buf []byte, f float64) []byte {
+	return binary.BigEndian.AppendUint64(append(buf, 0xfb), math.Float64bits(f))
+}
+
+// cborHead appends the head of a data item of the provided major type and argument to buf.
+func cborHead(buf []byte, major byte, arg uint64) []byte {
+	major <<= 5
+	switch {
+	case arg < 24:
+		return append(buf, major|byte(arg))
+	case arg <= math.MaxUint8:
+		return append(buf, major|24, byte(arg))
+	case arg <= math.MaxUint16:
+		return binary.BigEndian.AppendUint16(append(buf, major|25), uint16(arg))
+	case arg <= math.MaxUint32:
+		return binary.BigEndian.AppendUint32(append(buf, major|26), uint32(arg))
+	}
+
+	return binary.BigEndian.AppendUint64(append(buf, major|27), arg)
+}
+
+// cborDecode decodes the first data item of data and returns it along with the remaining bytes.
+//
+// Items are decoded into nil, bool, int64, uint64, float64, string, []byte, []any and map[string]any
+// values; map keys that are not text strings are formatted using fmt.Sprint, and tags are dropped in
+// favor of the tagged item. Indefinite-length items are not supported. Lengths are checked against
+// the size of data before allocating, so that malformed input cannot cause large allocations.
+func cborDecode(data []byte, nesting int) (any, []byte, error) {
+	if nesting > cborMaxNesting {
+		return nil, nil, fmt.Errorf("%w: nesting exceeds %d levels", errCborMalformed, cborMaxNesting)
+	}
+
+	if len(data) == 0 {
+		return nil, nil, fmt.Errorf("%w: unexpected end of data", errCborMalformed)
+	}
+
+	major, info := data[0]>>5, data[0]&0x1f
+	data = data[1:]
+
+	if major == cborSimple {
+		return cborDecodeSimple(info, data)
+	}
+
+	arg, data, err := cborArgument(info, data)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	switch major {
+	case cborUint:
+		if arg <= math.MaxInt64 {
+			return int64(arg), data, nil
+		}
+		return arg, data, nil
+	case cborNegative:
+		if arg <= math.MaxInt64 {
+			return -1 - int64(arg), data, nil
+		}
+		return -1 - float64(arg), data, nil
+	case cborBytes, cborText:
+		if arg > uint64(len(data)) {
+			return nil, nil, fmt.Errorf("%w: unexpected end of data", errCborMalformed)
+		}
+		if major == cborBytes {
+			return append([]byte(nil), data[:arg]...), data[arg:], nil
+		}
+		if !utf8.Valid(data[:arg]) {
+			return nil, nil, fmt.Errorf("%w: invalid UTF-8 in text string", errCborMalformed)
+		}
+		return string(data[:arg]), data[arg:], nil
+	case cborArray:
+		// Every item takes at least one byte.
+		if arg > uint64(len(data)) {
+			return nil, nil, fmt.Errorf("%w: unexpected end of data", errCborMalformed)
+		}
+		res := make([]any, arg)
+		for i := range res {
+			if res[i], data, err = cborDecode(data, nesting+1); err != nil {
+				return nil, nil, err
+			}
+		}
+		return res, data, nil
+	case cborMap:
+		if arg > uint64(len(data))/2 {
+			return nil, nil, fmt.Errorf("%w: unexpected end of data", errCborMalformed)
+		}
+		res := make(map[string]any, arg)
+		for range arg {
+			var key, value any
+			if key, data, err = cborDecode(data, nesting+1); err != nil {
+				return nil, nil, err
+			}
+			if value, data, err = cborDecode(data, nesting+1); err != nil {
+				return nil, nil, err
+			}
+
+			if s, ok := key.(string); ok {
+				res[s] = value
+			} else {
+				res[fmt.Sprint(key)] = value
+			}
+		}
+		return res, data, nil
+	}
+
+	// cborTag
+	return cborDecode(data, nesting+1)
+}
+
+// cborArgument decodes the argument of a data item head with the provided additional information.
+func cborArgument(info byte, data []byte) (uint64, []byte, error) {
+	var size int
+	switch {
+	case info < 24:
+		return uint64(info), data, nil
+	case info == 24:
+		size = 1
+	case info == 25:
+		size = 2
+	case info == 26:
+		size = 4
+	case info == 27:
+		size = 8
+	default:
+		return 0, nil, fmt.Errorf("%w: unsupported additional information %d", errCborMalformed, info)
+	}
+
+	if len(data) < size {
+		return 0, nil, fmt.Errorf("%w: unexpected end of data", errCborMalformed)
+	}
+
+	var arg uint64
+	for _, b := range data[:size] {
+		arg = arg<<8 | uint64(b)
+	}
+
+	return arg, data[size:], nil
+}
+
+// cborDecodeSimple decodes a simple value or floating-point number with the provided additional information.
+func cborDecodeSimple(info byte, data []byte) (any, []byte, error) {
+	switch info {
+	case 20:
+		return false, data, nil
+	case 21:
+		return true, data, nil
+	case 22, 23: // null, undefined
+		return nil, data, nil
+	}
+
+	arg, rest, err := cborArgument(info, data)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var f float64
+	switch info {
+	case 25:
+		f = halfToFloat64(uint16(arg))
+	case 26:
+		f = float64(math.Float32frombits(uint32(arg)))
+	case 27:
+		f = math.Float64frombits(arg)
+	default:
+		// Unassigned simple values carry no information.
+		return nil, rest, nil
+	}
+
+	// NaN and infinities have no JSON representation.
+	if math.IsNaN(f) || math.IsInf(f, 0) {
+		return nil, rest, nil
+	}
+
+	return f, rest, nil
+}
+
+// halfToFloat64 converts an IEEE 754 half-precision number to a float64.
+func halfToFloat64(h uint16) float64 {
+	exp := int(h>>10) & 0x1f
+	mant := float64(h & 0x3ff)
+
+	var f float64
+	switch exp {
+	case 0:
+		f = math.Ldexp(mant, -24)
+	case 0x1f:
+		if mant == 0 {
+			f = math.Inf(1)
+		} else {
+			f = math.NaN()
+		}
+	default:
+		f = math.Ldexp(mant+1024, exp-25)
+	}
+
+	if h&0x8000 != 0 {
+		return -f
+	}
+
+	return f
+}
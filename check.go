@@ -0,0 +1,88 @@
+package fail
+
+// Constraint is the outcome of a single check of an input, created using Check.
+//
+// A Constraint is a value; its methods return a modified copy, so that they can be chained.
+// CheckAll combines the violated constraints into a single validation error.
+type Constraint struct {
+	ok    bool
+	field FieldError
+}
+
+// Check returns a Constraint that is violated unless cond holds.
+//
+// msg describes the violation, such as "must not be empty". The name of the checked field and a
+// machine-readable code can be set using Constraint.Field and Constraint.Code.
+//
+// Example:
+//
+//	err := fail.CheckAll(
+//		fail.Check(req.Email != "", "is required").Field("email").Code("required"),
+//		fail.Check(len(req.Name) <= 64, "must be at most 64 characters").Field("name"),
+//		fail.Check(req.Quantity > 0, "must be positive").Field("quantity"),
+//	)
+func Check(cond bool, msg string) Constraint {
+	return Constraint{ok: cond, field: FieldError{Msg: msg}}
+}
+
+// Field returns a copy of the Constraint checking the field with the provided name or path, such as "items[2].quantity".
+func (c Constraint) Field(field string) Constraint {
+	c.field.Field = field
+	return c
+}
+
+// Code returns a copy of the Constraint with the provided machine-readable reason of the violation, such as "required".
+func (c Constraint) Code(code string) Constraint {
+	c.field.Code = code
+	return c
+}
+
+// Ok returns whether the constraint holds.
+func (c Constraint) Ok() bool {
+	return c.ok
+}
+
+// FieldError returns the field error describing the violation of the constraint.
+func (c Constraint) FieldError() FieldError {
+	return c.field
+}
+
+// CheckAll returns a validation error describing the violated constraints, or nil if all constraints hold.
+//
+// Unlike returning on the first failed check, CheckAll reports all violations at once. The returned Fail
+// has ErrCodeValidation and DomainValidation, and carries one FieldError per violated constraint, in the
+// order of the constraints. If a single constraint is violated, its field error is used as the message;
+// otherwise the message states the number of violations.
+//
+// Example:
+//
+//	if err := fail.CheckAll(
+//		fail.Check(req.Email != "", "is required").Field("email"),
+//		fail.Check(req.Age >= 18, "must be at least 18").Field("age"),
+//	); err != nil {
+//		_ = failhttp.Write(w, r, err)
+//		return
+//	}
+func CheckAll(constraints ...Constraint) error {
+	var violations []FieldError
+	for _, c := range constraints {
+		if !c.ok {
+			violations = append(violations, c.field)
+		}
+	}
+
+	if len(violations) == 0 {
+		return nil
+	}
+
+	b := New().
+		Code(ErrCodeValidation).
+		Domain(DomainValidation).
+		FieldErrors(violations...)
+
+	if len(violations) == 1 {
+		return b.Msg(violations[0].String())
+	}
+
+	return b.Msgf("%d validation checks failed", len(violations))
+}
@@ -0,0 +1,163 @@
+package fail
+
+import "sync"
+
+// ClassifierRule maps the errors matched by its Matcher to a domain, code, transience and HTTP status code.
+//
+// Zero fields are left unchanged on the classified error, so a rule may set only the information it knows.
+type ClassifierRule struct {
+	// Matcher selects the errors the rule applies to.
+	Matcher Matcher
+	// Domain is the domain of the classified error, see Builder.Domain.
+	Domain string
+	// Code is the error code of the classified error, see Builder.Code.
+	Code string
+	// Transience classifies the error as transient or permanent, see Builder.Transience.
+	Transience TransienceKind
+	// HttpStatusCode is the HTTP status code of the classified error, see Builder.HttpStatusCode.
+	HttpStatusCode int
+}
+
+// apply sets the non-zero fields of the rule on the builder.
+func (r ClassifierRule) apply(b Builder) Builder {
+	b = b.Domain(r.Domain).Code(r.Code).HttpStatusCode(r.HttpStatusCode)
+	if r.Transience != TransienceUnknown {
+		b = b.Transience(r.Transience)
+	}
+
+	return b
+}
+
+// Classifier maps arbitrary errors, such as those of third-party SDKs, to the domain, code and transience
+// of this package using a set of rules.
+//
+// A Classifier replaces the switch statements usually written around external errors: rules match
+// errors by type, sentinel, message or any other field of a Matcher, and Classify applies the rule
+// with the most specific Matcher, i.e. the one with the most non-zero fields. Among equally specific
+// matches, the rule added first wins. The zero value is an empty classifier ready to use.
+// A Classifier is safe for concurrent use, and implements Enricher, so it can be part of a Chain.
+//
+// Example:
+//
+//	classifier := fail.NewClassifier().
+//		Add(fail.ClassifierRule{
+//			Matcher:    fail.Matcher{Is: sql.ErrNoRows},
+//			Domain:     fail.DomainDatabase,
+//			Code:       fail.ErrCodeNotFound,
+//			Transience: fail.TransiencePermanent,
+//		}).
+//		Add(fail.ClassifierRule{
+//			Matcher:    fail.Matcher{Message: regexp.MustCompile(`(?i)throttl|rate exceeded`)},
+//			Code:       fail.ErrCodeRateLimited,
+//			Transience: fail.TransienceTransient,
+//		})
+//
+//	err = classifier.Classify(err)
+type Classifier struct {
+	mu    sync.RWMutex
+	rules []ClassifierRule
+}
+
+// NewClassifier returns an empty Classifier.
+func NewClassifier() *Classifier {
+	return &Classifier{}
+}
+
+// Add registers the provided rules and returns the classifier to allow chaining.
+func (c *Classifier) Add(rules ...ClassifierRule) *Classifier {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.rules = append(c.rules, rules...)
+	return c
+}
+
+// Reset removes all rules of the classifier.
+func (c *Classifier) Reset() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.rules = nil
+}
+
+// Classify returns a copy of err classified by the most specific rule matching it.
+//
+// The error is converted using From, so all of its metadata is kept, except for the fields set by
+// the rule, and errors.Is and errors.As still match err and its causes. If no rule matches, err is
+// returned unchanged. If err is nil, Classify returns nil.
+func (c *Classifier) Classify(err error) error {
+	if err == nil {
+		return nil
+	}
+
+	rule, ok := c.lookup(err)
+	if !ok {
+		return err
+	}
+
+	return rule.apply(fromOrigin(err)).asFail()
+}
+
+// Enrich implements Enricher by calling Classify.
+func (c *Classifier) Enrich(err error) error {
+	return c.Classify(err)
+}
+
+// lookup returns the rule with the most specific Matcher matching err.
+func (c *Classifier) lookup(err error) (ClassifierRule, bool) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	best := -1
+	var res ClassifierRule
+	for _, rule := range c.rules {
+		if s := rule.Matcher.specificity(); s > best && rule.Matcher.Match(err) {
+			best = s
+			res = rule
+		}
+	}
+
+	return res, best >= 0
+}
+
+// classifier is the Classifier used by Classify.
+var classifier Classifier
+
+// AddClassifierRule registers rules applied by Classify.
+//
+// Libraries and services register the rules for the external errors they deal with once, typically
+// during initialization, and classify errors at their boundaries using Classify. See Classifier for
+// how rules are selected. It is safe to call AddClassifierRule concurrently.
+//
+// Example:
+//
+//	fail.AddClassifierRule(fail.ClassifierRule{
+//		Matcher:    fail.Matcher{Kind: fail.KindOf[*net.OpError]()},
+//		Domain:     fail.DomainNetwork,
+//		Transience: fail.TransienceTransient,
+//	})
+func AddClassifierRule(rules ...ClassifierRule) {
+	classifier.Add(rules...)
+}
+
+// ResetClassifierRules removes all rules registered using AddClassifierRule.
+//
+// It is safe to call ResetClassifierRules concurrently.
+func ResetClassifierRules() {
+	classifier.Reset()
+}
+
+// Classify returns a copy of err classified by the most specific rule registered using AddClassifierRule.
+//
+// If no rule matches, err is returned unchanged. If err is nil, Classify returns nil.
+// See Classifier for details.
+//
+// Example:
+//
+//	out, err := s3Client.GetObject(ctx, input)
+//	if err != nil {
+//		return nil, fail.Classify(err)
+//	}
+func Classify(err error) error {
+	return classifier.Classify(err)
+}
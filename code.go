@@ -1,11 +1,15 @@
 package fail
 
+import "context"
+
 // Error code constants for canonical programmatic error codes.
 const (
 	// ErrCodeUnspecified is the default error code for unknown or unspecified errors.
 	ErrCodeUnspecified = "ERR_UNKNOWN"
 	// ErrCodeUnknown is an alias for ErrCodeUnspecified.
 	ErrCodeUnknown = ErrCodeUnspecified
+	// DefaultErrorCode is the default error code to use when no specific code is set.
+	DefaultErrorCode = ErrCodeUnspecified
 
 	// Validation errors
 	// ErrCodeValidation indicates a general validation failure.
@@ -107,11 +111,21 @@ type ErrorCode interface {
 //  3. Otherwise, it recursively examines the direct causes of err (using Causes(err)).
 //     If any cause implements ErrorCode, it returns the code from the cause with the highest ExitCode.
 //     Otherwise, it returns the code from the cause with the first non-default code.
-//  4. If no code is found, it returns DefaultErrorCode.
+//  4. If no code is found, it returns the default code set using SetDefaults, or DefaultErrorCode.
 //
 // This allows error types to specify custom error codes, and for composed/multi-cause errors
 // to propagate the code from the most severe cause (as determined by ExitCode).
+// The recursion is bounded by MaxDepth and MaxWidth, and cycles in the cause graph are ignored.
 func Code(err error) string {
+	if c := code(err, 0, newVisitSet()); c != ErrCodeUnspecified {
+		return c
+	}
+
+	return CurrentDefaults().Code
+}
+
+// code implements Code, tracking the current depth and the visited errors to guard against cycles.
+func code(err error, depth int, visited visitSet) string {
 	if err == nil {
 		return ""
 	}
@@ -121,12 +135,17 @@ func Code(err error) string {
 		return code.ErrorCode()
 	}
 
+	if depth >= MaxDepth() || !visited.enter(err) {
+		return ErrCodeUnspecified
+	}
+	defer visited.leave(err)
+
 	// Otherwise, check causes and return the code from the cause with the highest exit code.
 	maxCode := ErrCodeUnspecified
 	maxExitCode := 0
-	for _, cause := range Causes(err) {
+	for _, cause := range limitWidth(Causes(err)) {
 		causeExitCode := ExitCode(cause)
-		causeCode := Code(cause)
+		causeCode := code(cause, depth+1, visited)
 
 		// Prefer the code from the cause with the highest exit code.
 		if causeExitCode > maxExitCode {
@@ -148,3 +167,35 @@ func WithCode(err error, code string) error {
 
 	return From(err).Code(code).asFail()
 }
+
+// codeContextKey is an unexported type used as the key for storing
+// and retrieving the default error code in a context.Context.
+type codeContextKey struct{}
+
+// ContextWithCode returns a new context.Context that carries the provided default error code.
+// If a code is already set in the context, it is overwritten with the new value.
+//
+// Errors built using NewC, MsgC, WrapC or Builder.Context use the code from the context
+// unless a code is set explicitly on the Builder.
+//
+// Example usage:
+//
+//	ctx := ContextWithCode(context.Background(), "ERR_CHECKOUT")
+func ContextWithCode(ctx context.Context, code string) context.Context {
+	return context.WithValue(ctx, codeContextKey{}, code)
+}
+
+// CodeFromContext extracts the default error code from the provided context.
+// If no code is set in the context, CodeFromContext returns an empty string.
+//
+// Example usage:
+//
+//	code := CodeFromContext(ctx)
+func CodeFromContext(ctx context.Context) string {
+	code, ok := ctx.Value(codeContextKey{}).(string)
+	if !ok {
+		return ""
+	}
+
+	return code
+}
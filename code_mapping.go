@@ -0,0 +1,95 @@
+package fail
+
+import (
+	"maps"
+	"sync"
+	"sync/atomic"
+)
+
+// codeMapping maps error codes and domains to integers, such as exit codes or HTTP status codes.
+//
+// Lookups are lock-free; modifications copy the current table under a mutex.
+type codeMapping struct {
+	defaults func() *codeMappingTable
+
+	mu      sync.Mutex
+	current atomic.Pointer[codeMappingTable]
+}
+
+// codeMappingTable holds the values mapped to error codes and domains.
+type codeMappingTable struct {
+	codes   map[string]int
+	domains map[string]int
+}
+
+// newCodeMapping creates a codeMapping initialized with the table returned by defaults.
+func newCodeMapping(defaults func() *codeMappingTable) *codeMapping {
+	m := &codeMapping{defaults: defaults}
+	m.current.Store(defaults())
+
+	return m
+}
+
+// setCode maps code to value, or removes the mapping of code if value is less than or equal to zero.
+func (m *codeMapping) setCode(code string, value int) {
+	m.update(func(t *codeMappingTable) {
+		setMapping(t.codes, code, value)
+	})
+}
+
+// setDomain maps domain to value, or removes the mapping of domain if value is less than or equal to zero.
+func (m *codeMapping) setDomain(domain string, value int) {
+	m.update(func(t *codeMappingTable) {
+		setMapping(t.domains, domain, value)
+	})
+}
+
+// reset restores the default table.
+func (m *codeMapping) reset() {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.current.Store(m.defaults())
+}
+
+// update applies fn to a copy of the current table and stores the result.
+func (m *codeMapping) update(fn func(t *codeMappingTable)) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	current := m.current.Load()
+	next := &codeMappingTable{
+		codes:   maps.Clone(current.codes),
+		domains: maps.Clone(current.domains),
+	}
+	fn(next)
+
+	m.current.Store(next)
+}
+
+// lookup returns the value mapped to the error code of err or, if it is not mapped, to its domain.
+//
+// Only the error code of err itself is considered, not those of its causes, since Code consults
+// ExitCode to choose among the codes of the causes.
+func (m *codeMapping) lookup(err error) (int, bool) {
+	t := m.current.Load()
+
+	if c, ok := err.(ErrorCode); ok {
+		if value, ok := t.codes[c.ErrorCode()]; ok {
+			return value, true
+		}
+	}
+
+	value, ok := t.domains[Domain(err)]
+	return value, ok
+}
+
+// setMapping sets the value of key in m, or removes it if value is less than or equal to zero.
+func setMapping(m map[string]int, key string, value int) {
+	if value <= 0 {
+		delete(m, key)
+		return
+	}
+
+	m[key] = value
+}
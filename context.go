@@ -0,0 +1,44 @@
+package fail
+
+import (
+	"context"
+	"errors"
+
+	"github.com/FlowSeer/wz/slices"
+)
+
+// DeadlineAttribute is the attribute key under which the deadline of a done context.Context is attached to errors.
+const DeadlineAttribute = "deadline"
+
+// contextDone enriches the builder with the reason the provided context is done, if it is.
+//
+// The reason is added as a cause unless one of the existing causes already matches it according to errors.Is.
+// Expired deadlines set DomainTimeout and TagTimeout, cancellations TagCanceled.
+func (b Builder) contextDone(ctx context.Context) Builder {
+	ctxErr := ctx.Err()
+	if ctxErr == nil {
+		return b
+	}
+
+	cause := context.Cause(ctx)
+	if !slices.ContainsFunc(b.causes, func(err error) bool {
+		return errors.Is(err, ctxErr) || errors.Is(err, cause)
+	}) {
+		b = b.Cause(cause)
+	}
+
+	if errors.Is(ctxErr, context.DeadlineExceeded) {
+		if b.domain == DomainUnspecified {
+			b = b.Domain(DomainTimeout)
+		}
+		b = b.Tag(TagTimeout)
+	} else {
+		b = b.Tag(TagCanceled)
+	}
+
+	if deadline, ok := ctx.Deadline(); ok {
+		b = b.Attribute(DeadlineAttribute, deadline)
+	}
+
+	return b
+}
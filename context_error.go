@@ -0,0 +1,119 @@
+package fail
+
+import (
+	"context"
+	"sync"
+)
+
+// errorContextKey is an unexported type used as the key for storing
+// and retrieving the recorded errors in a context.Context.
+type errorContextKey struct{}
+
+// errorRecorder holds the errors recorded in a context.
+//
+// It is shared by all contexts derived from the context it was attached to, so that errors
+// recorded by inner handlers are visible to outer middleware.
+type errorRecorder struct {
+	mu   sync.Mutex
+	errs []error
+}
+
+// ContextWithError records err in the provided context.
+//
+// If the context already carries recorded errors, err is recorded in place and the context is returned
+// unchanged; the error is then visible to every holder of a context derived from the one the errors
+// were first attached to. Otherwise, a new context carrying err is returned.
+// Passing a nil error only prepares the context for recording, which allows middleware to observe
+// errors recorded by the handlers it calls without changing their signatures.
+//
+// Example usage:
+//
+//	func middleware(next http.Handler) http.Handler {
+//		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+//			ctx := fail.ContextWithError(r.Context(), nil)
+//			next.ServeHTTP(w, r.WithContext(ctx))
+//
+//			if err := fail.ErrorFromContext(ctx); err != nil {
+//				log.Println(err)
+//			}
+//		})
+//	}
+//
+//	func handler(w http.ResponseWriter, r *http.Request) {
+//		fail.ContextWithError(r.Context(), fail.Msg("request failed"))
+//	}
+func ContextWithError(ctx context.Context, err error) context.Context {
+	return AccumulateInContext(ctx, err)
+}
+
+// AccumulateInContext records the provided errors in the context, in addition to the errors already recorded.
+//
+// Nil errors are ignored. Like ContextWithError, the errors are recorded in place if the context already
+// carries recorded errors, and a new context is returned otherwise. All recorded errors can be retrieved
+// using ErrorsFromContext.
+//
+// Example usage:
+//
+//	ctx = fail.AccumulateInContext(ctx, validateName(req), validateEmail(req))
+func AccumulateInContext(ctx context.Context, errs ...error) context.Context {
+	r, ok := ctx.Value(errorContextKey{}).(*errorRecorder)
+	if !ok {
+		r = &errorRecorder{}
+		ctx = context.WithValue(ctx, errorContextKey{}, r)
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for _, err := range errs {
+		if err != nil {
+			r.errs = append(r.errs, err)
+		}
+	}
+
+	return ctx
+}
+
+// ErrorFromContext returns the last error recorded in the provided context.
+// If no error has been recorded, ErrorFromContext returns nil.
+//
+// Example usage:
+//
+//	if err := fail.ErrorFromContext(ctx); err != nil {
+//		w.WriteHeader(fail.HttpStatusCode(err))
+//	}
+func ErrorFromContext(ctx context.Context) error {
+	r, ok := ctx.Value(errorContextKey{}).(*errorRecorder)
+	if !ok {
+		return nil
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if len(r.errs) == 0 {
+		return nil
+	}
+
+	return r.errs[len(r.errs)-1]
+}
+
+// ErrorsFromContext returns all errors recorded in the provided context, in the order they were recorded.
+// If no error has been recorded, ErrorsFromContext returns nil.
+//
+// The returned slice is a copy.
+func ErrorsFromContext(ctx context.Context) []error {
+	r, ok := ctx.Value(errorContextKey{}).(*errorRecorder)
+	if !ok {
+		return nil
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if len(r.errs) == 0 {
+		return nil
+	}
+
+	return append([]error(nil), r.errs...)
+}
@@ -0,0 +1,139 @@
+package fail
+
+import (
+	"context"
+
+	"go.opentelemetry.io/otel/baggage"
+)
+
+// CorrelationIdBaggageMember is the name of the OpenTelemetry baggage member from which
+// CorrelationIdFromContext reads the correlation ID, if none is set using ContextWithCorrelationId.
+const CorrelationIdBaggageMember = "correlation_id"
+
+// ErrorCorrelationId is an error type that provides the correlation ID of the request or job that failed.
+//
+// A correlation ID, often called request ID, identifies a unit of work across services and log lines.
+// It is independent of distributed tracing and is commonly used by systems that predate it.
+// The returned string may be empty if no correlation ID is known.
+//
+// Example usage:
+//
+//	type MyError struct{}
+//	func (e *MyError) Error() string { return "request failed" }
+//	func (e *MyError) ErrorCorrelationId() string { return "req-42" }
+//
+//	err := &MyError{}
+//	id := fail.CorrelationId(err) // returns "req-42"
+type ErrorCorrelationId interface {
+	error
+
+	// ErrorCorrelationId returns the correlation ID associated with this error.
+	//
+	// The returned string may be empty if no correlation ID is set.
+	ErrorCorrelationId() string
+}
+
+// CorrelationId returns the correlation ID associated with the provided error, if any.
+//
+// This function attempts to extract the correlation ID from the error as follows:
+//  1. If err is nil, it returns an empty string.
+//  2. If err implements ErrorCorrelationId and the result of ErrorCorrelationId() is not empty, it returns the result.
+//  3. Otherwise, it recursively examines the causes of err (using Causes(err)) and returns the
+//     first non-empty correlation ID found.
+//
+// The recursion is bounded by MaxDepth and MaxWidth, and cycles in the cause graph are ignored.
+func CorrelationId(err error) string {
+	return correlationId(err, 0, newVisitSet())
+}
+
+// correlationId implements CorrelationId, tracking the current depth and the visited errors to guard against cycles.
+func correlationId(err error, depth int, visited visitSet) string {
+	if err == nil {
+		return ""
+	}
+
+	if c, ok := err.(ErrorCorrelationId); ok {
+		if id := c.ErrorCorrelationId(); id != "" {
+			return id
+		}
+	}
+
+	if depth >= MaxDepth() || !visited.enter(err) {
+		return ""
+	}
+	defer visited.leave(err)
+
+	for _, cause := range limitWidth(Causes(err)) {
+		if id := correlationId(cause, depth+1, visited); id != "" {
+			return id
+		}
+	}
+
+	return ""
+}
+
+// WithCorrelationId returns a new error with the specified correlation ID attached.
+//
+// This function takes an existing error and a correlation ID, and returns a new error
+// that includes the provided ID. If the provided error is nil, it returns nil.
+// If the correlation ID is empty, the original error is returned unchanged.
+//
+// The returned error will implement the ErrorCorrelationId interface, and the ID can be
+// retrieved using the fail.CorrelationId function.
+//
+// Example:
+//
+//	err := fail.WithCorrelationId(primaryErr, r.Header.Get("X-Request-Id"))
+//
+// Parameters:
+//   - err: The original error to which the correlation ID will be attached.
+//   - correlationId: The correlation ID.
+//
+// Returns:
+//   - A new error with the correlation ID attached, or nil if err is nil. If correlationId is empty, returns the original error.
+func WithCorrelationId(err error, correlationId string) error {
+	if err == nil {
+		return nil
+	}
+
+	if correlationId == "" {
+		return err
+	}
+
+	return From(err).CorrelationId(correlationId).asFail()
+}
+
+// CorrelationIdContextKey is the context key under which the correlation ID is stored.
+//
+// It is exported so that middleware that does not depend on this package's helpers can
+// provide the correlation ID using context.WithValue with a string value.
+type CorrelationIdContextKey struct{}
+
+// ContextWithCorrelationId returns a new context.Context that carries the provided correlation ID.
+// If a correlation ID is already set in the context, it is overwritten with the new value.
+//
+// Errors built using NewC, MsgC, WrapC or Builder.Context carry the correlation ID.
+//
+// Example usage:
+//
+//	ctx := ContextWithCorrelationId(r.Context(), r.Header.Get("X-Request-Id"))
+func ContextWithCorrelationId(ctx context.Context, correlationId string) context.Context {
+	return context.WithValue(ctx, CorrelationIdContextKey{}, correlationId)
+}
+
+// CorrelationIdFromContext extracts the correlation ID from the provided context.
+//
+// The correlation ID set using ContextWithCorrelationId (or stored under CorrelationIdContextKey)
+// takes precedence. Otherwise, the value of the CorrelationIdBaggageMember member of the
+// OpenTelemetry baggage in the context is returned. If neither is set, the result is empty.
+//
+// Example usage:
+//
+//	id := CorrelationIdFromContext(ctx)
+func CorrelationIdFromContext(ctx context.Context) string {
+	if id, ok := ctx.Value(CorrelationIdContextKey{}).(string); ok && id != "" {
+		return id
+	}
+
+	return baggage.FromContext(ctx).Member(CorrelationIdBaggageMember).Value()
+}
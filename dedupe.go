@@ -0,0 +1,75 @@
+package fail
+
+import "time"
+
+// Attribute keys under which Dedupe records the occurrences of collapsed errors.
+const (
+	// DedupeCountAttribute is the attribute key for the number of occurrences of a deduplicated error.
+	DedupeCountAttribute = "count"
+	// FirstSeenAttribute is the attribute key for the earliest timestamp of the occurrences of a deduplicated error.
+	FirstSeenAttribute = "first_seen"
+	// LastSeenAttribute is the attribute key for the latest timestamp of the occurrences of a deduplicated error.
+	LastSeenAttribute = "last_seen"
+)
+
+// Dedupe collapses identical errors into a single representative per Fingerprint.
+//
+// The representative of each group of identical errors is its first occurrence in errs, carrying the
+// number of occurrences under DedupeCountAttribute and, if any occurrence has a timestamp (see Time),
+// the earliest and latest timestamps under FirstSeenAttribute and LastSeenAttribute. Representatives
+// are returned in the order of their first occurrence, and nil errors are ignored. The errors in errs
+// are not modified; representatives are copies converted to Fail errors (see Modify).
+//
+// Example:
+//
+//	for _, err := range fail.Dedupe(errs) {
+//		logger.Error("import failed", "error", err, "count", fail.Attributes(err)[fail.DedupeCountAttribute])
+//	}
+func Dedupe(errs []error) []error {
+	type occurrences struct {
+		err                 error
+		count               int
+		firstSeen, lastSeen time.Time
+	}
+
+	var order []string
+	seen := make(map[string]*occurrences)
+	for _, err := range errs {
+		if err == nil {
+			continue
+		}
+
+		fingerprint := Fingerprint(err)
+		o, ok := seen[fingerprint]
+		if !ok {
+			o = &occurrences{err: err}
+			seen[fingerprint] = o
+			order = append(order, fingerprint)
+		}
+
+		o.count++
+		if t := Time(err); !t.IsZero() {
+			if o.firstSeen.IsZero() || t.Before(o.firstSeen) {
+				o.firstSeen = t
+			}
+			if t.After(o.lastSeen) {
+				o.lastSeen = t
+			}
+		}
+	}
+
+	res := make([]error, 0, len(order))
+	for _, fingerprint := range order {
+		o := seen[fingerprint]
+		res = append(res, Modify(o.err, func(b Builder) Builder {
+			b = b.Attribute(DedupeCountAttribute, o.count)
+			if !o.firstSeen.IsZero() {
+				b = b.Attribute(FirstSeenAttribute, o.firstSeen).Attribute(LastSeenAttribute, o.lastSeen)
+			}
+
+			return b
+		}))
+	}
+
+	return res
+}
@@ -0,0 +1,79 @@
+package fail
+
+import "sync/atomic"
+
+// Defaults holds the values reported for errors that do not specify them, see SetDefaults.
+//
+// Zero fields select the built-in defaults: DefaultErrorCode, DefaultExitCode, DefaultHttpStatusCode
+// and DomainUnspecified.
+type Defaults struct {
+	// Code is returned by Code for errors without a code in their cause tree.
+	Code string
+	// ExitCode is returned by ExitCode for errors without an explicit or mapped exit code.
+	ExitCode int
+	// HttpStatusCode is returned by HttpStatusCode for errors without an explicit or mapped HTTP status code.
+	HttpStatusCode int
+	// Domain is returned by Domain for errors without a domain.
+	Domain string
+}
+
+// builtinDefaults are the defaults in effect unless changed using SetDefaults.
+var builtinDefaults = Defaults{
+	Code:           DefaultErrorCode,
+	ExitCode:       DefaultExitCode,
+	HttpStatusCode: DefaultHttpStatusCode,
+	Domain:         DomainUnspecified,
+}
+
+// defaults holds the defaults set using SetDefaults, or nil if the built-in defaults are in effect.
+var defaults atomic.Pointer[Defaults]
+
+// SetDefaults sets the values reported process-wide for errors that do not specify them.
+//
+// The defaults apply to the results of Code, ExitCode, HttpStatusCode and Domain, and thereby to the
+// printers, loggers and integrations built on them, such as failhttp and Exit. They are consulted after
+// the explicit values of the error and its causes and after the mappings of error codes and domains
+// (see SetExitCodeForCode and SetHttpStatusCodeForCode). Errors keep reporting their own unset values
+// through their ErrorCode, ErrorExitCode, ErrorHttpStatusCode and ErrorDomain methods. Since the
+// mappings are looked up using Domain, errors without a domain use the mappings of the default domain.
+//
+// Empty or non-positive fields select the built-in defaults, and HTTP status codes that may not be set
+// on an error (see SetRelaxedHttpStatusCodes) are ignored, so SetDefaults(Defaults{}) restores the
+// built-in defaults. It is safe to call SetDefaults concurrently.
+//
+// Example:
+//
+//	fail.SetDefaults(fail.Defaults{
+//		Code:     "ERR_INTERNAL",
+//		ExitCode: fail.ExitCodeSoftware,
+//		Domain:   fail.DomainInternal,
+//	})
+func SetDefaults(d Defaults) {
+	if d.Code == "" {
+		d.Code = builtinDefaults.Code
+	}
+
+	if d.ExitCode <= 0 {
+		d.ExitCode = builtinDefaults.ExitCode
+	}
+
+	if !validHttpStatusCode(d.HttpStatusCode) {
+		d.HttpStatusCode = builtinDefaults.HttpStatusCode
+	}
+
+	if d == builtinDefaults {
+		defaults.Store(nil)
+		return
+	}
+
+	defaults.Store(&d)
+}
+
+// CurrentDefaults returns the defaults in effect, as set using SetDefaults.
+func CurrentDefaults() Defaults {
+	if d := defaults.Load(); d != nil {
+		return *d
+	}
+
+	return builtinDefaults
+}
@@ -0,0 +1,163 @@
+package fail
+
+import "time"
+
+// Snapshot is an exported, serializable view of an error and its causes.
+//
+// A Snapshot contains the same information as the accessor functions of this package
+// (Message, UserMessage, Code, Domain, ...) return for an error, captured at a single point in time.
+// It gives consumers a stable representation that does not depend on the unexported fields
+// of Fail, and can be encoded with encoding/json or any other struct-based encoder.
+//
+// SchemaVersion is only set on the top-level snapshot, see fail.SchemaVersion.
+// The Label of a snapshot in Causes is the label of that cause, see Builder.CauseLabeled,
+// and the Role of a snapshot in Associated is the role of that associated error, see Builder.AssociateRole.
+// ExitCodeInferred and HTTPStatusInferred report whether ExitCode and HTTPStatus were not set explicitly
+// on the error or its direct causes, but derived from its code, its domain or the defaults (see ExitCode
+// and HttpStatusCode), so that FromSnapshot restores them as such.
+//
+// Snapshots are created with fail.Details or Fail.Details.
+//
+// Example:
+//
+//	details := fail.Details(err)
+//	fmt.Println(details.Code, details.HTTPStatus)
+type Snapshot struct {
+	SchemaVersion      int            `json:"schema_version,omitempty"`
+	Msg                string         `json:"msg"`
+	UserMsg            string         `json:"user_msg,omitempty"`
+	Op                 string         `json:"op,omitempty"`
+	HelpURL            string         `json:"help_url,omitempty"`
+	Fields             []FieldError   `json:"fields,omitempty"`
+	Code               string         `json:"code,omitempty"`
+	Domain             string         `json:"domain,omitempty"`
+	ExitCode           int            `json:"exit_code,omitempty"`
+	HTTPStatus         int            `json:"http_status_code,omitempty"`
+	ExitCodeInferred   bool           `json:"exit_code_inferred,omitempty"`
+	HTTPStatusInferred bool           `json:"http_status_code_inferred,omitempty"`
+	Time               time.Time      `json:"time,omitzero"`
+	Tags               []string       `json:"tags,omitempty"`
+	Attrs              map[string]any `json:"attributes,omitempty"`
+	TraceId            string         `json:"trace_id,omitempty"`
+	SpanId             string         `json:"span_id,omitempty"`
+	CorrelationId      string         `json:"correlation_id,omitempty"`
+	Id                 string         `json:"error_id,omitempty"`
+	Source             SourceLocation `json:"source,omitzero"`
+	Duration           time.Duration  `json:"duration,omitempty"`
+	Retryable          bool           `json:"retryable,omitempty"`
+	Transience         TransienceKind `json:"transience,omitzero"`
+	RetryAfter         time.Duration  `json:"retry_after,omitempty"`
+	Label              string         `json:"label,omitempty"`
+	Role               string         `json:"role,omitempty"`
+	Causes             []Snapshot     `json:"causes,omitempty"`
+	Associated         []Snapshot     `json:"associated,omitempty"`
+}
+
+// Details returns a Snapshot of the provided error and, recursively, its causes and associated errors.
+//
+// The error does not have to be a Fail; the snapshot is filled using the accessor functions of this
+// package, so any error implementing the fail.Error* interfaces is supported. Recursion is bounded
+// by MaxDepth and MaxWidth, and cycles in the error graph are skipped.
+// If err is nil, Details returns the zero Snapshot.
+//
+// Example:
+//
+//	details := fail.Details(err)
+//	_ = json.NewEncoder(w).Encode(details)
+func Details(err error) Snapshot {
+	if err == nil {
+		return Snapshot{}
+	}
+
+	s := details(err, 0, newVisitSet())
+	s.SchemaVersion = SchemaVersion
+
+	return s
+}
+
+// Details returns a Snapshot of the Fail and, recursively, its causes and associated errors.
+//
+// This is equivalent to calling fail.Details(f).
+func (f Fail) Details() Snapshot {
+	return Details(f)
+}
+
+// FromSnapshot converts a Snapshot back into a Fail.
+//
+// All fields of the snapshot are restored, including nested causes and associated errors,
+// which are always restored as Fail errors. Nesting beyond MaxDepth is dropped.
+// This allows snapshots that were transported using encoding/json or another encoder to be
+// turned back into errors.
+//
+// Example:
+//
+//	var s fail.Snapshot
+//	_ = json.Unmarshal(data, &s)
+//	err := fail.FromSnapshot(s)
+func FromSnapshot(s Snapshot) Fail {
+	return fromSnapshot(s, 0)
+}
+
+func details(err error, depth int, visited visitSet) Snapshot {
+	_, exitCodeSet := explicitExitCode(err)
+	_, httpStatusCodeSet := explicitHttpStatusCode(err)
+
+	s := Snapshot{
+		Msg:                Message(err),
+		UserMsg:            UserMessage(err),
+		Op:                 Op(err),
+		HelpURL:            HelpURL(err),
+		Fields:             Fields(err),
+		Code:               Code(err),
+		Domain:             Domain(err),
+		ExitCode:           ExitCode(err),
+		HTTPStatus:         HttpStatusCode(err),
+		Time:               Time(err),
+		Tags:               Tags(err),
+		Attrs:              Attributes(err),
+		TraceId:            TraceId(err),
+		SpanId:             SpanId(err),
+		CorrelationId:      CorrelationId(err),
+		Id:                 ownId(err),
+		Source:             Source(err),
+		Duration:           Duration(err),
+		Retryable:          Retryable(err),
+		Transience:         Transience(err),
+		RetryAfter:         RetryAfter(err),
+		ExitCodeInferred:   !exitCodeSet,
+		HTTPStatusInferred: !httpStatusCodeSet,
+	}
+
+	if depth+1 >= MaxDepth() || !visited.enter(err) {
+		return s
+	}
+	defer visited.leave(err)
+
+	causes, labels := expandJoined(Causes(err), CauseLabels(err))
+	s.Causes = detailsSlice(causes, depth+1, visited)
+	for i, label := range labels {
+		if i < len(s.Causes) {
+			s.Causes[i].Label = label
+		}
+	}
+
+	s.Associated = detailsSlice(Associated(err), depth+1, visited)
+	for i, role := range AssociatedRoles(err) {
+		if i < len(s.Associated) {
+			s.Associated[i].Role = role
+		}
+	}
+
+	return s
+}
+
+func detailsSlice(errs []error, depth int, visited visitSet) []Snapshot {
+	var res []Snapshot
+	for _, err := range limitWidth(errs) {
+		if err != nil {
+			res = append(res, details(err, depth, visited))
+		}
+	}
+
+	return res
+}
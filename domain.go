@@ -57,8 +57,8 @@ type ErrorDomain interface {
 // Domain returns the domain name of the given error if it implements the ErrorDomain interface.
 //
 // If the error is nil, Domain returns an empty string. If the error implements
-// ErrorDomain, Domain returns the result of its ErrorDomain() method. Otherwise,
-// it returns DomainUnspecified.
+// ErrorDomain and its ErrorDomain() method returns a domain, Domain returns it. Otherwise,
+// it returns the default domain set using SetDefaults, which is DomainUnspecified unless changed.
 //
 // Example usage:
 //
@@ -74,6 +74,15 @@ func Domain(err error) string {
 		return ""
 	}
 
+	if domain := ownDomain(err); domain != DomainUnspecified {
+		return domain
+	}
+
+	return CurrentDefaults().Domain
+}
+
+// ownDomain returns the domain of err if it implements ErrorDomain, or DomainUnspecified, ignoring the default domain.
+func ownDomain(err error) string {
 	if domain, ok := err.(ErrorDomain); ok {
 		return domain.ErrorDomain()
 	}
@@ -0,0 +1,75 @@
+package fail
+
+import "time"
+
+// ErrorDuration is an error type that provides the duration of the operation that failed.
+//
+// Implementations of this interface should return how long the operation had been running
+// when the error occurred, such as the latency of a failed request. The returned duration
+// may be zero if no duration is known.
+//
+// Example usage:
+//
+//	type MyError struct{}
+//	func (e *MyError) Error() string { return "request timed out" }
+//	func (e *MyError) ErrorDuration() time.Duration { return 3 * time.Second }
+//
+//	err := &MyError{}
+//	d := fail.Duration(err) // returns 3s
+type ErrorDuration interface {
+	error
+
+	// ErrorDuration returns the duration of the operation that failed.
+	//
+	// The returned duration may be zero if no duration is known.
+	ErrorDuration() time.Duration
+}
+
+// Duration returns the duration of the failed operation associated with the provided error, if any.
+//
+// This function attempts to extract the duration from the error as follows:
+//  1. If err is nil, it returns zero.
+//  2. If err implements ErrorDuration, it returns the result of ErrorDuration().
+//  3. Otherwise, it returns zero.
+func Duration(err error) time.Duration {
+	if err == nil {
+		return 0
+	}
+
+	if d, ok := err.(ErrorDuration); ok {
+		return d.ErrorDuration()
+	}
+
+	return 0
+}
+
+// WithDuration returns a new error with the specified operation duration attached.
+//
+// This function takes an existing error and a duration, and returns a new error
+// that includes the provided duration. If the provided error is nil, it returns nil.
+// If the duration is less than or equal to zero, the original error is returned unchanged.
+//
+// The returned error will implement the ErrorDuration interface, and the duration can be
+// retrieved using the fail.Duration function.
+//
+// Example:
+//
+//	err := fail.WithDuration(primaryErr, time.Since(start))
+//
+// Parameters:
+//   - err: The original error to which the duration will be attached.
+//   - d:   The duration of the failed operation.
+//
+// Returns:
+//   - A new error with the duration attached, or nil if err is nil. If d <= 0, returns the original error.
+func WithDuration(err error, d time.Duration) error {
+	if err == nil {
+		return nil
+	}
+
+	if d <= 0 {
+		return err
+	}
+
+	return From(err).Duration(d).asFail()
+}
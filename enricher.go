@@ -0,0 +1,104 @@
+package fail
+
+// Enricher is a reusable enrichment step applied to errors, for example at service boundaries.
+//
+// Enrich receives a non-nil error and returns the enriched error. Enrichers should not modify the
+// provided error; errors are converted into enriched copies using Modify, Fail.With or the With*
+// functions of this package. Enrichers are composed into a single step using Chain.
+//
+// Example:
+//
+//	type tenantEnricher struct{ tenant string }
+//
+//	func (e tenantEnricher) Enrich(err error) error {
+//		return fail.Modify(err, func(b fail.Builder) fail.Builder {
+//			return b.Attribute("tenant", e.tenant)
+//		})
+//	}
+type Enricher interface {
+	// Enrich returns the enriched copy of the provided error.
+	Enrich(err error) error
+}
+
+// EnricherFunc is an adapter to allow the use of ordinary functions as Enrichers.
+type EnricherFunc func(err error) error
+
+// Enrich calls the underlying function.
+func (f EnricherFunc) Enrich(err error) error {
+	return f(err)
+}
+
+// chain is an Enricher applying a sequence of Enrichers in order.
+type chain []Enricher
+
+// Enrich applies the enrichers of the chain in order, skipping enrichers returning nil.
+func (c chain) Enrich(err error) error {
+	if err == nil {
+		return nil
+	}
+
+	for _, enricher := range c {
+		if enriched := enricher.Enrich(err); enriched != nil {
+			err = enriched
+		}
+	}
+
+	return err
+}
+
+// Chain composes the provided enrichers into a single Enricher applying them in order.
+//
+// Each enricher receives the error returned by the previous one. If an enricher returns nil, its result
+// is ignored and the next enricher receives the previous error, so enrichers cannot discard errors.
+// Enriching a nil error returns nil without calling the enrichers. Nil enrichers are ignored, and nested
+// chains are flattened.
+//
+// Example:
+//
+//	enrich := fail.Chain(
+//		fail.EnrichWith(func(b fail.Builder) fail.Builder {
+//			return b.AttributeMap(fail.EnvironmentAttributes())
+//		}),
+//		fail.EnricherFunc(redactTokens),
+//		fail.EnrichFingerprint(),
+//	)
+//
+//	if err := handle(ctx, req); err != nil {
+//		return enrich.Enrich(err)
+//	}
+func Chain(enrichers ...Enricher) Enricher {
+	var c chain
+	for _, enricher := range enrichers {
+		switch e := enricher.(type) {
+		case nil:
+		case chain:
+			c = append(c, e...)
+		default:
+			c = append(c, e)
+		}
+	}
+
+	return c
+}
+
+// EnrichWith returns an Enricher applying the provided options to a copy of the error using Modify.
+//
+// Example:
+//
+//	withService := fail.EnrichWith(func(b fail.Builder) fail.Builder {
+//		return b.Domain("checkout").Tag("edge")
+//	})
+func EnrichWith(opts ...FailOption) Enricher {
+	return EnricherFunc(func(err error) error {
+		return Modify(err, opts...)
+	})
+}
+
+// EnrichFingerprint returns an Enricher recording the Fingerprint of the error under FingerprintAttribute.
+//
+// Since the fingerprint ignores attributes, recording it does not change the fingerprint of the error.
+func EnrichFingerprint() Enricher {
+	return EnricherFunc(func(err error) error {
+		return WithAttributes(err, map[string]any{FingerprintAttribute: Fingerprint(err)})
+	})
+}
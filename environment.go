@@ -0,0 +1,133 @@
+package fail
+
+import (
+	"maps"
+	"os"
+	"runtime/debug"
+	"slices"
+	"sync"
+	"sync/atomic"
+)
+
+// Attribute keys of the attributes returned by EnvironmentAttributes.
+const (
+	// ModuleAttribute is the attribute key for the path of the main module of the binary.
+	ModuleAttribute = "module"
+	// ModuleVersionAttribute is the attribute key for the version of the main module of the binary.
+	ModuleVersionAttribute = "module_version"
+	// VcsRevisionAttribute is the attribute key for the VCS revision the binary was built from.
+	VcsRevisionAttribute = "vcs_revision"
+	// VcsModifiedAttribute is the attribute key recording whether the working tree had local modifications at build time.
+	VcsModifiedAttribute = "vcs_modified"
+	// GoVersionAttribute is the attribute key for the Go version the binary was built with.
+	GoVersionAttribute = "go_version"
+	// HostnameAttribute is the attribute key for the hostname of the machine running the process.
+	HostnameAttribute = "hostname"
+	// PidAttribute is the attribute key for the ID of the process.
+	PidAttribute = "pid"
+)
+
+// globalAttributes holds the attributes added to every built error, or nil if there are none.
+var globalAttributes atomic.Pointer[map[string]any]
+
+// SetGlobalAttributes sets attributes added to every error when it is built by Builder.Msg or Builder.Msgf.
+//
+// Global attributes are added before the hooks registered using AddHook are applied, and do not
+// override attributes set explicitly on the error. The map is copied. Passing nil or an empty map
+// removes the global attributes (the default). It is safe to call SetGlobalAttributes concurrently.
+//
+// Combined with EnvironmentAttributes, this makes every serialized error identify the binary and the
+// host it originates from.
+//
+// Example:
+//
+//	attrs := fail.EnvironmentAttributes()
+//	attrs["service"] = "checkout"
+//	fail.SetGlobalAttributes(attrs)
+func SetGlobalAttributes(attrs map[string]any) {
+	if len(attrs) == 0 {
+		globalAttributes.Store(nil)
+		return
+	}
+
+	attrs = maps.Clone(attrs)
+	globalAttributes.Store(&attrs)
+}
+
+// GlobalAttributes returns a copy of the attributes set using SetGlobalAttributes, or nil if there are none.
+func GlobalAttributes() map[string]any {
+	attrs := globalAttributes.Load()
+	if attrs == nil {
+		return nil
+	}
+
+	return maps.Clone(*attrs)
+}
+
+// applyGlobalAttributes adds the global attributes that are not already set to the builder.
+func (b Builder) applyGlobalAttributes() Builder {
+	attrs := globalAttributes.Load()
+	if attrs == nil {
+		return b
+	}
+
+	for _, k := range slices.Sorted(maps.Keys(*attrs)) {
+		if _, ok := b.attrs.get(k); !ok {
+			b = b.Attribute(k, (*attrs)[k])
+		}
+	}
+
+	return b
+}
+
+// environmentAttributes computes the attributes returned by EnvironmentAttributes once.
+var environmentAttributes = sync.OnceValue(func() map[string]any {
+	attrs := map[string]any{
+		PidAttribute: os.Getpid(),
+	}
+
+	if hostname, err := os.Hostname(); err == nil && hostname != "" {
+		attrs[HostnameAttribute] = hostname
+	}
+
+	info, ok := debug.ReadBuildInfo()
+	if !ok {
+		return attrs
+	}
+
+	attrs[GoVersionAttribute] = info.GoVersion
+	if info.Main.Path != "" {
+		attrs[ModuleAttribute] = info.Main.Path
+	}
+	if info.Main.Version != "" {
+		attrs[ModuleVersionAttribute] = info.Main.Version
+	}
+
+	for _, setting := range info.Settings {
+		switch setting.Key {
+		case "vcs.revision":
+			attrs[VcsRevisionAttribute] = setting.Value
+		case "vcs.modified":
+			attrs[VcsModifiedAttribute] = setting.Value == "true"
+		}
+	}
+
+	return attrs
+})
+
+// EnvironmentAttributes returns attributes identifying the running binary and its host.
+//
+// The attributes include the module path and version, the VCS revision and modification state,
+// and the Go version from runtime/debug.BuildInfo, if available, as well as the hostname and the
+// process ID. Build information is only available in binaries built with module support, and VCS
+// information only in binaries built from a repository with go build. The attributes are computed once;
+// the returned map is a copy that may be modified.
+//
+// The attributes are not added to errors by default; use SetGlobalAttributes to opt in.
+//
+// Example:
+//
+//	fail.SetGlobalAttributes(fail.EnvironmentAttributes())
+func EnvironmentAttributes() map[string]any {
+	return maps.Clone(environmentAttributes())
+}
@@ -0,0 +1,164 @@
+package fail
+
+import (
+	"sync"
+	"time"
+)
+
+// TagEscalated is the tag added by an Escalator to errors that occurred too frequently.
+const TagEscalated = "escalated"
+
+// EscalationCountAttribute is the attribute key under which an Escalator records the number of
+// occurrences of an escalated error within the current window.
+const EscalationCountAttribute = "occurrences"
+
+// maxEscalatorEntries is the number of tracked fingerprints above which an Escalator discards expired entries.
+const maxEscalatorEntries = 10000
+
+// maxEscalatorIds is the number of most recently counted error IDs an Escalator remembers.
+const maxEscalatorIds = 10000
+
+// escalatedSeverity maps a severity to the severity of an escalated error.
+var escalatedSeverity = map[string]string{
+	SeverityInfo:    SeverityWarning,
+	SeverityWarning: SeverityError,
+	SeverityError:   SeverityCritical,
+}
+
+// Escalator promotes errors that occur frequently, so that flapping warnings become errors in logs and alerts.
+//
+// Occurrences are counted by Fingerprint within a fixed window that starts with the first occurrence.
+// From the Threshold-th occurrence within the window on, errors are tagged with TagEscalated, the number
+// of occurrences is recorded under EscalationCountAttribute, and their severity (see SeverityAttribute),
+// if set, is raised by one level: info becomes warning, warning becomes error and error becomes critical.
+// Every occurrence is counted once: errors rebuilt from an already counted error, for example using
+// From, a With function or Modify, keep its ID and are not counted again. The Escalator remembers the
+// IDs of the most recently counted errors only, so a rebuild long after the error was created may be
+// counted as a new occurrence.
+// An Escalator is safe for concurrent use and must be created using NewEscalator.
+//
+// Example:
+//
+//	escalator := fail.NewEscalator(10, time.Minute)
+//	fail.AddHook(escalator.Hook())
+type Escalator struct {
+	threshold int
+	window    time.Duration
+
+	mu      sync.Mutex
+	entries map[string]*escalationEntry
+	ids     map[string]struct{}
+	order   []string
+	next    int
+}
+
+// escalationEntry counts the occurrences of a fingerprint within the current window.
+type escalationEntry struct {
+	start time.Time
+	count int
+}
+
+// NewEscalator creates an Escalator promoting errors that occur at least threshold times within window.
+//
+// A threshold below 1 is raised to 1, escalating every error.
+//
+// Example:
+//
+//	escalator := fail.NewEscalator(5, 30*time.Second)
+func NewEscalator(threshold int, window time.Duration) *Escalator {
+	return &Escalator{
+		threshold: max(threshold, 1),
+		window:    window,
+		entries:   make(map[string]*escalationEntry),
+		ids:       make(map[string]struct{}),
+	}
+}
+
+// Hook returns a FailOption applying the Escalator to errors, for use with AddHook or Modify.
+func (e *Escalator) Hook() FailOption {
+	return e.apply
+}
+
+// apply counts the occurrence of the error being built and escalates it if it is too frequent.
+func (e *Escalator) apply(b Builder) Builder {
+	count, ok := e.observe(b.id, Fingerprint(Fail(b)), time.Now())
+	if !ok || count < e.threshold {
+		return b
+	}
+
+	b = b.Tag(TagEscalated).Attribute(EscalationCountAttribute, count)
+	if severity, ok := b.attrs.get(SeverityAttribute); ok {
+		if s, ok := severity.(string); ok && escalatedSeverity[s] != "" {
+			b = b.Attribute(SeverityAttribute, escalatedSeverity[s])
+		}
+	}
+
+	return b
+}
+
+// observe records an occurrence of the fingerprint at now and returns the number of occurrences in its window.
+//
+// If the error with the given ID has already been counted, nothing is recorded and observe returns false.
+func (e *Escalator) observe(id, fingerprint string, now time.Time) (int, bool) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	if id != "" && !e.remember(id) {
+		return 0, false
+	}
+
+	entry, ok := e.entries[fingerprint]
+	if !ok || now.Sub(entry.start) >= e.window {
+		if len(e.entries) >= maxEscalatorEntries {
+			e.prune(now)
+		}
+
+		entry = &escalationEntry{start: now}
+		e.entries[fingerprint] = entry
+	}
+
+	entry.count++
+
+	return entry.count, true
+}
+
+// remember records the ID as counted and reports whether it was new.
+//
+// Once maxEscalatorIds IDs are remembered, each new ID replaces the oldest one.
+func (e *Escalator) remember(id string) bool {
+	if _, ok := e.ids[id]; ok {
+		return false
+	}
+
+	if len(e.order) < maxEscalatorIds {
+		e.order = append(e.order, id)
+	} else {
+		delete(e.ids, e.order[e.next])
+		e.order[e.next] = id
+		e.next = (e.next + 1) % maxEscalatorIds
+	}
+
+	e.ids[id] = struct{}{}
+
+	return true
+}
+
+// prune discards the entries whose window has expired.
+func (e *Escalator) prune(now time.Time) {
+	for fingerprint, entry := range e.entries {
+		if now.Sub(entry.start) >= e.window {
+			delete(e.entries, fingerprint)
+		}
+	}
+}
+
+// Reset discards all counted occurrences.
+func (e *Escalator) Reset() {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	clear(e.entries)
+	clear(e.ids)
+	e.order = e.order[:0]
+	e.next = 0
+}
@@ -0,0 +1,104 @@
+package fail
+
+import (
+	"bytes"
+	"errors"
+	"io/fs"
+	"os/exec"
+	"strings"
+	"syscall"
+)
+
+// Attribute keys set by FromExecError.
+const (
+	// CommandAttribute is the attribute key for the command line of a failed command.
+	CommandAttribute = "command"
+	// StderrAttribute is the attribute key for the tail of the standard error output of a failed command.
+	StderrAttribute = "stderr"
+	// SignalAttribute is the attribute key for the signal that terminated a command or process.
+	SignalAttribute = "signal"
+)
+
+// maxStderrTail is the maximum number of bytes of standard error output recorded by FromExecError.
+const maxStderrTail = 2048
+
+// FromExecError converts an error returned by running an external command into a Fail.
+//
+// The returned error keeps the message and causes of err, has DomainDependency as its domain, and
+// carries an exit code that mirrors the command's, so that command line tools can pass it through:
+//   - commands that exited with a non-zero status: that status
+//   - commands terminated by a signal: 128 plus the signal number
+//   - commands that could not be found: 127, ErrCodeNotFound
+//   - commands that could not be executed due to missing permissions: 126, ErrCodeForbidden
+//
+// If cmd is not nil, its command line is recorded as the CommandAttribute attribute. The last 2048
+// bytes of the command's standard error output are recorded as the StderrAttribute attribute if
+// they are available, either from *exec.ExitError (when using Cmd.Output) or from a *bytes.Buffer
+// or *strings.Builder assigned to cmd.Stderr.
+// If err is nil, FromExecError returns nil.
+//
+// Example:
+//
+//	cmd := exec.Command("git", "fetch")
+//	if err := cmd.Run(); err != nil {
+//		fail.Fatal(fail.FromExecError(err, cmd))
+//	}
+func FromExecError(err error, cmd *exec.Cmd) error {
+	if err == nil {
+		return nil
+	}
+
+	b := From(err).Domain(DomainDependency).Tag(TagDependency)
+
+	if cmd != nil {
+		b = b.Attribute(CommandAttribute, cmd.String())
+	}
+
+	var exitErr *exec.ExitError
+	if errors.As(err, &exitErr) {
+		if code := exitErr.ExitCode(); code > 0 {
+			b = b.ExitCode(code)
+		} else if ws, ok := exitErr.Sys().(interface {
+			Signaled() bool
+			Signal() syscall.Signal
+		}); ok && ws.Signaled() {
+			b = b.ExitCode(128+int(ws.Signal())).Attribute(SignalAttribute, ws.Signal().String())
+		}
+	}
+
+	switch {
+	case errors.Is(err, exec.ErrNotFound), errors.Is(err, fs.ErrNotExist):
+		b = b.Code(ErrCodeNotFound).ExitCode(127)
+	case errors.Is(err, fs.ErrPermission):
+		b = b.Code(ErrCodeForbidden).ExitCode(126)
+	}
+
+	if stderr := stderrTail(exitErr, cmd); stderr != "" {
+		b = b.Attribute(StderrAttribute, stderr)
+	}
+
+	return b.Msg(Message(err))
+}
+
+// stderrTail returns the last maxStderrTail bytes of the standard error output of a command, if available.
+func stderrTail(exitErr *exec.ExitError, cmd *exec.Cmd) string {
+	var stderr string
+	switch {
+	case exitErr != nil && len(exitErr.Stderr) > 0:
+		stderr = string(exitErr.Stderr)
+	case cmd != nil:
+		switch w := cmd.Stderr.(type) {
+		case *bytes.Buffer:
+			stderr = w.String()
+		case *strings.Builder:
+			stderr = w.String()
+		}
+	}
+
+	stderr = strings.TrimSpace(stderr)
+	if len(stderr) > maxStderrTail {
+		stderr = "..." + stderr[len(stderr)-maxStderrTail:]
+	}
+
+	return stderr
+}
@@ -5,6 +5,40 @@ import "os"
 // DefaultExitCode is the default exit code to use when no specific exit code is set.
 const DefaultExitCode = 1
 
+// Exit code constants following the BSD sysexits.h conventions.
+const (
+	// ExitCodeUsage indicates the command was used incorrectly.
+	ExitCodeUsage = 64
+	// ExitCodeDataErr indicates the input data was incorrect.
+	ExitCodeDataErr = 65
+	// ExitCodeNoInput indicates an input file did not exist or was not readable.
+	ExitCodeNoInput = 66
+	// ExitCodeNoUser indicates the specified user did not exist.
+	ExitCodeNoUser = 67
+	// ExitCodeNoHost indicates the specified host did not exist.
+	ExitCodeNoHost = 68
+	// ExitCodeUnavailable indicates a service is unavailable.
+	ExitCodeUnavailable = 69
+	// ExitCodeSoftware indicates an internal software error.
+	ExitCodeSoftware = 70
+	// ExitCodeOSErr indicates an operating system error.
+	ExitCodeOSErr = 71
+	// ExitCodeOSFile indicates a system file did not exist or could not be opened.
+	ExitCodeOSFile = 72
+	// ExitCodeCantCreate indicates an output file could not be created.
+	ExitCodeCantCreate = 73
+	// ExitCodeIOErr indicates an error occurred while doing I/O.
+	ExitCodeIOErr = 74
+	// ExitCodeTempFail indicates a temporary failure; the operation may succeed when retried.
+	ExitCodeTempFail = 75
+	// ExitCodeProtocol indicates the remote system returned something invalid during a protocol exchange.
+	ExitCodeProtocol = 76
+	// ExitCodeNoPerm indicates insufficient permission to perform the operation.
+	ExitCodeNoPerm = 77
+	// ExitCodeConfig indicates a configuration error.
+	ExitCodeConfig = 78
+)
+
 // ErrorExitCode is an error type that provides a program exit code.
 //
 // Implementations of this interface should return a non-zero exit code to indicate failure.
@@ -32,10 +66,16 @@ type ErrorExitCode interface {
 //
 // This function determines the exit code as follows:
 //  1. If err is nil, it returns 0 (success).
-//  2. If err implements ErrorExitCode, it returns the result of ErrorExitCode().
-//  3. Otherwise, it recursively examines the direct causes of err (using Causes(err)).
-//     If any cause implements ErrorExitCode, it returns the maximum exit code found among them.
-//  4. If no exit code is found, it returns DefaultExitCode.
+//  2. If err implements ErrorExitCode and its exit code was set explicitly, it returns that exit code.
+//  3. If err does not implement ErrorExitCode, it examines the direct causes of err (using Causes(err)).
+//     If any cause has an explicitly set exit code, it returns the maximum exit code found among them.
+//  4. Otherwise, it returns the exit code mapped to the error code or domain of err (see SetExitCodeForCode
+//     and SetExitCodeForDomain), or the default exit code set using SetDefaults (DefaultExitCode unless
+//     changed) if neither is mapped.
+//
+// The exit code of a Fail counts as set explicitly once it has been set using Builder.ExitCode or
+// WithExitCode, even if it equals DefaultExitCode. For other implementations of ErrorExitCode, any exit
+// code other than DefaultExitCode counts as set explicitly.
 //
 // This allows error types to specify custom exit codes, and for composed/multi-cause errors
 // to propagate the most severe exit code.
@@ -44,20 +84,46 @@ func ExitCode(err error) int {
 		return 0
 	}
 
-	if exitCode, ok := err.(ErrorExitCode); ok {
-		return exitCode.ErrorExitCode()
+	if code, ok := explicitExitCode(err); ok {
+		return code
+	}
+
+	return mappedExitCode(err)
+}
+
+// explicitExitCode returns the explicitly set exit code of err, or the maximum one of its direct causes.
+func explicitExitCode(err error) (int, bool) {
+	if _, ok := err.(ErrorExitCode); ok {
+		return ownExitCode(err)
+	}
+
+	maxExitCode, found := DefaultExitCode, false
+	for _, cause := range limitWidth(Causes(err)) {
+		if code, ok := ownExitCode(cause); ok && code >= maxExitCode {
+			maxExitCode, found = code, true
+		}
 	}
 
-	maxExitCode := DefaultExitCode
-	for _, cause := range Causes(err) {
-		if exitCode, ok := cause.(ErrorExitCode); ok {
-			if exitCode.ErrorExitCode() > maxExitCode {
-				maxExitCode = exitCode.ErrorExitCode()
-			}
+	return maxExitCode, found
+}
+
+// ownExitCode returns the exit code of err itself and whether it was set explicitly.
+func ownExitCode(err error) (int, bool) {
+	switch err := err.(type) {
+	case Fail:
+		return err.exitCode, err.exitCodeSet
+	case *Fail:
+		if err == nil {
+			return 0, false
 		}
+
+		return err.exitCode, err.exitCodeSet
+	case ErrorExitCode:
+		code := err.ErrorExitCode()
+		return code, code != DefaultExitCode
 	}
 
-	return maxExitCode
+	return 0, false
 }
 
 // Exit exits the program with the exit code of the provided error.
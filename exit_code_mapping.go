@@ -0,0 +1,89 @@
+package fail
+
+// defaultExitCodeMappings returns the exit code mappings in effect unless changed, following sysexits.h.
+func defaultExitCodeMappings() *codeMappingTable {
+	return &codeMappingTable{
+		codes: map[string]int{
+			ErrCodeValidation:         2,
+			ErrCodeInvalidInput:       2,
+			ErrCodeMissingRequired:    2,
+			ErrCodeInvalidFormat:      2,
+			ErrCodeOutOfRange:         2,
+			ErrCodeUnauthorized:       ExitCodeNoPerm,
+			ErrCodeForbidden:          ExitCodeNoPerm,
+			ErrCodeAuthentication:     ExitCodeNoPerm,
+			ErrCodeTokenExpired:       ExitCodeNoPerm,
+			ErrCodeInvalidToken:       ExitCodeNoPerm,
+			ErrCodeNetwork:            ExitCodeUnavailable,
+			ErrCodeTimeout:            ExitCodeTempFail,
+			ErrCodeConnection:         ExitCodeUnavailable,
+			ErrCodeUnreachable:        ExitCodeNoHost,
+			ErrCodeInternal:           ExitCodeSoftware,
+			ErrCodeServiceUnavailable: ExitCodeUnavailable,
+			ErrCodeStorage:            ExitCodeIOErr,
+			ErrCodeConfiguration:      ExitCodeConfig,
+			ErrCodeRateLimited:        ExitCodeTempFail,
+			ErrCodeMaintenance:        ExitCodeTempFail,
+		},
+		domains: map[string]int{
+			DomainValidation: 2,
+			DomainConfig:     ExitCodeConfig,
+			DomainNetwork:    ExitCodeUnavailable,
+			DomainDependency: ExitCodeUnavailable,
+			DomainAuth:       ExitCodeNoPerm,
+			DomainRateLimit:  ExitCodeTempFail,
+			DomainIO:         ExitCodeIOErr,
+			DomainTimeout:    ExitCodeTempFail,
+			DomainInternal:   ExitCodeSoftware,
+		},
+	}
+}
+
+// exitCodes holds the exit code mappings consulted by ExitCode.
+var exitCodes = newCodeMapping(defaultExitCodeMappings)
+
+// SetExitCodeForCode sets the exit code returned by ExitCode for errors with the provided error code
+// and no explicit exit code.
+//
+// By default, error codes are mapped following the conventions of sysexits.h: validation errors
+// exit with 2, configuration errors with ExitCodeConfig, authentication and authorization errors
+// with ExitCodeNoPerm, network and availability errors with ExitCodeUnavailable, timeouts and rate
+// limits with ExitCodeTempFail, and internal errors with ExitCodeSoftware. Passing an exit code less
+// than or equal to zero removes the mapping of the code. It is safe to call SetExitCodeForCode concurrently.
+//
+// Example:
+//
+//	fail.SetExitCodeForCode("ERR_QUOTA_EXCEEDED", fail.ExitCodeTempFail)
+func SetExitCodeForCode(code string, exitCode int) {
+	exitCodes.setCode(code, exitCode)
+}
+
+// SetExitCodeForDomain sets the exit code returned by ExitCode for errors with the provided domain
+// and neither an explicit exit code nor a mapped error code.
+//
+// By default, the predefined domains are mapped following the conventions of sysexits.h, like the
+// predefined error codes (see SetExitCodeForCode). Passing an exit code less than or equal to zero
+// removes the mapping of the domain. It is safe to call SetExitCodeForDomain concurrently.
+//
+// Example:
+//
+//	fail.SetExitCodeForDomain(fail.DomainDatabase, fail.ExitCodeUnavailable)
+func SetExitCodeForDomain(domain string, exitCode int) {
+	exitCodes.setDomain(domain, exitCode)
+}
+
+// ResetExitCodes restores the default mappings of error codes and domains to exit codes.
+//
+// It is safe to call ResetExitCodes concurrently.
+func ResetExitCodes() {
+	exitCodes.reset()
+}
+
+// mappedExitCode returns the exit code mapped to the error code or domain of err, or the default exit code (see SetDefaults).
+func mappedExitCode(err error) int {
+	if exitCode, ok := exitCodes.lookup(err); ok {
+		return exitCode
+	}
+
+	return CurrentDefaults().ExitCode
+}
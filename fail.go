@@ -2,10 +2,10 @@ package fail
 
 import (
 	"log/slog"
+	"sort"
 	"strings"
 	"time"
 
-	"github.com/FlowSeer/wz/maps"
 	"github.com/FlowSeer/wz/slices"
 )
 
@@ -15,38 +15,62 @@ import (
 // tags, and arbitrary attributes. This struct is intended to be used as the canonical error
 // implementation for the fail package.
 type Fail struct {
+	id   string    // Unique ID of this error instance
 	time time.Time // Timestamp of when the error occurred
 
 	msg     string // The main error message (required, never empty)
 	userMsg string // Optional user-facing message
+	op      string // Name of the failed operation
+	helpURL string // Link to documentation about the error
 
-	domain         string // Domain of the error
-	code           string // Application-specific error code
-	exitCode       int    // Process exit code
-	httpStatusCode int    // HTTP status code
+	fields []FieldError // Field-level details of a validation failure
 
-	causes     []error // Direct causes of this error
-	associated []error // Associated (but not causal) errors
+	domain            string // Domain of the error
+	code              string // Application-specific error code
+	exitCode          int    // Process exit code
+	exitCodeSet       bool   // Whether the exit code was set explicitly, see ExitCode
+	httpStatusCode    int    // HTTP status code
+	httpStatusCodeSet bool   // Whether the HTTP status code was set explicitly, see HttpStatusCode
 
-	tags  map[string]struct{} // Set of string tags
-	attrs map[string]any      // Arbitrary key-value attributes
+	causes      []error   // Direct causes of this error
+	causeLabels labelList // Labels of the direct causes, aligned with causes
+	associated  []error   // Associated (but not causal) errors
+	assocRoles  labelList // Roles of the associated errors, aligned with associated
+
+	dedupeCauses bool // Whether identical causes are removed when the error is built
+	maxCauses    int  // Maximum number of causes kept when the error is built, 0 for no limit
+	verbose      bool // Whether Error renders the causes on a single line, see SetVerbose
+
+	tags  tagList  // Set of string tags
+	attrs attrList // Arbitrary key-value attributes
 
 	spanId  string // spanId is the unique identifier for the tracing span associated with this error.
 	traceId string // traceId is the unique identifier for the tracing trace associated with this error.
+
+	correlationId string // Correlation ID of the request or job that failed
+
+	source     SourceLocation // Source location where the error was created
+	duration   time.Duration  // Duration of the failed operation
+	transience TransienceKind // Whether the failure is transient or permanent
+	retryAfter time.Duration  // Minimum duration to wait before retrying
+
+	handled  bool // Whether the error has been handled, see MarkHandled
+	reported bool // Whether the error has been reported, see MarkReported
+
+	origin error // Original error converted into this Fail, see fromOrigin
 }
 
 // newFail creates a new Fail error with the given message.
 //
 // The message must not be an empty string. The returned Fail will have default values
 // for code, exitCode, httpStatusCode, and empty tags/attributes.
+// Tags and attributes are only allocated once they are added.
 func newFail(msg string) Fail {
 	return Fail{
 		msg:            msg,
 		code:           ErrCodeUnspecified,
 		exitCode:       DefaultExitCode,
 		httpStatusCode: DefaultHttpStatusCode,
-		tags:           make(map[string]struct{}),
-		attrs:          make(map[string]any),
 	}
 }
 
@@ -57,15 +81,38 @@ func newFail(msg string) Fail {
 // instance based on an existing one, without sharing mutable state.
 func (f Fail) Clone() Fail {
 	return Fail{
-		msg:            f.msg,
-		userMsg:        f.userMsg,
-		code:           f.code,
-		exitCode:       f.exitCode,
-		httpStatusCode: f.httpStatusCode,
-		causes:         slices.Clone(f.causes),
-		associated:     slices.Clone(f.associated),
-		tags:           maps.Clone(f.tags),
-		attrs:          maps.Clone(f.attrs),
+		time:              f.time,
+		msg:               f.msg,
+		userMsg:           f.userMsg,
+		op:                f.op,
+		helpURL:           f.helpURL,
+		fields:            slices.Clone(f.fields),
+		domain:            f.domain,
+		code:              f.code,
+		exitCode:          f.exitCode,
+		exitCodeSet:       f.exitCodeSet,
+		httpStatusCode:    f.httpStatusCode,
+		httpStatusCodeSet: f.httpStatusCodeSet,
+		causes:            slices.Clone(f.causes),
+		causeLabels:       slices.Clone(f.causeLabels),
+		associated:        slices.Clone(f.associated),
+		assocRoles:        slices.Clone(f.assocRoles),
+		tags:              f.tags,
+		attrs:             f.attrs,
+		spanId:            f.spanId,
+		traceId:           f.traceId,
+		correlationId:     f.correlationId,
+		id:                f.id,
+		source:            f.source,
+		duration:          f.duration,
+		transience:        f.transience,
+		retryAfter:        f.retryAfter,
+		handled:           f.handled,
+		reported:          f.reported,
+		origin:            f.origin,
+		verbose:           f.verbose,
+		dedupeCauses:      f.dedupeCauses,
+		maxCauses:         f.maxCauses,
 	}
 }
 
@@ -74,8 +121,16 @@ func (f Fail) String() string {
 }
 
 // Error returns the main error message.
+//
+// Only the messages of the error and its causes are included; metadata is omitted.
+// If verbose mode is enabled using SetVerbose or Builder.Verbose, the messages are rendered
+// on a single line as "msg: cause1: cause2".
 func (f Fail) Error() string {
-	return PrintsPretty(f)
+	if f.verbose || Verbose() {
+		return verboseError(f, 0, newVisitSet())
+	}
+
+	return PrintsPretty(f, printMessagesOnly)
 }
 
 // ErrorCauses returns the direct causes of this error.
@@ -85,6 +140,27 @@ func (f Fail) ErrorCauses() []error {
 	return f.causes
 }
 
+// Unwrap returns the direct causes of this error.
+//
+// This allows errors.Is and errors.As to examine the causes of the error. Associated errors are
+// not causes and are therefore not examined. If the Fail was converted from another error by
+// MarkHandled, MarkReported or Classify, that error is returned as well, so that errors.Is and
+// errors.As still match it.
+func (f Fail) Unwrap() []error {
+	if f.origin == nil {
+		return f.causes
+	}
+
+	return append(f.causes[:len(f.causes):len(f.causes)], f.origin)
+}
+
+// ErrorCauseLabels returns the labels of the direct causes of this error, aligned with ErrorCauses.
+//
+// Implements ErrorCauseLabels interface. The returned slice is a copy.
+func (f Fail) ErrorCauseLabels() []string {
+	return f.causeLabels.aligned(len(f.causes))
+}
+
 // ErrorAssociated returns the associated (non-causal) errors.
 //
 // Implements ErrorAssociated interface. The returned slice is a copy.
@@ -92,6 +168,13 @@ func (f Fail) ErrorAssociated() []error {
 	return slices.Clone(f.associated)
 }
 
+// ErrorAssociatedRoles returns the roles of the associated errors, aligned with ErrorAssociated.
+//
+// Implements ErrorAssociatedRoles interface. The returned slice is a copy.
+func (f Fail) ErrorAssociatedRoles() []string {
+	return f.assocRoles.aligned(len(f.associated))
+}
+
 // ErrorCode returns the application-specific error code.
 //
 // Implements ErrorCode interface.
@@ -99,6 +182,13 @@ func (f Fail) ErrorCode() string {
 	return f.code
 }
 
+// ErrorDomain returns the domain of this error.
+//
+// Implements ErrorDomain interface.
+func (f Fail) ErrorDomain() string {
+	return f.domain
+}
+
 // ErrorExitCode returns the process exit code for this error.
 //
 // Implements ErrorExitCode interface.
@@ -127,18 +217,25 @@ func (f Fail) ErrorUserMessage() string {
 	return f.userMsg
 }
 
+// ErrorOp returns the name of the failed operation.
+//
+// Implements ErrorOp interface.
+func (f Fail) ErrorOp() string {
+	return f.op
+}
+
 // ErrorTags returns a slice of tags associated with this error.
 //
 // Implements ErrorTags interface. The returned slice is a copy.
 func (f Fail) ErrorTags() []string {
-	return slices.Collect(maps.Keys(f.tags))
+	return f.tags.slice()
 }
 
 // ErrorAttributes returns a copy of the attributes map for this error.
 //
 // Implements ErrorAttributes interface.
 func (f Fail) ErrorAttributes() map[string]any {
-	return maps.Clone(f.attrs)
+	return f.attrs.toMap()
 }
 
 // ErrorTime returns the timestamp of when the error occurred.
@@ -162,6 +259,83 @@ func (f Fail) ErrorSpanId() string {
 	return f.spanId
 }
 
+// ErrorId returns the unique ID of this error instance.
+//
+// Implements ErrorId interface.
+func (f Fail) ErrorId() string {
+	return f.id
+}
+
+// ErrorCorrelationId returns the correlation ID associated with this error.
+//
+// Implements ErrorCorrelationId interface.
+func (f Fail) ErrorCorrelationId() string {
+	return f.correlationId
+}
+
+// ErrorHelpURL returns the link to documentation about this error.
+//
+// Implements ErrorHelpURL interface.
+func (f Fail) ErrorHelpURL() string {
+	return f.helpURL
+}
+
+// ErrorFields returns the field-level details of the validation failure described by this error.
+//
+// Implements ErrorFields interface. The returned slice is a copy.
+func (f Fail) ErrorFields() []FieldError {
+	return slices.Clone(f.fields)
+}
+
+// ErrorSource returns the source location where this error was created.
+//
+// Implements ErrorSource interface.
+func (f Fail) ErrorSource() SourceLocation {
+	return f.source
+}
+
+// ErrorDuration returns the duration of the failed operation.
+//
+// Implements ErrorDuration interface.
+func (f Fail) ErrorDuration() time.Duration {
+	return f.duration
+}
+
+// ErrorRetryable reports whether the failed operation may succeed when retried.
+//
+// Implements ErrorRetryable interface.
+func (f Fail) ErrorRetryable() bool {
+	return f.transience == TransienceTransient
+}
+
+// ErrorTransience classifies this error as transient or permanent.
+//
+// Implements ErrorTransience interface.
+func (f Fail) ErrorTransience() TransienceKind {
+	return f.transience
+}
+
+// ErrorRetryAfter returns the minimum duration to wait before retrying the failed operation.
+//
+// Implements ErrorRetryAfter interface.
+func (f Fail) ErrorRetryAfter() time.Duration {
+	return f.retryAfter
+}
+
+// ErrorHandled reports whether this error has been handled.
+//
+// Implements ErrorHandled interface.
+func (f Fail) ErrorHandled() bool {
+	return f.handled
+}
+
+// ErrorReported reports whether this error has been reported.
+//
+// Implements ErrorReported interface.
+func (f Fail) ErrorReported() bool {
+	return f.reported
+}
+
 // LogValue returns a slog.Value representation of the Fail error.
 //
 // Implements slog.Value interface.
@@ -173,9 +347,22 @@ func (f Fail) LogValue() slog.Value {
 	if f.userMsg != "" {
 		attrs = append(attrs, slog.String("user_msg", f.userMsg))
 	}
+	if f.op != "" {
+		attrs = append(attrs, slog.String("op", f.op))
+	}
+	if f.helpURL != "" {
+		attrs = append(attrs, slog.String("help_url", f.helpURL))
+	}
 	if f.code != "" {
 		attrs = append(attrs, slog.String("code", f.code))
 	}
+	if len(f.fields) > 0 {
+		fields := make([]slog.Attr, len(f.fields))
+		for i, field := range f.fields {
+			fields[i] = slog.String(field.Field, field.Msg)
+		}
+		attrs = append(attrs, slog.Attr{Key: "fields", Value: slog.GroupValue(fields...)})
+	}
 	if f.exitCode != 0 {
 		attrs = append(attrs, slog.Int("exit_code", f.exitCode))
 	}
@@ -191,14 +378,41 @@ func (f Fail) LogValue() slog.Value {
 	if f.traceId != "" {
 		attrs = append(attrs, slog.String("trace_id", f.traceId))
 	}
-	if len(f.tags) > 0 {
+	if f.correlationId != "" {
+		attrs = append(attrs, slog.String("correlation_id", f.correlationId))
+	}
+	if f.id != "" {
+		attrs = append(attrs, slog.String("error_id", f.id), slog.String("reference_code", referenceCode(f.id)))
+	}
+	if f.duration > 0 {
+		attrs = append(attrs, slog.Duration("duration", f.duration))
+	}
+	if f.transience != TransienceUnknown {
+		attrs = append(attrs, slog.String("transience", f.transience.String()))
+	}
+	if f.retryAfter > 0 {
+		attrs = append(attrs, slog.Duration("retry_after", f.retryAfter))
+	}
+	if !f.source.IsZero() {
+		attrs = append(attrs, slog.String("source", f.source.String()))
+	}
+	if f.tags.len() > 0 {
 		attrs = append(attrs, slog.String("tags", strings.Join(f.ErrorTags(), ",")))
 	}
-	if len(f.attrs) > 0 {
+	if f.attrs.len() > 0 {
 		var attrAttrs []any
 
-		for k, v := range f.attrs {
-			attrAttrs = append(attrAttrs, slog.Any(k, v))
+		nested := NestAttributes(f.attrs.toMap())
+		for _, a := range f.attrs.all() {
+			key := a.key
+			if _, ok := nested[key]; !ok {
+				key, _, _ = strings.Cut(key, AttributeGroupSeparator)
+			}
+
+			if value, ok := nested[key]; ok {
+				attrAttrs = append(attrAttrs, logAttr(key, value))
+				delete(nested, key)
+			}
 		}
 
 		attrs = append(attrs, slog.Group("attrs", attrAttrs...))
@@ -206,3 +420,24 @@ func (f Fail) LogValue() slog.Value {
 
 	return slog.GroupValue(attrs...)
 }
+
+// logAttr returns a slog.Attr for an attribute, rendering attribute groups as nested slog groups.
+func logAttr(key string, value any) slog.Attr {
+	group, ok := value.(map[string]any)
+	if !ok {
+		return slog.Any(key, value)
+	}
+
+	keys := make([]string, 0, len(group))
+	for k := range group {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	attrs := make([]any, 0, len(keys))
+	for _, k := range keys {
+		attrs = append(attrs, logAttr(k, group[k]))
+	}
+
+	return slog.Group(key, attrs...)
+}
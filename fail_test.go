@@ -0,0 +1,37 @@
+package fail_test
+
+import (
+	"errors"
+	"slices"
+	"testing"
+
+	"github.com/FlowSeer/fail"
+)
+
+func TestClone(t *testing.T) {
+	a, b, c := errors.New("a"), errors.New("b"), errors.New("c")
+
+	t.Run("causes are not shared", func(t *testing.T) {
+		f := fail.New().Cause(a).Msg("outer").(fail.Fail)
+		clone := f.Clone()
+
+		_ = fail.From(clone).Cause(b).Msg("outer")
+		if got := fail.Causes(f); !slices.Equal(got, []error{a}) {
+			t.Errorf("got causes %v on the original, want [a]", got)
+		}
+	})
+
+	t.Run("cause limits are kept", func(t *testing.T) {
+		f := fail.New().DedupeCauses(true).MaxCauses(2).Cause(a).Msg("outer").(fail.Fail)
+
+		for _, err := range []fail.Fail{f, f.Clone()} {
+			rebuilt := fail.From(err).Cause(a, b, c).Msg("outer")
+			if got := fail.Causes(rebuilt); !slices.Equal(got, []error{a, b}) {
+				t.Errorf("got causes %v, want [a b]", got)
+			}
+			if got := fail.Attributes(rebuilt)[fail.OmittedCausesAttribute]; got != 1 {
+				t.Errorf("got %v omitted causes, want 1", got)
+			}
+		}
+	})
+}
@@ -0,0 +1,238 @@
+package failcloud
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/FlowSeer/fail"
+)
+
+// Attribute keys set by ClassifyAWS.
+const (
+	// AttributeAWSErrorCode is the attribute key for the error code reported by the AWS service.
+	AttributeAWSErrorCode = "aws.error_code"
+	// AttributeAWSFault is the attribute key for the party at fault, "client" or "server", as reported by the SDK.
+	AttributeAWSFault = "aws.fault"
+	// AttributeAWSRequestId is the attribute key for the ID of the request, as reported by the AWS service.
+	AttributeAWSRequestId = "aws.request_id"
+	// AttributeAWSHostId is the attribute key for the extended request ID reported by Amazon S3.
+	AttributeAWSHostId = "aws.host_id"
+	// AttributeAWSService is the attribute key for the ID of the AWS service the failed operation belongs to.
+	AttributeAWSService = "aws.service"
+	// AttributeAWSOperation is the attribute key for the name of the failed operation.
+	AttributeAWSOperation = "aws.operation"
+	// AttributeAWSStatusCode is the attribute key for the HTTP status code of the response of the AWS service.
+	AttributeAWSStatusCode = "aws.status_code"
+)
+
+// awsAPIError is implemented by smithy.APIError, along with an ErrorFault() smithy.ErrorFault method.
+type awsAPIError interface {
+	error
+	ErrorCode() string
+	ErrorMessage() string
+}
+
+// awsCodes maps the error codes of AWS services to the classification applied by ClassifyAWS.
+var awsCodes = map[string]func(fail.Builder) fail.Builder{}
+
+func init() {
+	register := func(classify func(fail.Builder) fail.Builder, codes ...string) {
+		for _, code := range codes {
+			awsCodes[code] = classify
+		}
+	}
+
+	// The throttling and transient error codes retried by the SDK.
+	register(rateLimited, "Throttling", "ThrottlingException", "ThrottledException", "RequestThrottledException",
+		"TooManyRequestsException", "ProvisionedThroughputExceededException", "TransactionInProgressException",
+		"RequestLimitExceeded", "BandwidthLimitExceeded", "LimitExceededException", "RequestThrottled", "SlowDown",
+		"PriorRequestNotComplete", "EC2ThrottledException")
+	register(timeout, "RequestTimeout", "RequestTimeoutException")
+	register(unavailable, "InternalError", "InternalFailure", "InternalServerError", "InternalServerException",
+		"ServiceUnavailable", "ServiceUnavailableException", "ServiceException")
+
+	register(quotaExceeded, "ServiceQuotaExceededException", "QuotaExceededException")
+	register(status(fail.ErrCodeForbidden, 502), "AccessDenied", "AccessDeniedException", "UnauthorizedOperation",
+		"AuthorizationError")
+	register(status(fail.ErrCodeAuthentication, 502), "UnrecognizedClientException", "InvalidClientTokenId",
+		"InvalidSignatureException", "SignatureDoesNotMatch", "IncompleteSignature", "MissingAuthenticationToken",
+		"AuthFailure")
+	register(status(fail.ErrCodeTokenExpired, 502), "ExpiredToken", "ExpiredTokenException", "RequestExpired")
+	register(status(fail.ErrCodeValidation, 400), "ValidationException", "ValidationError", "InvalidParameterValue",
+		"InvalidParameterException", "InvalidParameterCombination", "MissingParameter", "InvalidArgument",
+		"InvalidRequest", "SerializationException")
+	register(status(fail.ErrCodeConflict, 409), "ConditionalCheckFailedException", "ConflictException",
+		"ResourceInUseException", "TransactionConflictException", "OptimisticLockException", "PreconditionFailed")
+	register(status(fail.ErrCodeAlreadyExists, 409), "BucketAlreadyExists", "BucketAlreadyOwnedByYou",
+		"AlreadyExistsException", "ResourceAlreadyExistsException", "EntityAlreadyExists")
+}
+
+// ClassifyAWS applies the classification of the provided AWS SDK error to the builder.
+//
+// The domain is set to fail.DomainDependency and the tag TagAWS is added. The error is classified by the
+// error code reported by the service: the throttling and transient error codes retried by the SDK are
+// classified as retryable, and common codes such as "AccessDenied", "ValidationException" and
+// "ConditionalCheckFailedException" are mapped to the corresponding fail codes. Codes starting with "NoSuch"
+// or ending with "NotFound" or "NotFoundException" are classified as fail.ErrCodeNotFound, 404. Other codes
+// are classified by the HTTP status code of the response, as described for Classify.
+//
+// The error code, the party at fault, the request ID, the S3 extended request ID, the service, the
+// operation and the HTTP status code are recorded as attributes, if available.
+// The error itself is not added as a cause.
+//
+// Example:
+//
+//	if err != nil {
+//		return failcloud.ClassifyAWS(fail.New(), err).Cause(err).Msg("failed to publish event")
+//	}
+func ClassifyAWS(b fail.Builder, err error) fail.Builder {
+	b = b.Domain(fail.DomainDependency).Tag(fail.TagDependency).Tag(TagAWS)
+	if err == nil {
+		return b
+	}
+
+	b = b.AttributeMap(awsAttributes(err))
+
+	code, ok := AWSErrorCode(err)
+	if classify, known := awsCodes[code]; ok && known {
+		return classify(b)
+	}
+
+	if ok && (strings.HasPrefix(code, "NoSuch") || strings.HasSuffix(code, "NotFound") || strings.HasSuffix(code, "NotFoundException")) {
+		return b.Code(fail.ErrCodeNotFound).HttpStatusCode(404)
+	}
+
+	if status, ok := AWSHttpStatusCode(err); ok {
+		return classifyStatus(b, status)
+	}
+
+	if awsFault(err) == "server" {
+		return classifyStatus(b, 500)
+	}
+
+	return b
+}
+
+// AWSErrorCode returns the error code reported by the AWS service, such as "NoSuchKey", if any.
+//
+// The code is taken from the first error in the chain of err (as traversed by errors.As) implementing
+// smithy.APIError, as the errors returned by the operations of the AWS SDK for Go v2 do.
+func AWSErrorCode(err error) (string, bool) {
+	apiErr, ok := awsError(err)
+	if !ok {
+		return "", false
+	}
+
+	return apiErr.ErrorCode(), true
+}
+
+// AWSRequestId returns the ID of the request reported by the AWS service, if any.
+//
+// The ID is taken from the first error in the chain of err with a ServiceRequestID() string method,
+// as implemented by the response errors of the AWS SDK for Go v2.
+func AWSRequestId(err error) string {
+	var r interface{ ServiceRequestID() string }
+	if errors.As(err, &r) {
+		return r.ServiceRequestID()
+	}
+
+	return ""
+}
+
+// AWSHttpStatusCode returns the HTTP status code of the response of the AWS service, if any.
+//
+// The status code is taken from the first error in the chain of err with an HTTPStatusCode() int method,
+// as implemented by the response errors of the AWS SDK for Go v2.
+func AWSHttpStatusCode(err error) (int, bool) {
+	var r interface{ HTTPStatusCode() int }
+	if errors.As(err, &r) && r.HTTPStatusCode() > 0 {
+		return r.HTTPStatusCode(), true
+	}
+
+	return 0, false
+}
+
+// awsError returns the first error in the chain of err implementing smithy.APIError.
+//
+// Since fail errors have ErrorCode and ErrorMessage methods as well, the ErrorFault method is required.
+func awsError(err error) (awsAPIError, bool) {
+	var res awsAPIError
+	found := find(err, func(err error) bool {
+		apiErr, ok := err.(awsAPIError)
+		if ok && reflect.ValueOf(err).MethodByName("ErrorFault").IsValid() {
+			res = apiErr
+			return true
+		}
+
+		return false
+	})
+
+	return res, found
+}
+
+// awsFault returns the party at fault reported by the smithy.APIError in the chain of err, if known.
+func awsFault(err error) string {
+	apiErr, ok := awsError(err)
+	if !ok {
+		return ""
+	}
+
+	m := reflect.ValueOf(apiErr).MethodByName("ErrorFault")
+	if m.Type().NumIn() != 0 || m.Type().NumOut() != 1 {
+		return ""
+	}
+
+	fault, ok := m.Call(nil)[0].Interface().(fmt.Stringer)
+	if !ok || fault.String() == "unknown" {
+		return ""
+	}
+
+	return fault.String()
+}
+
+// awsAttributes returns the attributes recorded by ClassifyAWS for err.
+func awsAttributes(err error) map[string]any {
+	attrs := map[string]any{}
+	if code, ok := AWSErrorCode(err); ok && code != "" {
+		attrs[AttributeAWSErrorCode] = code
+	}
+	if fault := awsFault(err); fault != "" {
+		attrs[AttributeAWSFault] = fault
+	}
+	if id := AWSRequestId(err); id != "" {
+		attrs[AttributeAWSRequestId] = id
+	}
+
+	var host interface{ ServiceHostID() string }
+	if errors.As(err, &host) && host.ServiceHostID() != "" {
+		attrs[AttributeAWSHostId] = host.ServiceHostID()
+	}
+
+	var op interface {
+		Service() string
+		Operation() string
+	}
+	if errors.As(err, &op) {
+		if op.Service() != "" {
+			attrs[AttributeAWSService] = op.Service()
+		}
+		if op.Operation() != "" {
+			attrs[AttributeAWSOperation] = op.Operation()
+		}
+	}
+
+	if status, ok := AWSHttpStatusCode(err); ok {
+		attrs[AttributeAWSStatusCode] = status
+	}
+
+	return attrs
+}
+
+// status returns a classification setting the provided code and HTTP status code.
+func status(code string, httpStatusCode int) func(fail.Builder) fail.Builder {
+	return func(b fail.Builder) fail.Builder {
+		return b.Code(code).HttpStatusCode(httpStatusCode)
+	}
+}
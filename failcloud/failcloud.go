@@ -0,0 +1,176 @@
+// Package failcloud classifies the errors of cloud provider SDKs and wraps them into fail errors.
+//
+// It recognizes the API errors of the AWS SDK for Go v2 (smithy.APIError, along with the request IDs
+// reported by the SDK) and of the Google API client libraries (googleapi.Error). SDK errors are detected
+// by their exported methods and fields, so this package does not depend on any of the SDKs.
+package failcloud
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/FlowSeer/fail"
+)
+
+// Tags set by this package to identify the cloud provider an error originates from.
+const (
+	// TagAWS is the tag of errors reported by the AWS SDK.
+	TagAWS = "aws"
+	// TagGCP is the tag of errors reported by the Google API client libraries.
+	TagGCP = "gcp"
+)
+
+// Wrap returns a new fail error with the given message, wrapping the provided cloud SDK error as its cause.
+//
+// The returned error has fail.DomainDependency as its domain, and its code, HTTP status code,
+// retryability and attributes are derived from the SDK error as described for Classify.
+// If err is nil, Wrap returns nil.
+//
+// Example:
+//
+//	out, err := client.GetObject(ctx, &s3.GetObjectInput{Bucket: &bucket, Key: &key})
+//	if err != nil {
+//		return failcloud.Wrap(err, "failed to download report")
+//	}
+func Wrap(err error, msg string) error {
+	if err == nil {
+		return nil
+	}
+
+	return Classify(fail.New(), err).Cause(err).Msg(msg)
+}
+
+// Wrapf returns a new fail error with a formatted message, wrapping the provided cloud SDK error as its cause.
+//
+// If err is nil, Wrapf returns nil. See Wrap for details.
+//
+// Example:
+//
+//	err = failcloud.Wrapf(err, "failed to download report %s", key)
+func Wrapf(err error, format string, args ...any) error {
+	if err == nil {
+		return nil
+	}
+
+	return Wrap(err, fmt.Sprintf(format, args...))
+}
+
+// Classify applies the classification of the provided cloud SDK error to the builder.
+//
+// The domain is set to fail.DomainDependency, and the error is classified according to the provider:
+//   - AWS SDK errors are classified by their error code, falling back to the HTTP status code of the
+//     response, see ClassifyAWS
+//   - Google API errors are classified by the reason of their first error item, falling back to their
+//     HTTP status code, see ClassifyGoogle
+//   - context deadline exceeded: fail.ErrCodeTimeout, 504, fail.TagTimeout, retryable
+//   - context cancellation: fail.TagCanceled
+//
+// HTTP status codes reported by the provider are mapped as follows:
+//   - 400: fail.ErrCodeValidation, 400
+//   - 401: fail.ErrCodeAuthentication, 502
+//   - 403: fail.ErrCodeForbidden, 502
+//   - 404: fail.ErrCodeNotFound, 404
+//   - 409 and 412: fail.ErrCodeConflict, 409
+//   - 429: fail.ErrCodeRateLimited, 429, retryable
+//   - 502 and 503: fail.ErrCodeServiceUnavailable, 503, retryable
+//   - 504: fail.ErrCodeTimeout, 504, retryable
+//   - other 5xx status codes: fail.ErrCodeInternal, 502, retryable
+//
+// Authentication and authorization failures are reported with status code 502, since they concern the
+// credentials of the service rather than those of its clients. The error itself is not added as a cause.
+func Classify(b fail.Builder, err error) fail.Builder {
+	b = b.Domain(fail.DomainDependency).Tag(fail.TagDependency)
+	if err == nil {
+		return b
+	}
+
+	if _, ok := AWSErrorCode(err); ok || AWSRequestId(err) != "" {
+		return ClassifyAWS(b, err)
+	}
+
+	if _, ok := googleError(err); ok {
+		return ClassifyGoogle(b, err)
+	}
+
+	switch {
+	case errors.Is(err, context.DeadlineExceeded):
+		return timeout(b)
+	case errors.Is(err, context.Canceled):
+		return b.Tag(fail.TagCanceled)
+	}
+
+	return b
+}
+
+// find reports whether any error in the chain of err, as traversed by errors.As, satisfies match.
+func find(err error, match func(err error) bool) bool {
+	if err == nil {
+		return false
+	}
+
+	if match(err) {
+		return true
+	}
+
+	switch u := err.(type) {
+	case interface{ Unwrap() error }:
+		return find(u.Unwrap(), match)
+	case interface{ Unwrap() []error }:
+		for _, e := range u.Unwrap() {
+			if find(e, match) {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+// classifyStatus applies the classification of an HTTP status code reported by a provider to the builder.
+func classifyStatus(b fail.Builder, status int) fail.Builder {
+	switch status {
+	case 400:
+		return b.Code(fail.ErrCodeValidation).HttpStatusCode(400)
+	case 401:
+		return b.Code(fail.ErrCodeAuthentication).HttpStatusCode(502)
+	case 403:
+		return b.Code(fail.ErrCodeForbidden).HttpStatusCode(502)
+	case 404:
+		return b.Code(fail.ErrCodeNotFound).HttpStatusCode(404)
+	case 409, 412:
+		return b.Code(fail.ErrCodeConflict).HttpStatusCode(409)
+	case 429:
+		return rateLimited(b)
+	case 502, 503:
+		return unavailable(b)
+	case 504:
+		return timeout(b)
+	}
+
+	if status >= 500 && status <= 599 {
+		return b.Code(fail.ErrCodeInternal).HttpStatusCode(502).Retryable(true)
+	}
+
+	return b
+}
+
+// rateLimited classifies the error as a retryable throttling of requests.
+func rateLimited(b fail.Builder) fail.Builder {
+	return b.Code(fail.ErrCodeRateLimited).HttpStatusCode(429).Tag(fail.TagRateLimit).Retryable(true)
+}
+
+// quotaExceeded classifies the error as an exceeded quota, which is not retryable.
+func quotaExceeded(b fail.Builder) fail.Builder {
+	return b.Code(fail.ErrCodeQuotaExceeded).HttpStatusCode(429).Tag(fail.TagRateLimit)
+}
+
+// timeout classifies the error as a retryable timeout.
+func timeout(b fail.Builder) fail.Builder {
+	return b.Code(fail.ErrCodeTimeout).HttpStatusCode(504).Tag(fail.TagTimeout).Retryable(true)
+}
+
+// unavailable classifies the error as a retryable unavailability of the provider.
+func unavailable(b fail.Builder) fail.Builder {
+	return b.Code(fail.ErrCodeServiceUnavailable).HttpStatusCode(503).Retryable(true)
+}
@@ -0,0 +1,181 @@
+package failcloud
+
+import (
+	"reflect"
+	"strings"
+
+	"github.com/FlowSeer/fail"
+)
+
+// Attribute keys set by ClassifyGoogle.
+const (
+	// AttributeGCPStatusCode is the attribute key for the HTTP status code reported by the Google API.
+	AttributeGCPStatusCode = "gcp.status_code"
+	// AttributeGCPReason is the attribute key for the reason of the error reported by the Google API.
+	AttributeGCPReason = "gcp.reason"
+	// AttributeGCPRequestId is the attribute key for the ID of the request, as reported by the Google API.
+	AttributeGCPRequestId = "gcp.request_id"
+)
+
+// googleReasons maps the error reasons of Google APIs to the classification applied by ClassifyGoogle.
+var googleReasons = map[string]func(fail.Builder) fail.Builder{
+	"rateLimitExceeded":       rateLimited,
+	"userRateLimitExceeded":   rateLimited,
+	"RATE_LIMIT_EXCEEDED":     rateLimited,
+	"quotaExceeded":           quotaExceeded,
+	"dailyLimitExceeded":      quotaExceeded,
+	"backendError":            unavailable,
+	"internalError":           unavailable,
+	"notFound":                status(fail.ErrCodeNotFound, 404),
+	"duplicate":               status(fail.ErrCodeAlreadyExists, 409),
+	"conflict":                status(fail.ErrCodeConflict, 409),
+	"conditionNotMet":         status(fail.ErrCodeConflict, 409),
+	"invalid":                 status(fail.ErrCodeValidation, 400),
+	"invalidParameter":        status(fail.ErrCodeValidation, 400),
+	"required":                status(fail.ErrCodeMissingRequired, 400),
+	"badRequest":              status(fail.ErrCodeValidation, 400),
+	"forbidden":               status(fail.ErrCodeForbidden, 502),
+	"insufficientPermissions": status(fail.ErrCodeForbidden, 502),
+	"authError":               status(fail.ErrCodeAuthentication, 502),
+	"unauthorized":            status(fail.ErrCodeAuthentication, 502),
+}
+
+// ClassifyGoogle applies the classification of the provided Google API error to the builder.
+//
+// The domain is set to fail.DomainDependency and the tag TagGCP is added. The error is classified by its
+// reason, such as "rateLimitExceeded", "backendError" or "notFound", falling back to its HTTP status code
+// as described for Classify. Rate limits are classified as retryable, while exceeded quotas are not.
+//
+// The HTTP status code, the reason and the request ID are recorded as attributes, if available.
+// The error itself is not added as a cause.
+//
+// Example:
+//
+//	if _, err := service.Objects.Get(bucket, name).Do(); err != nil {
+//		return failcloud.ClassifyGoogle(fail.New(), err).Cause(err).Msg("failed to load object")
+//	}
+func ClassifyGoogle(b fail.Builder, err error) fail.Builder {
+	b = b.Domain(fail.DomainDependency).Tag(fail.TagDependency).Tag(TagGCP)
+	if err == nil {
+		return b
+	}
+
+	status, ok := GoogleStatusCode(err)
+	if !ok {
+		return b
+	}
+
+	attrs := map[string]any{AttributeGCPStatusCode: status}
+	reason := GoogleReason(err)
+	if reason != "" {
+		attrs[AttributeGCPReason] = reason
+	}
+	if id := GoogleRequestId(err); id != "" {
+		attrs[AttributeGCPRequestId] = id
+	}
+	b = b.AttributeMap(attrs)
+
+	if classify, ok := googleReasons[reason]; ok {
+		return classify(b)
+	}
+
+	return classifyStatus(b, status)
+}
+
+// GoogleStatusCode returns the HTTP status code of the first googleapi.Error in the chain of err, if any.
+func GoogleStatusCode(err error) (int, bool) {
+	v, ok := googleError(err)
+	if !ok {
+		return 0, false
+	}
+
+	f := v.FieldByName("Code")
+	if !f.IsValid() || f.Kind() != reflect.Int {
+		return 0, false
+	}
+
+	return int(f.Int()), true
+}
+
+// GoogleReason returns the reason of the first googleapi.Error in the chain of err, if any.
+//
+// The reason is taken from the first error item of the error, or from its google.rpc.ErrorInfo detail.
+func GoogleReason(err error) string {
+	v, ok := googleError(err)
+	if !ok {
+		return ""
+	}
+
+	if items := v.FieldByName("Errors"); items.IsValid() && items.Kind() == reflect.Slice {
+		for i := range items.Len() {
+			if f := items.Index(i).FieldByName("Reason"); f.IsValid() && f.Kind() == reflect.String && f.String() != "" {
+				return f.String()
+			}
+		}
+	}
+
+	reason, _ := googleDetail(v, "google.rpc.ErrorInfo", "reason")
+	return reason
+}
+
+// GoogleRequestId returns the ID of the request of the first googleapi.Error in the chain of err, if any.
+//
+// The ID is taken from the google.rpc.RequestInfo detail of the error.
+func GoogleRequestId(err error) string {
+	v, ok := googleError(err)
+	if !ok {
+		return ""
+	}
+
+	id, _ := googleDetail(v, "google.rpc.RequestInfo", "requestId")
+	return id
+}
+
+// googleError returns the struct value of the first *googleapi.Error in the chain of err.
+func googleError(err error) (reflect.Value, bool) {
+	var res reflect.Value
+	found := find(err, func(err error) bool {
+		v := reflect.ValueOf(err)
+		if v.Kind() != reflect.Pointer || v.IsNil() || v.Elem().Kind() != reflect.Struct {
+			return false
+		}
+
+		t := v.Elem().Type()
+		if t.Name() != "Error" || !strings.HasSuffix(t.PkgPath(), "/googleapi") {
+			return false
+		}
+
+		res = v.Elem()
+		return true
+	})
+
+	return res, found
+}
+
+// googleDetail returns the string field key of the first detail of the error with the provided type.
+//
+// Details are decoded from the JSON error response, so they are maps keyed by the JSON field names,
+// with their type in the "@type" field, as in "type.googleapis.com/google.rpc.RequestInfo".
+func googleDetail(v reflect.Value, typ, key string) (string, bool) {
+	details := v.FieldByName("Details")
+	if !details.IsValid() || details.Kind() != reflect.Slice {
+		return "", false
+	}
+
+	for i := range details.Len() {
+		detail, ok := details.Index(i).Interface().(map[string]any)
+		if !ok {
+			continue
+		}
+
+		if t, _ := detail["@type"].(string); !strings.HasSuffix(t, "/"+typ) {
+			continue
+		}
+
+		if s, ok := detail[key].(string); ok && s != "" {
+			return s, true
+		}
+	}
+
+	return "", false
+}
@@ -0,0 +1,187 @@
+// Package failcompat provides a drop-in replacement for the API of github.com/pkg/errors.
+//
+// All functions have the signatures of their github.com/pkg/errors counterparts, but return
+// fail errors. Large codebases can therefore migrate to fail by changing only the import path,
+// and then adopt the fail builder incrementally.
+//
+// Instead of a stack trace, the returned errors record the source location of the caller
+// (see fail.Source). Stack traces of errors created by github.com/pkg/errors are still available
+// through fail.StackTrace.
+package failcompat
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/FlowSeer/fail"
+)
+
+// New returns an error with the supplied message.
+func New(message string) error {
+	return fail.New().Msg(message)
+}
+
+// Errorf formats according to a format specifier and returns the string as a value that satisfies error.
+func Errorf(format string, args ...any) error {
+	return fail.New().Msgf(format, args...)
+}
+
+// WithStack annotates err with the source location of the caller.
+//
+// If err is a Fail without a source location, the location is added to a copy of err.
+// Otherwise, err is wrapped as the cause of a new error with the same message, so that
+// Cause and Is still find it. If err is nil, WithStack returns nil.
+func WithStack(err error) error {
+	if err == nil {
+		return nil
+	}
+
+	if f, ok := err.(fail.Fail); ok {
+		if !fail.Source(f).IsZero() {
+			return f
+		}
+
+		return fail.From(f).Msg(fail.Message(f))
+	}
+
+	return fail.New().Cause(err).Msg(fail.Message(err))
+}
+
+// Wrap returns an error annotating err with the supplied message and the source location of the caller.
+//
+// If err is nil, Wrap returns nil.
+func Wrap(err error, message string) error {
+	if err == nil {
+		return nil
+	}
+
+	return fail.New().Cause(err).Msg(message)
+}
+
+// Wrapf returns an error annotating err with the format specifier and the source location of the caller.
+//
+// If err is nil, Wrapf returns nil.
+func Wrapf(err error, format string, args ...any) error {
+	if err == nil {
+		return nil
+	}
+
+	return fail.New().Cause(err).Msgf(format, args...)
+}
+
+// WithMessage annotates err with a new message.
+//
+// If err is nil, WithMessage returns nil.
+func WithMessage(err error, message string) error {
+	if err == nil {
+		return nil
+	}
+
+	return fail.New().Cause(err).Msg(message)
+}
+
+// WithMessagef annotates err with the format specifier.
+//
+// If err is nil, WithMessagef returns nil.
+func WithMessagef(err error, format string, args ...any) error {
+	if err == nil {
+		return nil
+	}
+
+	return fail.New().Cause(err).Msg(fmt.Sprintf(format, args...))
+}
+
+// Cause returns the underlying cause of the error, if possible.
+//
+// The first cause (see fail.Causes) is followed until an error without causes is found,
+// which is returned. This also follows errors created by github.com/pkg/errors and errors
+// implementing Unwrap. The traversal is bounded by fail.MaxDepth. If err is nil, Cause returns nil.
+func Cause(err error) error {
+	for depth := 0; err != nil && depth < fail.MaxDepth(); depth++ {
+		causes := fail.Causes(err)
+		if len(causes) == 0 || causes[0] == nil {
+			return err
+		}
+
+		err = causes[0]
+	}
+
+	return err
+}
+
+// Unwrap returns the first cause of err, or nil if err has no causes.
+//
+// Unlike errors.Unwrap, Unwrap also returns the causes of fail errors.
+func Unwrap(err error) error {
+	causes := fail.Causes(err)
+	if len(causes) == 0 {
+		return nil
+	}
+
+	return causes[0]
+}
+
+// Is reports whether any error in the tree of err matches target.
+//
+// Unlike errors.Is, Is also descends into the causes of fail errors (see fail.Causes).
+// The traversal is bounded by fail.MaxDepth and fail.MaxWidth.
+func Is(err, target error) bool {
+	return is(err, target, 0)
+}
+
+func is(err, target error, depth int) bool {
+	if err == nil {
+		return target == nil
+	}
+
+	if depth >= fail.MaxDepth() {
+		return false
+	}
+
+	if errors.Is(err, target) {
+		return true
+	}
+
+	for i, cause := range fail.Causes(err) {
+		if i >= fail.MaxWidth() {
+			break
+		}
+
+		if is(cause, target, depth+1) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// As finds the first error in the tree of err that matches target, and if one is found,
+// sets target to that error value and returns true. Otherwise, it returns false.
+//
+// Unlike errors.As, As also descends into the causes of fail errors (see fail.Causes).
+// The traversal is bounded by fail.MaxDepth and fail.MaxWidth.
+func As(err error, target any) bool {
+	return as(err, target, 0)
+}
+
+func as(err error, target any, depth int) bool {
+	if err == nil || depth >= fail.MaxDepth() {
+		return false
+	}
+
+	if errors.As(err, target) {
+		return true
+	}
+
+	for i, cause := range fail.Causes(err) {
+		if i >= fail.MaxWidth() {
+			break
+		}
+
+		if as(cause, target, depth+1) {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,232 @@
+// Package faildb classifies database driver errors and wraps them into fail errors.
+//
+// It recognizes the sentinel errors of database/sql, SQLSTATE codes reported by PostgreSQL
+// drivers such as lib/pq and pgx, MySQL error numbers reported by go-sql-driver/mysql, and
+// context cancellation. Drivers are detected by their exported methods and fields, so this
+// package does not depend on any of them.
+package faildb
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/FlowSeer/fail"
+)
+
+// Attribute keys set by this package.
+const (
+	// AttributeSQLState is the attribute key for the SQLSTATE code reported by the database.
+	AttributeSQLState = "sqlstate"
+	// AttributeMySQLErrorNumber is the attribute key for the MySQL error number.
+	AttributeMySQLErrorNumber = "mysql_error_number"
+)
+
+// Wrap returns a new fail error with the given message, wrapping the provided database error as its cause.
+//
+// The returned error has fail.DomainDatabase as its domain, and its code, HTTP status code and
+// retryability are derived from the database error as described for Classify.
+// If err is nil, Wrap returns nil.
+//
+// Example:
+//
+//	row := db.QueryRowContext(ctx, "SELECT name FROM users WHERE id = $1", id)
+//	if err := row.Scan(&name); err != nil {
+//		return faildb.Wrap(err, "failed to load user")
+//	}
+func Wrap(err error, msg string) error {
+	if err == nil {
+		return nil
+	}
+
+	return Classify(fail.New(), err).Cause(err).Msg(msg)
+}
+
+// Wrapf returns a new fail error with a formatted message, wrapping the provided database error as its cause.
+//
+// If err is nil, Wrapf returns nil. See Wrap for details.
+//
+// Example:
+//
+//	err = faildb.Wrapf(err, "failed to load user %d", id)
+func Wrapf(err error, format string, args ...any) error {
+	if err == nil {
+		return nil
+	}
+
+	return Wrap(err, fmt.Sprintf(format, args...))
+}
+
+// Classify applies the classification of the provided database error to the builder.
+//
+// The domain is set to fail.DomainDatabase, and the code, HTTP status code, tags and retryability
+// are set according to the error:
+//   - sql.ErrNoRows: fail.ErrCodeNotFound, 404
+//   - unique, foreign key and exclusion violations: fail.ErrCodeConflict, 409
+//   - not null and check violations: fail.ErrCodeValidation, 400
+//   - data exceptions: fail.ErrCodeInvalidInput, 400
+//   - serialization failures and deadlocks: fail.ErrCodeConflict, 409, retryable
+//   - connection failures: fail.ErrCodeConnection, 503, retryable
+//   - insufficient resources and server shutdown: fail.ErrCodeServiceUnavailable, 503, retryable
+//   - context deadline exceeded and query timeouts: fail.ErrCodeTimeout, 504, fail.TagTimeout, retryable
+//   - context cancellation: fail.TagCanceled
+//   - anything else: fail.ErrCodeDatabase
+//
+// The SQLSTATE code and the MySQL error number are recorded as attributes, if available.
+// The error itself is not added as a cause.
+func Classify(b fail.Builder, err error) fail.Builder {
+	b = b.Domain(fail.DomainDatabase).Tag(fail.TagDatabase)
+	if err == nil {
+		return b
+	}
+
+	switch {
+	case errors.Is(err, sql.ErrNoRows):
+		return b.Code(fail.ErrCodeNotFound).HttpStatusCode(404)
+	case errors.Is(err, context.DeadlineExceeded):
+		return timeout(b)
+	case errors.Is(err, context.Canceled):
+		return b.Code(fail.ErrCodeDatabase).Tag(fail.TagCanceled)
+	case errors.Is(err, sql.ErrConnDone), errors.Is(err, driver.ErrBadConn):
+		return connection(b)
+	}
+
+	state := SQLState(err)
+	if state != "" {
+		b = b.Attribute(AttributeSQLState, state)
+	}
+
+	if number, ok := MySQLErrorNumber(err); ok {
+		return classifyMySQL(b.Attribute(AttributeMySQLErrorNumber, number), number)
+	}
+
+	if state != "" {
+		return classifySQLState(b, state)
+	}
+
+	return b.Code(fail.ErrCodeDatabase)
+}
+
+// SQLState returns the five-character SQLSTATE code reported by the database, if any.
+//
+// The code is extracted from the first error in the chain of err (as traversed by errors.As)
+// that has a SQLState() string method, as implemented by lib/pq and pgx, or that is a
+// go-sql-driver/mysql error with a SQLState field. Generic MySQL states ("HY000") are ignored.
+func SQLState(err error) string {
+	var stater interface{ SQLState() string }
+	if errors.As(err, &stater) {
+		return stater.SQLState()
+	}
+
+	if v, ok := mysqlError(err); ok {
+		if f := v.FieldByName("SQLState"); f.IsValid() && f.Kind() == reflect.Array && f.Len() == 5 {
+			state := make([]byte, 5)
+			for i := range state {
+				state[i] = byte(f.Index(i).Uint())
+			}
+
+			if s := strings.TrimRight(string(state), "\x00"); s != "HY000" {
+				return s
+			}
+		}
+	}
+
+	return ""
+}
+
+// MySQLErrorNumber returns the error number of a go-sql-driver/mysql error in the chain of err, if any.
+func MySQLErrorNumber(err error) (uint16, bool) {
+	v, ok := mysqlError(err)
+	if !ok {
+		return 0, false
+	}
+
+	f := v.FieldByName("Number")
+	if !f.IsValid() || f.Kind() != reflect.Uint16 {
+		return 0, false
+	}
+
+	return uint16(f.Uint()), true
+}
+
+// mysqlError returns the struct value of the first *MySQLError in the chain of err.
+func mysqlError(err error) (reflect.Value, bool) {
+	for err != nil {
+		v := reflect.ValueOf(err)
+		if v.Kind() == reflect.Pointer && !v.IsNil() && v.Elem().Kind() == reflect.Struct && v.Elem().Type().Name() == "MySQLError" {
+			return v.Elem(), true
+		}
+
+		err = errors.Unwrap(err)
+	}
+
+	return reflect.Value{}, false
+}
+
+// classifySQLState applies the classification of a SQLSTATE code to the builder.
+func classifySQLState(b fail.Builder, state string) fail.Builder {
+	switch state {
+	case "23505", "23503", "23P01": // unique, foreign key and exclusion violations
+		return b.Code(fail.ErrCodeConflict).HttpStatusCode(409)
+	case "23502", "23514": // not null and check violations
+		return b.Code(fail.ErrCodeValidation).HttpStatusCode(400)
+	case "40001", "40P01": // serialization failure, deadlock detected
+		return b.Code(fail.ErrCodeConflict).HttpStatusCode(409).Retryable(true)
+	case "57014": // query canceled, usually due to a statement timeout
+		return timeout(b)
+	case "57P01", "57P02", "57P03": // admin shutdown, crash shutdown, cannot connect now
+		return unavailable(b)
+	}
+
+	switch state[:2] {
+	case "08": // connection exception
+		return connection(b)
+	case "22": // data exception
+		return b.Code(fail.ErrCodeInvalidInput).HttpStatusCode(400)
+	case "53": // insufficient resources
+		return unavailable(b)
+	}
+
+	return b.Code(fail.ErrCodeDatabase)
+}
+
+// classifyMySQL applies the classification of a MySQL error number to the builder.
+func classifyMySQL(b fail.Builder, number uint16) fail.Builder {
+	switch number {
+	case 1062, 1451, 1452: // duplicate entry, foreign key constraint fails
+		return b.Code(fail.ErrCodeConflict).HttpStatusCode(409)
+	case 1048, 3819: // column cannot be null, check constraint violated
+		return b.Code(fail.ErrCodeValidation).HttpStatusCode(400)
+	case 1264, 1366, 1406: // out of range value, incorrect value, data too long
+		return b.Code(fail.ErrCodeInvalidInput).HttpStatusCode(400)
+	case 1213: // deadlock found
+		return b.Code(fail.ErrCodeConflict).HttpStatusCode(409).Retryable(true)
+	case 1205, 3024: // lock wait timeout, query execution interrupted by timeout
+		return timeout(b)
+	case 1040, 1053: // too many connections, server shutdown
+		return unavailable(b)
+	case 2002, 2003, 2006, 2013: // cannot connect, server has gone away, lost connection
+		return connection(b)
+	}
+
+	return b.Code(fail.ErrCodeDatabase)
+}
+
+// timeout classifies the error as a retryable timeout.
+func timeout(b fail.Builder) fail.Builder {
+	return b.Code(fail.ErrCodeTimeout).HttpStatusCode(504).Tag(fail.TagTimeout).Retryable(true)
+}
+
+// connection classifies the error as a retryable connection failure.
+func connection(b fail.Builder) fail.Builder {
+	return b.Code(fail.ErrCodeConnection).HttpStatusCode(503).Tag(fail.TagNetwork).Retryable(true)
+}
+
+// unavailable classifies the error as a retryable unavailability of the database.
+func unavailable(b fail.Builder) fail.Builder {
+	return b.Code(fail.ErrCodeServiceUnavailable).HttpStatusCode(503).Retryable(true)
+}
@@ -0,0 +1,162 @@
+// Package faildebug exposes the recent errors, error statistics and code catalog of a service for production triage.
+//
+// The report is served as JSON by Handler, typically mounted on an internal debug port next to
+// net/http/pprof, or published as an expvar variable using Publish.
+package faildebug
+
+import (
+	"encoding/json"
+	"expvar"
+	"net/http"
+
+	"github.com/FlowSeer/fail"
+)
+
+// Option configures the report exposed by Handler and Publish.
+type Option func(*options)
+
+// options holds the sources of the report.
+type options struct {
+	recorder *fail.Recorder
+	stats    *fail.Stats
+	registry *fail.Registry
+}
+
+// WithRecorder sets the recorder whose errors are listed as the recent errors of the report.
+//
+// Without a recorder, the report contains no recent errors.
+//
+// Example:
+//
+//	recorder := fail.NewRecorder(100)
+//	mux.Handle("/debug/errors", faildebug.Handler(faildebug.WithRecorder(recorder)))
+func WithRecorder(recorder *fail.Recorder) Option {
+	return func(o *options) {
+		o.recorder = recorder
+	}
+}
+
+// WithStats sets the collector whose snapshot is included as the error statistics of the report.
+//
+// Without a collector, the report contains no statistics.
+//
+// Example:
+//
+//	stats := fail.NewStats(time.Minute)
+//	mux.Handle("/debug/errors", faildebug.Handler(faildebug.WithStats(stats)))
+func WithStats(stats *fail.Stats) Option {
+	return func(o *options) {
+		o.stats = stats
+	}
+}
+
+// WithRegistry sets the registry whose codes are listed as the catalog of the report.
+//
+// By default, the catalog of the default registry (see fail.Catalog) is used.
+//
+// Example:
+//
+//	mux.Handle("/debug/errors", faildebug.Handler(faildebug.WithRegistry(registry)))
+func WithRegistry(registry *fail.Registry) Option {
+	return func(o *options) {
+		o.registry = registry
+	}
+}
+
+// Report is the document exposed by Handler and Publish.
+type Report struct {
+	// Recent are the errors kept by the recorder, oldest first.
+	Recent []fail.RecordedError `json:"recent"`
+	// Stats are the error statistics aggregated by code, domain and tag, if a collector is configured.
+	Stats *fail.StatsSnapshot `json:"stats,omitempty"`
+	// Catalog lists the registered error codes, sorted by code.
+	Catalog []fail.CodeInfo `json:"catalog"`
+}
+
+// report returns the current report.
+func (o options) report() Report {
+	r := Report{Recent: []fail.RecordedError{}}
+
+	if o.recorder != nil {
+		r.Recent = o.recorder.Errors()
+	}
+
+	if o.stats != nil {
+		snapshot := o.stats.Snapshot()
+		r.Stats = &snapshot
+	}
+
+	if o.registry != nil {
+		r.Catalog = o.registry.Catalog()
+	} else {
+		r.Catalog = fail.Catalog()
+	}
+	if r.Catalog == nil {
+		r.Catalog = []fail.CodeInfo{}
+	}
+
+	return r
+}
+
+// newOptions returns the options configured by opts.
+func newOptions(opts []Option) options {
+	var o options
+	for _, opt := range opts {
+		opt(&o)
+	}
+
+	return o
+}
+
+// Handler returns an http.Handler serving the current Report as JSON.
+//
+// Only GET and HEAD requests are accepted. The report is computed on every request and is not cached.
+// The handler exposes error details and should only be reachable from internal networks.
+//
+// Example:
+//
+//	recorder := fail.NewRecorder(100)
+//	stats := fail.NewStats(5 * time.Minute)
+//
+//	mux := http.NewServeMux()
+//	mux.Handle("/debug/errors", faildebug.Handler(faildebug.WithRecorder(recorder), faildebug.WithStats(stats)))
+func Handler(opts ...Option) http.Handler {
+	o := newOptions(opts)
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet && r.Method != http.MethodHead {
+			w.Header().Set("Allow", "GET, HEAD")
+			http.Error(w, http.StatusText(http.StatusMethodNotAllowed), http.StatusMethodNotAllowed)
+			return
+		}
+
+		body, err := json.MarshalIndent(o.report(), "", "  ")
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set("Cache-Control", "no-store")
+		w.WriteHeader(http.StatusOK)
+		if r.Method != http.MethodHead {
+			_, _ = w.Write(body)
+		}
+	})
+}
+
+// Publish publishes the current Report as the expvar variable with the provided name.
+//
+// The report is then served by the expvar handler at /debug/vars. Like expvar.Publish, Publish
+// panics if a variable with the same name has already been published.
+//
+// Example:
+//
+//	faildebug.Publish("errors", faildebug.WithRecorder(recorder))
+func Publish(name string, opts ...Option) {
+	o := newOptions(opts)
+
+	expvar.Publish(name, expvar.Func(func() any {
+		return o.report()
+	}))
+}
@@ -0,0 +1,67 @@
+// Package failecho integrates fail errors with the Echo web framework.
+//
+// The error handler in this package renders errors returned by Echo handlers using failhttp.Write,
+// so that Echo services respond with the same content-negotiated error documents as other services.
+package failecho
+
+import (
+	"fmt"
+
+	"github.com/FlowSeer/fail"
+	"github.com/FlowSeer/fail/failhttp"
+	"github.com/labstack/echo/v4"
+)
+
+// HTTPErrorHandler renders err as the response using failhttp.Write with the default options.
+//
+// It can be assigned directly to echo.Echo.HTTPErrorHandler. See NewHTTPErrorHandler for details.
+//
+// Example:
+//
+//	e := echo.New()
+//	e.HTTPErrorHandler = failecho.HTTPErrorHandler
+func HTTPErrorHandler(err error, c echo.Context) {
+	NewHTTPErrorHandler()(err, c)
+}
+
+// NewHTTPErrorHandler returns an echo.HTTPErrorHandler rendering errors using failhttp.Write.
+//
+// Errors of type *echo.HTTPError, such as the ones returned by Echo for unknown routes, are converted
+// using FromHTTPError. Nothing is written if the response has already been committed. The options are
+// passed to failhttp.Write.
+//
+// Example:
+//
+//	e.HTTPErrorHandler = failecho.NewHTTPErrorHandler(failhttp.Debug(true))
+func NewHTTPErrorHandler(opts ...failhttp.WriteOption) echo.HTTPErrorHandler {
+	return func(err error, c echo.Context) {
+		if err == nil || c.Response().Committed {
+			return
+		}
+
+		if he, ok := err.(*echo.HTTPError); ok {
+			err = FromHTTPError(he)
+		}
+
+		_ = failhttp.Write(c.Response(), c.Request(), err, opts...)
+	}
+}
+
+// FromHTTPError converts an *echo.HTTPError into a fail error.
+//
+// The status code of the HTTP error becomes the HTTP status code of the returned error, its message
+// becomes both the developer-facing and the user-facing message, and its internal error, if any,
+// becomes the cause. If he is nil, FromHTTPError returns nil.
+func FromHTTPError(he *echo.HTTPError) error {
+	if he == nil {
+		return nil
+	}
+
+	msg := fmt.Sprint(he.Message)
+
+	return fail.New().
+		HttpStatusCode(he.Code).
+		UserMsg(msg).
+		Cause(he.Internal).
+		Msg(msg)
+}
@@ -0,0 +1,26 @@
+module github.com/FlowSeer/fail/failecho
+
+go 1.24.5
+
+require (
+	github.com/FlowSeer/fail v0.0.0-00010101000000-000000000000
+	github.com/labstack/echo/v4 v4.13.4
+)
+
+require (
+	github.com/FlowSeer/wz v0.0.3 // indirect
+	github.com/labstack/gommon v0.4.2 // indirect
+	github.com/mattn/go-colorable v0.1.14 // indirect
+	github.com/mattn/go-isatty v0.0.20 // indirect
+	github.com/valyala/bytebufferpool v1.0.0 // indirect
+	github.com/valyala/fasttemplate v1.2.2 // indirect
+	go.opentelemetry.io/otel v1.38.0 // indirect
+	go.opentelemetry.io/otel/trace v1.38.0 // indirect
+	golang.org/x/crypto v0.38.0 // indirect
+	golang.org/x/exp v0.0.0-20250819193227-8b4c13bb791b // indirect
+	golang.org/x/net v0.40.0 // indirect
+	golang.org/x/sys v0.33.0 // indirect
+	golang.org/x/text v0.25.0 // indirect
+)
+
+replace github.com/FlowSeer/fail => ../
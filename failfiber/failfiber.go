@@ -0,0 +1,58 @@
+// Package failfiber integrates fail errors with the Fiber web framework.
+//
+// The error handler in this package renders errors returned by Fiber handlers using failhttp.Render,
+// so that Fiber services respond with the same content-negotiated error documents as other services.
+package failfiber
+
+import (
+	"github.com/FlowSeer/fail"
+	"github.com/FlowSeer/fail/failhttp"
+	"github.com/gofiber/fiber/v2"
+)
+
+// ErrorHandler returns a fiber.ErrorHandler rendering errors using failhttp.Render.
+//
+// Errors of type *fiber.Error, such as the ones returned by Fiber for unknown routes, are converted
+// using FromFiberError. The options are passed to failhttp.Render.
+//
+// Example:
+//
+//	app := fiber.New(fiber.Config{
+//		ErrorHandler: failfiber.ErrorHandler(),
+//	})
+func ErrorHandler(opts ...failhttp.WriteOption) fiber.ErrorHandler {
+	return func(c *fiber.Ctx, err error) error {
+		if err == nil {
+			return nil
+		}
+
+		if fe, ok := err.(*fiber.Error); ok {
+			err = FromFiberError(fe)
+		}
+
+		status, header, body := failhttp.Render(c.Get(fiber.HeaderAccept), err, opts...)
+		for k, values := range header {
+			for _, v := range values {
+				c.Set(k, v)
+			}
+		}
+
+		return c.Status(status).Send(body)
+	}
+}
+
+// FromFiberError converts a *fiber.Error into a fail error.
+//
+// The status code of the Fiber error becomes the HTTP status code of the returned error, and its
+// message becomes both the developer-facing and the user-facing message. If fe is nil,
+// FromFiberError returns nil.
+func FromFiberError(fe *fiber.Error) error {
+	if fe == nil {
+		return nil
+	}
+
+	return fail.New().
+		HttpStatusCode(fe.Code).
+		UserMsg(fe.Message).
+		Msg(fe.Message)
+}
@@ -0,0 +1,28 @@
+module github.com/FlowSeer/fail/failfiber
+
+go 1.24.5
+
+require (
+	github.com/FlowSeer/fail v0.0.0-00010101000000-000000000000
+	github.com/gofiber/fiber/v2 v2.52.9
+)
+
+require (
+	github.com/FlowSeer/wz v0.0.3 // indirect
+	github.com/andybalholm/brotli v1.1.0 // indirect
+	github.com/google/uuid v1.6.0 // indirect
+	github.com/klauspost/compress v1.17.9 // indirect
+	github.com/mattn/go-colorable v0.1.13 // indirect
+	github.com/mattn/go-isatty v0.0.20 // indirect
+	github.com/mattn/go-runewidth v0.0.16 // indirect
+	github.com/rivo/uniseg v0.2.0 // indirect
+	github.com/valyala/bytebufferpool v1.0.0 // indirect
+	github.com/valyala/fasthttp v1.51.0 // indirect
+	github.com/valyala/tcplisten v1.0.0 // indirect
+	go.opentelemetry.io/otel v1.38.0 // indirect
+	go.opentelemetry.io/otel/trace v1.38.0 // indirect
+	golang.org/x/exp v0.0.0-20250819193227-8b4c13bb791b // indirect
+	golang.org/x/sys v0.28.0 // indirect
+)
+
+replace github.com/FlowSeer/fail => ../
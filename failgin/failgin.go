@@ -0,0 +1,62 @@
+// Package failgin integrates fail errors with the Gin web framework.
+//
+// The middleware in this package renders errors attached to a Gin context using failhttp.Write,
+// so that Gin services respond with the same content-negotiated error documents as other services.
+package failgin
+
+import (
+	"github.com/FlowSeer/fail/failhttp"
+	"github.com/gin-gonic/gin"
+)
+
+// ErrorHandler returns a middleware rendering the last error attached to the context using failhttp.Write.
+//
+// Handlers attach errors using c.Error. Once the remaining handlers have run, the last attached
+// error is written as the response, unless a response has already been written. The options are
+// passed to failhttp.Write.
+//
+// Example:
+//
+//	router := gin.New()
+//	router.Use(failgin.ErrorHandler())
+//	router.GET("/users/:id", func(c *gin.Context) {
+//		user, err := findUser(c.Param("id"))
+//		if err != nil {
+//			_ = c.Error(err)
+//			return
+//		}
+//		c.JSON(http.StatusOK, user)
+//	})
+func ErrorHandler(opts ...failhttp.WriteOption) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		c.Next()
+
+		last := c.Errors.Last()
+		if last == nil || c.Writer.Written() {
+			return
+		}
+
+		_ = failhttp.Write(c.Writer, c.Request, last.Err, opts...)
+	}
+}
+
+// Abort aborts the request and writes err as the response using failhttp.Write.
+//
+// The error is also attached to the context, so that logging middleware can observe it.
+// If err is nil, Abort does nothing.
+//
+// Example:
+//
+//	if err != nil {
+//		failgin.Abort(c, err)
+//		return
+//	}
+func Abort(c *gin.Context, err error, opts ...failhttp.WriteOption) {
+	if err == nil {
+		return
+	}
+
+	_ = c.Error(err)
+	c.Abort()
+	_ = failhttp.Write(c.Writer, c.Request, err, opts...)
+}
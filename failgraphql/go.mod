@@ -0,0 +1,17 @@
+module github.com/FlowSeer/fail/failgraphql
+
+go 1.24.5
+
+require (
+	github.com/FlowSeer/fail v0.0.0-00010101000000-000000000000
+	github.com/vektah/gqlparser/v2 v2.5.58
+)
+
+require (
+	github.com/FlowSeer/wz v0.0.3 // indirect
+	go.opentelemetry.io/otel v1.38.0 // indirect
+	go.opentelemetry.io/otel/trace v1.38.0 // indirect
+	golang.org/x/exp v0.0.0-20250819193227-8b4c13bb791b // indirect
+)
+
+replace github.com/FlowSeer/fail => ../
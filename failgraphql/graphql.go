@@ -0,0 +1,120 @@
+// Package failgraphql converts fail errors into GraphQL errors.
+//
+// Errors are rendered in the GraphQL response error shape, with the error code, domain,
+// attributes and trace ID carried in the extensions member, so that gateways expose
+// consistent error extensions.
+package failgraphql
+
+import (
+	"context"
+	"errors"
+
+	"github.com/FlowSeer/fail"
+	"github.com/vektah/gqlparser/v2/ast"
+	"github.com/vektah/gqlparser/v2/gqlerror"
+)
+
+// Extension keys set by this package.
+const (
+	// ExtensionCode is the extension key for the error code.
+	ExtensionCode = "code"
+	// ExtensionDomain is the extension key for the error domain.
+	ExtensionDomain = "domain"
+	// ExtensionAttributes is the extension key for the error attributes.
+	ExtensionAttributes = "attributes"
+	// ExtensionTraceId is the extension key for the trace ID.
+	ExtensionTraceId = "trace_id"
+)
+
+// Error converts err into a GraphQL error located at the given path.
+//
+// The message of the returned error is the user-facing message of err if it has one,
+// and its developer-facing message otherwise. The extensions carry the error code, domain,
+// attributes and trace ID, if set. If err is nil, Error returns nil.
+//
+// Example:
+//
+//	gqlErr := failgraphql.Error(err, ast.Path{ast.PathName("user"), ast.PathIndex(0)})
+func Error(err error, path ast.Path) *gqlerror.Error {
+	if err == nil {
+		return nil
+	}
+
+	return &gqlerror.Error{
+		Err:        err,
+		Message:    message(err),
+		Path:       path,
+		Extensions: Extensions(err),
+	}
+}
+
+// Extensions returns the GraphQL extensions for err.
+//
+// The returned map contains the error code, domain, attributes and trace ID, omitting
+// those that are not set. It is never nil.
+func Extensions(err error) map[string]any {
+	ext := map[string]any{}
+	if err == nil {
+		return ext
+	}
+
+	if code := fail.Code(err); code != "" {
+		ext[ExtensionCode] = code
+	}
+
+	if domain := fail.Domain(err); domain != "" {
+		ext[ExtensionDomain] = domain
+	}
+
+	if attrs := fail.Attributes(err); len(attrs) > 0 {
+		ext[ExtensionAttributes] = attrs
+	}
+
+	if traceId := fail.TraceId(err); traceId != "" {
+		ext[ExtensionTraceId] = traceId
+	}
+
+	return ext
+}
+
+// Presenter returns an error presenter for gqlgen that renders fail errors consistently.
+//
+// The returned function first calls next, typically graphql.DefaultErrorPresenter, to resolve
+// the path and locations of the error. If err is or wraps a fail.Fail, the message is replaced
+// as described for Error, and the fail extensions are merged into the existing extensions.
+//
+// Example:
+//
+//	srv.SetErrorPresenter(failgraphql.Presenter(graphql.DefaultErrorPresenter))
+func Presenter(next func(ctx context.Context, err error) *gqlerror.Error) func(ctx context.Context, err error) *gqlerror.Error {
+	return func(ctx context.Context, err error) *gqlerror.Error {
+		presented := next(ctx, err)
+		if presented == nil {
+			return nil
+		}
+
+		var f fail.Fail
+		if !errors.As(err, &f) {
+			return presented
+		}
+
+		presented.Message = message(f)
+		if presented.Extensions == nil {
+			presented.Extensions = map[string]any{}
+		}
+		for k, v := range Extensions(f) {
+			presented.Extensions[k] = v
+		}
+
+		return presented
+	}
+}
+
+// message returns the user-facing message of err if it has one, and its developer-facing message otherwise.
+func message(err error) string {
+	if userMsg, ok := err.(fail.ErrorUserMessage); ok && userMsg.ErrorUserMessage() != "" {
+		return userMsg.ErrorUserMessage()
+	}
+
+	return fail.Message(err)
+}
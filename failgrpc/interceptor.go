@@ -0,0 +1,184 @@
+package failgrpc
+
+import (
+	"context"
+	"io"
+	"log/slog"
+
+	"github.com/FlowSeer/fail"
+	"go.opentelemetry.io/otel/attribute"
+	otelcodes "go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+	"google.golang.org/grpc"
+)
+
+// Option configures the interceptors of this package.
+type Option func(*options)
+
+// options holds the configuration of the interceptors and of Status.
+type options struct {
+	logger *slog.Logger
+	debug  bool
+}
+
+// WithLogger sets the logger used by the server interceptors to log errors returned by handlers.
+//
+// By default, slog.Default() is used. Passing nil disables logging.
+//
+// Example:
+//
+//	grpc.NewServer(grpc.UnaryInterceptor(failgrpc.UnaryServerInterceptor(failgrpc.WithLogger(logger))))
+func WithLogger(logger *slog.Logger) Option {
+	return func(o *options) {
+		o.logger = logger
+	}
+}
+
+// Debug enables or disables debug detail in the statuses created by Status and the server interceptors.
+//
+// With debug detail enabled, the status message is the developer-facing message and the ErrorInfo metadata
+// holds the attributes of the error, including the global attributes (see fail.SetGlobalAttributes) and the
+// attributes added by hooks. This may leak internal information and must only be enabled in non-production
+// environments.
+//
+// Example:
+//
+//	grpc.NewServer(grpc.UnaryInterceptor(failgrpc.UnaryServerInterceptor(failgrpc.Debug(os.Getenv("ENV") != "production"))))
+func Debug(debug bool) Option {
+	return func(o *options) {
+		o.debug = debug
+	}
+}
+
+// newOptions returns the options configured by opts.
+func newOptions(opts []Option) options {
+	o := options{logger: slog.Default()}
+	for _, opt := range opts {
+		opt(&o)
+	}
+
+	return o
+}
+
+// UnaryServerInterceptor returns a server interceptor converting errors returned by unary handlers into status errors.
+//
+// Errors are recorded on the span of the request context, logged (see WithLogger and fail.LogIfError)
+// unless the call was canceled, and converted using Status with the provided options. Errors that
+// already carry a gRPC status are recorded and logged but returned unchanged.
+//
+// Example:
+//
+//	server := grpc.NewServer(grpc.ChainUnaryInterceptor(failgrpc.UnaryServerInterceptor()))
+func UnaryServerInterceptor(opts ...Option) grpc.UnaryServerInterceptor {
+	o := newOptions(opts)
+
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		resp, err := handler(ctx, req)
+		if err != nil {
+			err = o.handle(ctx, info.FullMethod, err)
+		}
+
+		return resp, err
+	}
+}
+
+// StreamServerInterceptor returns a server interceptor converting errors returned by stream handlers into status errors.
+//
+// Errors are handled like in UnaryServerInterceptor.
+//
+// Example:
+//
+//	server := grpc.NewServer(grpc.ChainStreamInterceptor(failgrpc.StreamServerInterceptor()))
+func StreamServerInterceptor(opts ...Option) grpc.StreamServerInterceptor {
+	o := newOptions(opts)
+
+	return func(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		err := handler(srv, ss)
+		if err != nil {
+			err = o.handle(ss.Context(), info.FullMethod, err)
+		}
+
+		return err
+	}
+}
+
+// handle records err on the span of ctx, logs it, and converts it into a status error.
+func (o options) handle(ctx context.Context, method string, err error) error {
+	code := Code(err)
+
+	if span := trace.SpanFromContext(ctx); span.IsRecording() {
+		span.RecordError(err, trace.WithAttributes(
+			attribute.String("error.code", fail.Code(err)),
+			attribute.String("rpc.grpc.status_code", code.String()),
+		))
+		span.SetStatus(otelcodes.Error, fail.Message(err))
+	}
+
+	if o.logger != nil && !fail.IsCanceled(err) {
+		fail.LogIfError(o.logger, err, "grpc call failed", "rpc.method", method, "rpc.grpc.status_code", code.String())
+	}
+
+	return o.status(err).Err()
+}
+
+// UnaryClientInterceptor returns a client interceptor converting status errors returned by unary calls into fail errors.
+//
+// Errors are converted using FromError, so that callers can use the accessor functions of the fail package,
+// such as fail.Code and fail.Retryable, on them.
+//
+// Example:
+//
+//	conn, err := grpc.NewClient(target, grpc.WithChainUnaryInterceptor(failgrpc.UnaryClientInterceptor()))
+func UnaryClientInterceptor() grpc.UnaryClientInterceptor {
+	return func(ctx context.Context, method string, req, reply any, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+		return FromError(invoker(ctx, method, req, reply, cc, opts...))
+	}
+}
+
+// StreamClientInterceptor returns a client interceptor converting status errors returned by streams into fail errors.
+//
+// Errors returned when creating the stream and by its methods are converted using FromError.
+// io.EOF, which signals the end of a stream, is returned unchanged.
+//
+// Example:
+//
+//	conn, err := grpc.NewClient(target, grpc.WithChainStreamInterceptor(failgrpc.StreamClientInterceptor()))
+func StreamClientInterceptor() grpc.StreamClientInterceptor {
+	return func(ctx context.Context, desc *grpc.StreamDesc, cc *grpc.ClientConn, method string, streamer grpc.Streamer, opts ...grpc.CallOption) (grpc.ClientStream, error) {
+		cs, err := streamer(ctx, desc, cc, method, opts...)
+		if err != nil {
+			return nil, FromError(err)
+		}
+
+		return clientStream{ClientStream: cs}, nil
+	}
+}
+
+// clientStream wraps a grpc.ClientStream, converting the errors of its methods using FromError.
+type clientStream struct {
+	grpc.ClientStream
+}
+
+// SendMsg sends m and converts the returned error.
+func (s clientStream) SendMsg(m any) error {
+	return convertStreamError(s.ClientStream.SendMsg(m))
+}
+
+// RecvMsg receives a message into m and converts the returned error.
+func (s clientStream) RecvMsg(m any) error {
+	return convertStreamError(s.ClientStream.RecvMsg(m))
+}
+
+// CloseSend closes the sending side of the stream and converts the returned error.
+func (s clientStream) CloseSend() error {
+	return convertStreamError(s.ClientStream.CloseSend())
+}
+
+// convertStreamError converts err using FromError, except for io.EOF.
+func convertStreamError(err error) error {
+	if err == io.EOF {
+		return err
+	}
+
+	return FromError(err)
+}
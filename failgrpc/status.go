@@ -0,0 +1,348 @@
+// Package failgrpc converts between fail errors and gRPC status errors.
+//
+// Errors are converted into status errors carrying the error code, domain, user-facing message,
+// help URL and retry backoff as standard google.rpc error details, and status errors received by
+// clients are converted back into fail errors. The interceptors in this package apply the
+// conversions automatically, record errors on the active span, and log them.
+package failgrpc
+
+import (
+	"cmp"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/FlowSeer/fail"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/protoadapt"
+	"google.golang.org/protobuf/types/known/durationpb"
+)
+
+// AttributeGrpcCode is the attribute key for the gRPC status code of an error converted by FromStatus.
+const AttributeGrpcCode = "grpc_code"
+
+// Keys of the google.rpc.ErrorInfo metadata set by Status.
+const (
+	// MetadataUserMsg is the metadata key for the user-facing message of the error.
+	MetadataUserMsg = "user_msg"
+	// MetadataTraceId is the metadata key for the trace ID of the error.
+	MetadataTraceId = "trace_id"
+	// MetadataCorrelationId is the metadata key for the correlation ID of the error.
+	MetadataCorrelationId = "correlation_id"
+	// MetadataErrorId is the metadata key for the unique instance ID of the error.
+	MetadataErrorId = "error_id"
+	// MetadataSchemaVersion is the metadata key for the fail.SchemaVersion of the sender.
+	MetadataSchemaVersion = fail.SchemaVersionKey
+)
+
+// grpcCodes maps error codes to gRPC status codes.
+var grpcCodes = map[string]codes.Code{
+	fail.ErrCodeValidation:         codes.InvalidArgument,
+	fail.ErrCodeInvalidInput:       codes.InvalidArgument,
+	fail.ErrCodeMissingRequired:    codes.InvalidArgument,
+	fail.ErrCodeInvalidFormat:      codes.InvalidArgument,
+	fail.ErrCodeOutOfRange:         codes.OutOfRange,
+	fail.ErrCodeUnauthorized:       codes.Unauthenticated,
+	fail.ErrCodeAuthentication:     codes.Unauthenticated,
+	fail.ErrCodeTokenExpired:       codes.Unauthenticated,
+	fail.ErrCodeInvalidToken:       codes.Unauthenticated,
+	fail.ErrCodeForbidden:          codes.PermissionDenied,
+	fail.ErrCodeNotFound:           codes.NotFound,
+	fail.ErrCodeResourceGone:       codes.NotFound,
+	fail.ErrCodeAlreadyExists:      codes.AlreadyExists,
+	fail.ErrCodeConflict:           codes.Aborted,
+	fail.ErrCodeTimeout:            codes.DeadlineExceeded,
+	fail.ErrCodeNetwork:            codes.Unavailable,
+	fail.ErrCodeConnection:         codes.Unavailable,
+	fail.ErrCodeUnreachable:        codes.Unavailable,
+	fail.ErrCodeServiceUnavailable: codes.Unavailable,
+	fail.ErrCodeMaintenance:        codes.Unavailable,
+	fail.ErrCodeQuotaExceeded:      codes.ResourceExhausted,
+	fail.ErrCodeRateLimited:        codes.ResourceExhausted,
+	fail.ErrCodeBusinessRule:       codes.FailedPrecondition,
+	fail.ErrCodeInternal:           codes.Internal,
+	fail.ErrCodeDatabase:           codes.Internal,
+	fail.ErrCodeStorage:            codes.Internal,
+	fail.ErrCodeConfiguration:      codes.Internal,
+}
+
+// grpcHttpCodes maps HTTP status codes to gRPC status codes, for errors whose code has no mapping.
+var grpcHttpCodes = map[int]codes.Code{
+	http.StatusBadRequest:          codes.InvalidArgument,
+	http.StatusUnauthorized:        codes.Unauthenticated,
+	http.StatusForbidden:           codes.PermissionDenied,
+	http.StatusNotFound:            codes.NotFound,
+	http.StatusConflict:            codes.Aborted,
+	http.StatusPreconditionFailed:  codes.FailedPrecondition,
+	http.StatusTooManyRequests:     codes.ResourceExhausted,
+	499:                            codes.Canceled,
+	http.StatusNotImplemented:      codes.Unimplemented,
+	http.StatusServiceUnavailable:  codes.Unavailable,
+	http.StatusGatewayTimeout:      codes.DeadlineExceeded,
+	http.StatusInternalServerError: codes.Internal,
+}
+
+// failCodes maps gRPC status codes to the error code, HTTP status code and retryability of converted errors.
+var failCodes = map[codes.Code]struct {
+	code      string
+	status    int
+	retryable bool
+}{
+	codes.Canceled:           {fail.ErrCodeUnspecified, 499, false},
+	codes.Unknown:            {fail.ErrCodeUnspecified, http.StatusInternalServerError, false},
+	codes.InvalidArgument:    {fail.ErrCodeInvalidInput, http.StatusBadRequest, false},
+	codes.DeadlineExceeded:   {fail.ErrCodeTimeout, http.StatusGatewayTimeout, true},
+	codes.NotFound:           {fail.ErrCodeNotFound, http.StatusNotFound, false},
+	codes.AlreadyExists:      {fail.ErrCodeAlreadyExists, http.StatusConflict, false},
+	codes.PermissionDenied:   {fail.ErrCodeForbidden, http.StatusForbidden, false},
+	codes.ResourceExhausted:  {fail.ErrCodeRateLimited, http.StatusTooManyRequests, true},
+	codes.FailedPrecondition: {fail.ErrCodeBusinessRule, http.StatusBadRequest, false},
+	codes.Aborted:            {fail.ErrCodeConflict, http.StatusConflict, true},
+	codes.OutOfRange:         {fail.ErrCodeOutOfRange, http.StatusBadRequest, false},
+	codes.Unimplemented:      {fail.ErrCodeInternal, http.StatusNotImplemented, false},
+	codes.Internal:           {fail.ErrCodeInternal, http.StatusInternalServerError, false},
+	codes.Unavailable:        {fail.ErrCodeServiceUnavailable, http.StatusServiceUnavailable, true},
+	codes.DataLoss:           {fail.ErrCodeStorage, http.StatusInternalServerError, false},
+	codes.Unauthenticated:    {fail.ErrCodeUnauthorized, http.StatusUnauthorized, false},
+}
+
+// Code returns the gRPC status code for the provided error.
+//
+// Canceled errors (see fail.IsCanceled) map to codes.Canceled and timeouts (see fail.IsTimeout)
+// to codes.DeadlineExceeded. Otherwise, the error code is mapped to the corresponding gRPC code,
+// falling back to a mapping of the HTTP status code, and to codes.Unknown if neither is known.
+// If err already carries a gRPC status, its code is returned. If err is nil, Code returns codes.OK.
+//
+// Example:
+//
+//	code := failgrpc.Code(fail.New().Code(fail.ErrCodeNotFound).Msg("user not found")) // codes.NotFound
+func Code(err error) codes.Code {
+	if err == nil {
+		return codes.OK
+	}
+
+	if s, ok := err.(interface{ GRPCStatus() *status.Status }); ok {
+		return s.GRPCStatus().Code()
+	}
+
+	switch {
+	case fail.IsCanceled(err):
+		return codes.Canceled
+	case fail.IsTimeout(err):
+		return codes.DeadlineExceeded
+	}
+
+	if c, ok := grpcCodes[fail.Code(err)]; ok {
+		return c
+	}
+
+	if c, ok := grpcHttpCodes[fail.HttpStatusCode(err)]; ok {
+		return c
+	}
+
+	return codes.Unknown
+}
+
+// Status converts the provided error into a gRPC status.
+//
+// The status code is determined by Code. The error code and domain are carried in a google.rpc.ErrorInfo
+// detail, whose metadata also holds the user-facing message if one is set explicitly (see
+// fail.ExplicitUserMessage), the trace, correlation and error IDs, and the fail.SchemaVersion of the sender.
+// The retry backoff is carried in a google.rpc.RetryInfo detail, and the help URL in a google.rpc.Help detail.
+//
+// Unless debug detail is enabled using the Debug option, the status message is the explicit user-facing
+// message, or the name of the status code if there is none, so that internal error text is never sent to
+// clients. With debug detail enabled, the status message is the developer-facing message and the metadata
+// additionally holds the attributes of the error formatted using fmt.Sprint. Attribute keys are adapted to
+// the format required for ErrorInfo metadata keys by replacing invalid characters with underscores, as in
+// "http_request_method" for "http.request.method"; keys that cannot be adapted are skipped.
+// If err already carries a gRPC status, that status is returned as-is. If err is nil, Status returns nil.
+//
+// Example:
+//
+//	return nil, failgrpc.Status(err).Err()
+func Status(err error, opts ...Option) *status.Status {
+	return newOptions(opts).status(err)
+}
+
+// status implements Status using the options o.
+func (o options) status(err error) *status.Status {
+	if err == nil {
+		return nil
+	}
+
+	if s, ok := err.(interface{ GRPCStatus() *status.Status }); ok {
+		return s.GRPCStatus()
+	}
+
+	code := Code(err)
+	userMsg := fail.ExplicitUserMessage(err)
+
+	msg := cmp.Or(userMsg, code.String())
+	if o.debug {
+		msg = fail.Message(err)
+	}
+
+	st := status.New(code, msg)
+
+	metadata := map[string]string{
+		MetadataSchemaVersion: strconv.Itoa(fail.SchemaVersion),
+	}
+	if o.debug {
+		for k, v := range fail.Attributes(err) {
+			if key, ok := metadataKey(k); ok {
+				metadata[key] = fmt.Sprint(v)
+			}
+		}
+	}
+	for k, v := range map[string]string{
+		MetadataUserMsg:       userMsg,
+		MetadataTraceId:       fail.TraceId(err),
+		MetadataCorrelationId: fail.CorrelationId(err),
+		MetadataErrorId:       fail.Id(err),
+	} {
+		if v != "" {
+			metadata[k] = v
+		}
+	}
+
+	reason := fail.Code(err)
+	if reason == fail.ErrCodeUnspecified {
+		reason = ""
+	}
+
+	details := []protoadapt.MessageV1{&errdetails.ErrorInfo{
+		Reason:   reason,
+		Domain:   fail.Domain(err),
+		Metadata: metadata,
+	}}
+
+	if d := fail.RetryAfter(err); d > 0 {
+		details = append(details, &errdetails.RetryInfo{RetryDelay: durationpb.New(d)})
+	}
+
+	if helpURL := fail.HelpURL(err); helpURL != "" {
+		details = append(details, &errdetails.Help{Links: []*errdetails.Help_Link{{Url: helpURL}}})
+	}
+
+	if withDetails, dErr := st.WithDetails(details...); dErr == nil {
+		return withDetails
+	}
+
+	return st
+}
+
+// maxMetadataKeyLength is the maximum length of a google.rpc.ErrorInfo metadata key.
+const maxMetadataKeyLength = 64
+
+// metadataKey adapts the attribute key k to the format of google.rpc.ErrorInfo metadata keys, [a-z][a-zA-Z0-9-_]+.
+//
+// Invalid characters are replaced by underscores. If the key does not start with a lowercase letter, is shorter
+// than two characters or longer than maxMetadataKeyLength, metadataKey returns false.
+func metadataKey(k string) (string, bool) {
+	if len(k) < 2 || len(k) > maxMetadataKeyLength || k[0] < 'a' || k[0] > 'z' {
+		return "", false
+	}
+
+	key := []byte(k)
+	for i, c := range key {
+		if !('a' <= c && c <= 'z' || 'A' <= c && c <= 'Z' || '0' <= c && c <= '9' || c == '-' || c == '_') {
+			key[i] = '_'
+		}
+	}
+
+	return string(key), true
+}
+
+// FromStatus converts a gRPC status received from a server back into an error.
+//
+// The error code and domain are restored from the google.rpc.ErrorInfo detail, if present, and
+// otherwise derived from the status code; the domain defaults to fail.DomainDependency. The user-facing
+// message, trace, correlation and error IDs, retry backoff and help URL are restored from the details, the
+// remaining ErrorInfo metadata become attributes, and the status code is recorded as AttributeGrpcCode.
+// The HTTP status code and retryability are derived from the status code. If st is nil or has
+// codes.OK, FromStatus returns nil.
+//
+// Example:
+//
+//	err := failgrpc.FromStatus(status.Convert(rpcErr))
+func FromStatus(st *status.Status) error {
+	if st == nil || st.Code() == codes.OK {
+		return nil
+	}
+
+	mapped, ok := failCodes[st.Code()]
+	if !ok {
+		mapped = failCodes[codes.Unknown]
+	}
+
+	b := fail.New().
+		Code(mapped.code).
+		Domain(fail.DomainDependency).
+		HttpStatusCode(mapped.status).
+		Retryable(mapped.retryable).
+		Attribute(AttributeGrpcCode, st.Code().String())
+
+	switch st.Code() {
+	case codes.Canceled:
+		b = b.Tag(fail.TagCanceled)
+	case codes.DeadlineExceeded:
+		b = b.Tag(fail.TagTimeout)
+	}
+
+	for _, detail := range st.Details() {
+		switch d := detail.(type) {
+		case *errdetails.ErrorInfo:
+			b = b.Code(d.GetReason()).Domain(d.GetDomain())
+			for k, v := range d.GetMetadata() {
+				switch k {
+				case MetadataUserMsg:
+					b = b.UserMsg(v)
+				case MetadataTraceId:
+					b = b.TraceId(v)
+				case MetadataCorrelationId:
+					b = b.CorrelationId(v)
+				case MetadataErrorId:
+					b = b.Id(v)
+				case MetadataSchemaVersion:
+					// The metadata of all schema versions is restored the same way.
+				default:
+					b = b.Attribute(k, v)
+				}
+			}
+		case *errdetails.RetryInfo:
+			b = b.RetryAfter(d.GetRetryDelay().AsDuration())
+		case *errdetails.Help:
+			for _, link := range d.GetLinks() {
+				b = b.HelpURL(link.GetUrl())
+			}
+		}
+	}
+
+	return b.Msg(st.Message())
+}
+
+// FromError converts err back into a fail error if it carries a gRPC status, and returns it unchanged otherwise.
+//
+// If err is nil, FromError returns nil.
+//
+// Example:
+//
+//	resp, err := client.GetUser(ctx, req)
+//	if err != nil {
+//		return failgrpc.FromError(err)
+//	}
+func FromError(err error) error {
+	if err == nil {
+		return nil
+	}
+
+	st, ok := status.FromError(err)
+	if !ok {
+		return err
+	}
+
+	return FromStatus(st)
+}
@@ -0,0 +1,152 @@
+package failhttp
+
+import (
+	"net/http"
+	"slices"
+	"strings"
+	"sync/atomic"
+
+	"github.com/FlowSeer/fail"
+)
+
+// Attribute groups used by AttributeRequest and AttributeResponse.
+const (
+	// AttributeRequestGroup is the attribute group under which AttributeRequest records the request.
+	AttributeRequestGroup = "http.request"
+	// AttributeResponseGroup is the attribute group under which AttributeResponse records the response.
+	AttributeResponseGroup = "http.response"
+)
+
+// defaultCapturedHeaders are the headers recorded by AttributeRequest and AttributeResponse unless changed.
+var defaultCapturedHeaders = []string{
+	"Accept",
+	"Authorization",
+	"Content-Length",
+	"Content-Type",
+	"Cookie",
+	"Location",
+	"Referer",
+	"Retry-After",
+	"Set-Cookie",
+	"User-Agent",
+	"X-Forwarded-For",
+	"X-Request-Id",
+}
+
+// redactedHeaders are the headers whose values are always replaced by fail.Redacted.
+var redactedHeaders = []string{
+	"Authorization",
+	"Cookie",
+	"Proxy-Authorization",
+	"Set-Cookie",
+	"X-Api-Key",
+}
+
+// capturedHeaders holds the canonical names of the headers recorded by AttributeRequest and AttributeResponse.
+var capturedHeaders atomic.Pointer[[]string]
+
+func init() {
+	SetCapturedHeaders(defaultCapturedHeaders...)
+}
+
+// SetCapturedHeaders sets the headers recorded by AttributeRequest and AttributeResponse.
+//
+// By default, a selection of headers useful for debugging is recorded, such as Content-Type, User-Agent,
+// Retry-After and X-Request-Id. The values of headers carrying credentials, such as Authorization, Cookie,
+// Set-Cookie and X-Api-Key, are always replaced by fail.Redacted. Calling SetCapturedHeaders without
+// headers restores the default selection. It is safe to call SetCapturedHeaders concurrently.
+//
+// Example:
+//
+//	failhttp.SetCapturedHeaders("Content-Type", "X-Request-Id", "X-Tenant-Id")
+func SetCapturedHeaders(headers ...string) {
+	if len(headers) == 0 {
+		headers = defaultCapturedHeaders
+	}
+
+	canonical := make([]string, len(headers))
+	for i, header := range headers {
+		canonical[i] = http.CanonicalHeaderKey(header)
+	}
+
+	capturedHeaders.Store(&canonical)
+}
+
+// AttributeRequest records the HTTP request r as attributes of b, grouped under AttributeRequestGroup.
+//
+// The method, the URL with its password redacted, the remote address and the headers selected using
+// SetCapturedHeaders are recorded, as in "http.request.method" and "http.request.header.user-agent".
+// The body is not read; use fail.Builder.AttributeReaderPeek to capture a snippet of it.
+// If r is nil, b is returned unchanged.
+//
+// Example:
+//
+//	if err := process(r); err != nil {
+//		return failhttp.AttributeRequest(fail.From(err), r).Msg("failed to process request")
+//	}
+func AttributeRequest(b fail.Builder, r *http.Request) fail.Builder {
+	if r == nil {
+		return b
+	}
+
+	attrs := map[string]any{
+		"method":      nonEmpty(r.Method),
+		"remote_addr": nonEmpty(r.RemoteAddr),
+	}
+	if r.URL != nil {
+		attrs["url"] = nonEmpty(r.URL.Redacted())
+	}
+	if headers := captureHeaders(r.Header); len(headers) > 0 {
+		attrs["header"] = headers
+	}
+
+	return b.AttributeGroup(AttributeRequestGroup, attrs)
+}
+
+// AttributeResponse records the HTTP response resp as attributes of b, grouped under AttributeResponseGroup.
+//
+// The status code, the status and the headers selected using SetCapturedHeaders are recorded, as in
+// "http.response.status_code" and "http.response.header.content-type". The body is not read, and the
+// request of the response is not recorded; use AttributeRequest to record it.
+// If resp is nil, b is returned unchanged.
+//
+// Example:
+//
+//	if resp.StatusCode != http.StatusOK {
+//		return failhttp.AttributeResponse(fail.New(), resp).Msg("unexpected response from inventory service")
+//	}
+func AttributeResponse(b fail.Builder, resp *http.Response) fail.Builder {
+	if resp == nil {
+		return b
+	}
+
+	attrs := map[string]any{
+		"status_code": resp.StatusCode,
+		"status":      nonEmpty(resp.Status),
+	}
+	if headers := captureHeaders(resp.Header); len(headers) > 0 {
+		attrs["header"] = headers
+	}
+
+	return b.AttributeGroup(AttributeResponseGroup, attrs)
+}
+
+// captureHeaders returns the captured headers of h keyed by their lower-case names, with credentials redacted.
+func captureHeaders(h http.Header) map[string]any {
+	res := map[string]any{}
+	for _, name := range *capturedHeaders.Load() {
+		values := h.Values(name)
+		if len(values) == 0 {
+			continue
+		}
+
+		value := strings.Join(values, ", ")
+		if slices.Contains(redactedHeaders, name) {
+			value = fail.Redacted
+		}
+
+		res[strings.ToLower(name)] = value
+	}
+
+	return res
+}
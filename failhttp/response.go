@@ -0,0 +1,143 @@
+// Package failhttp provides helpers for converting between fail errors and HTTP messages.
+package failhttp
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/FlowSeer/fail"
+)
+
+// Attribute keys used by the helpers in this package.
+const (
+	// AttributeMethod is the attribute key for the HTTP method of the request.
+	AttributeMethod = "http_method"
+	// AttributeURL is the attribute key for the URL of the request.
+	AttributeURL = "http_url"
+	// AttributeBody is the attribute key for a snippet of a response body that could not be parsed.
+	AttributeBody = "http_body"
+)
+
+// MaxBodySize is the maximum number of bytes read from a response body by FromResponse.
+const MaxBodySize = 1 << 20
+
+// maxBodySnippet is the maximum number of bytes of an unparsed response body recorded as AttributeBody.
+const maxBodySnippet = 1024
+
+// FromResponse builds an error from an HTTP response with a non-2xx status code.
+//
+// If the response is nil or has a 2xx status code, FromResponse returns nil.
+// Otherwise, the response body is read (up to MaxBodySize bytes) and parsed as follows:
+//   - application/problem+json bodies are parsed using fail.FromProblemJson.
+//   - JSON bodies produced by the fail JSON printer are restored using fail.FromJson.
+//   - Any other non-empty body is recorded as the AttributeBody attribute, truncated to 1024 bytes.
+//
+// The returned error carries the response status code (if it is in the 400-599 range, or is a 3xx code
+// and status codes are relaxed using fail.SetRelaxedHttpStatusCodes), the
+// backoff from the Retry-After header (see fail.RetryAfter), and the request method and URL as attributes. If no domain is known,
+// fail.DomainDependency is used. The response body is consumed but not closed.
+//
+// Example:
+//
+//	resp, err := http.Get(url)
+//	if err != nil {
+//		return err
+//	}
+//	defer resp.Body.Close()
+//
+//	if err := failhttp.FromResponse(resp); err != nil {
+//		return err
+//	}
+func FromResponse(resp *http.Response) error {
+	if resp == nil || (resp.StatusCode >= 200 && resp.StatusCode < 300) {
+		return nil
+	}
+
+	var body []byte
+	if resp.Body != nil {
+		body, _ = io.ReadAll(io.LimitReader(resp.Body, MaxBodySize))
+	}
+
+	b, msg := parseBody(resp.Header.Get("Content-Type"), body)
+	if msg == "" {
+		msg = fmt.Sprintf("unexpected HTTP status %s", statusText(resp))
+	}
+
+	b = b.HttpStatusCode(resp.StatusCode)
+
+	if retryAfter, ok := ParseRetryAfter(resp.Header.Get("Retry-After"), time.Now()); ok {
+		b = b.RetryAfter(retryAfter)
+	}
+
+	if req := resp.Request; req != nil {
+		b = b.Attribute(AttributeMethod, nonEmpty(req.Method))
+		if req.URL != nil {
+			b = b.Attribute(AttributeURL, req.URL.Redacted())
+		}
+	}
+
+	return b.Msg(msg)
+}
+
+// parseBody creates a builder from the response body and returns it together with the message found in the body, if any.
+//
+// The domain of the returned builder is fail.DomainDependency unless the body specifies a domain.
+func parseBody(contentType string, body []byte) (fail.Builder, string) {
+	body = bytes.TrimSpace(body)
+	if len(body) == 0 {
+		return fail.New().Domain(fail.DomainDependency), ""
+	}
+
+	mediaType, _, _ := mime.ParseMediaType(contentType)
+	if mediaType == fail.ProblemJsonContentType {
+		if f, err := fail.FromProblemJson(body); err == nil {
+			return fromParsed(f)
+		}
+	}
+
+	if body[0] == '{' {
+		if f, err := fail.FromJson(body); err == nil && f.ErrorMessage() != fail.EmptyMessage {
+			return fromParsed(f)
+		}
+	}
+
+	snippet := string(body)
+	if len(snippet) > maxBodySnippet {
+		snippet = snippet[:maxBodySnippet] + "..."
+	}
+
+	return fail.New().Domain(fail.DomainDependency).Attribute(AttributeBody, snippet), ""
+}
+
+// fromParsed creates a builder from an error parsed from the response body and returns it together with its message.
+func fromParsed(f fail.Fail) (fail.Builder, string) {
+	b := fail.From(f)
+	if f.ErrorDomain() == fail.DomainUnspecified {
+		b = b.Domain(fail.DomainDependency)
+	}
+
+	return b, f.ErrorMessage()
+}
+
+// statusText returns the status line of the response, such as "404 Not Found".
+func statusText(resp *http.Response) string {
+	if resp.Status != "" {
+		return resp.Status
+	}
+
+	return strings.TrimSpace(fmt.Sprintf("%d %s", resp.StatusCode, http.StatusText(resp.StatusCode)))
+}
+
+// nonEmpty returns s, or nil if s is empty, so that empty values are ignored by fail.Builder.Attribute.
+func nonEmpty(s string) any {
+	if s == "" {
+		return nil
+	}
+
+	return s
+}
@@ -0,0 +1,58 @@
+package failhttp
+
+import (
+	"math"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/FlowSeer/fail"
+)
+
+// SetRetryAfter sets the Retry-After header from the retry backoff of err, if any.
+//
+// The backoff returned by fail.RetryAfter is rounded up to whole seconds. If err is nil or
+// carries no backoff, the header is left unchanged.
+//
+// Example:
+//
+//	failhttp.SetRetryAfter(w.Header(), err)
+//	w.WriteHeader(fail.HttpStatusCode(err))
+func SetRetryAfter(h http.Header, err error) {
+	d := fail.RetryAfter(err)
+	if d <= 0 {
+		return
+	}
+
+	h.Set("Retry-After", strconv.FormatInt(int64(math.Ceil(d.Seconds())), 10))
+}
+
+// ParseRetryAfter parses the value of a Retry-After header relative to now.
+//
+// Both forms defined by RFC 9110 are supported: a number of seconds, and an HTTP date.
+// It reports false if the value cannot be parsed or does not denote a point in the future.
+//
+// Example:
+//
+//	d, ok := failhttp.ParseRetryAfter(resp.Header.Get("Retry-After"), time.Now())
+func ParseRetryAfter(value string, now time.Time) (time.Duration, bool) {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return 0, false
+	}
+
+	if seconds, err := strconv.ParseInt(value, 10, 64); err == nil {
+		if seconds <= 0 {
+			return 0, false
+		}
+
+		return time.Duration(seconds) * time.Second, true
+	}
+
+	if t, err := http.ParseTime(value); err == nil && t.After(now) {
+		return t.Sub(now), true
+	}
+
+	return 0, false
+}
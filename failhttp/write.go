@@ -0,0 +1,272 @@
+package failhttp
+
+import (
+	"cmp"
+	"mime"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/FlowSeer/fail"
+)
+
+// Response headers set by Write.
+const (
+	// HeaderErrorCode is the response header carrying the error code.
+	HeaderErrorCode = "X-Error-Code"
+	// HeaderTraceId is the response header carrying the trace ID of the error.
+	HeaderTraceId = "X-Trace-Id"
+	// HeaderErrorId is the response header carrying the unique instance ID of the error.
+	HeaderErrorId = "X-Error-Id"
+)
+
+// Media types supported by Write.
+const (
+	// ContentTypeJson is the media type of documents produced by the fail JSON printer.
+	ContentTypeJson = "application/json"
+	// ContentTypeText is the media type of plain text responses.
+	ContentTypeText = "text/plain"
+)
+
+// WriteOption configures the behavior of Write.
+type WriteOption func(*writeOptions)
+
+// writeOptions holds the configuration of Write.
+type writeOptions struct {
+	debug         bool
+	referenceCode bool
+}
+
+// Debug enables or disables debug detail in the responses written by Write.
+//
+// With debug detail enabled, the response contains the developer-facing message, the causes,
+// the attributes and all other metadata of the error, as rendered by the fail printers. This may
+// leak internal information and must only be enabled in non-production environments.
+//
+// Example:
+//
+//	failhttp.Write(w, r, err, failhttp.Debug(os.Getenv("ENV") != "production"))
+func Debug(debug bool) WriteOption {
+	return func(o *writeOptions) {
+		o.debug = debug
+	}
+}
+
+// AppendReferenceCode enables or disables appending the reference code of the error to the user-facing message.
+//
+// With the reference code enabled, the user-facing message (or the status text if there is none) is followed
+// by the reference code of the error (see fail.ReferenceCode), as in "Payment declined (reference E7K2-9QW1)".
+// Users can quote the reference to support, which can find the occurrence in the logs using it.
+// Errors without an instance ID are assigned one first, see Write.
+//
+// Example:
+//
+//	failhttp.Write(w, r, err, failhttp.AppendReferenceCode(true))
+func AppendReferenceCode(enabled bool) WriteOption {
+	return func(o *writeOptions) {
+		o.referenceCode = enabled
+	}
+}
+
+// Write writes err as the HTTP response to r.
+//
+// The format of the body is negotiated using the Accept header of the request:
+//   - application/problem+json: an RFC 7807 problem details document (see fail.ProblemJsonPrinter)
+//   - application/json: a document in the format of the fail JSON printer (see fail.JsonPrinter)
+//   - text/plain: the message as plain text
+//
+// If the request has no Accept header or accepts any type, problem+json is used. The status code is taken
+// from fail.HttpStatusCode, falling back to 500 if it is not a valid status code. The error code is sent in
+// the HeaderErrorCode header, the trace ID in the HeaderTraceId header, the instance ID of the error (see
+// fail.Id) in the HeaderErrorId header, and the retry backoff in the Retry-After header (see SetRetryAfter).
+// If err has no instance ID, it is assigned a new one (see fail.NewId), which is used for the header, the
+// reference code and the body alike. To log the error with the same ID, assign the ID before calling Write:
+//
+//	err = fail.WithId(err, fail.NewId())
+//	logger.Error("request failed", "error", err)
+//	_ = failhttp.Write(w, r, err)
+//
+// Unless debug detail is enabled using the Debug option, the body only contains the user-facing message
+// (or the status text if there is none), the code, the status code, the help URL, the field errors of
+// validation failures (see fail.Fields) and the trace, correlation and error IDs. The developer-facing message
+// is never included, since it may contain internal details.
+// If err is nil, nothing is written. Write returns the first error encountered while writing the body.
+//
+// Example:
+//
+//	func handler(w http.ResponseWriter, r *http.Request) {
+//		if err := serve(r); err != nil {
+//			_ = failhttp.Write(w, r, err)
+//		}
+//	}
+func Write(w http.ResponseWriter, r *http.Request, err error, opts ...WriteOption) error {
+	if err == nil {
+		return nil
+	}
+
+	accept := ""
+	if r != nil {
+		accept = r.Header.Get("Accept")
+	}
+
+	status, header, body := Render(accept, err, opts...)
+	for k, v := range header {
+		w.Header()[k] = v
+	}
+	w.WriteHeader(status)
+
+	_, wErr := w.Write(body)
+	return wErr
+}
+
+// Render returns the status code, headers and body of the response Write would write for err.
+//
+// The accept argument is the value of the Accept header of the request. Render is useful for
+// integrating with frameworks that do not use http.ResponseWriter; see Write for details.
+// If err is nil, Render returns http.StatusOK, empty headers and an empty body.
+//
+// Example:
+//
+//	status, header, body := failhttp.Render(c.Get("Accept"), err)
+func Render(accept string, err error, opts ...WriteOption) (int, http.Header, []byte) {
+	h := http.Header{}
+	if err == nil {
+		return http.StatusOK, h, nil
+	}
+
+	var o writeOptions
+	for _, opt := range opts {
+		opt(&o)
+	}
+
+	id := fail.Id(err)
+	if id == "" {
+		id = fail.NewId()
+		err = fail.WithId(err, id)
+	}
+
+	status := fail.HttpStatusCode(err)
+	if status < 100 || status > 599 {
+		status = http.StatusInternalServerError
+	}
+
+	contentType := negotiate(accept)
+
+	h.Set("Content-Type", contentType+"; charset=utf-8")
+	h.Set("X-Content-Type-Options", "nosniff")
+	if code := fail.Code(err); code != "" && code != fail.ErrCodeUnspecified {
+		h.Set(HeaderErrorCode, code)
+	}
+	if traceId := fail.TraceId(err); traceId != "" {
+		h.Set(HeaderTraceId, traceId)
+	}
+	if id != "" {
+		h.Set(HeaderErrorId, id)
+	}
+	SetRetryAfter(h, err)
+
+	if o.referenceCode {
+		err = withReferenceCode(err, status)
+	}
+	if !o.debug {
+		err = public(err, status)
+	}
+
+	var body string
+	switch contentType {
+	case fail.ProblemJsonContentType:
+		body = fail.PrintsProblemJson(err, fail.PrintIndent(0), fail.PrintAttributes(o.debug), fail.PrintTags(o.debug))
+	case ContentTypeJson:
+		body = fail.PrintsJson(err, fail.PrintIndent(0), fail.PrintTime(o.debug), fail.PrintSource(o.debug), fail.PrintExitCode(o.debug),
+			fail.PrintAttributes(o.debug), fail.PrintTags(o.debug))
+	default:
+		if o.debug {
+			body = fail.PrintsPretty(err)
+		} else {
+			body = fail.Message(err)
+		}
+	}
+
+	return status, h, []byte(body)
+}
+
+// public returns an error carrying only the information of err that may be shown to clients.
+//
+// The returned error keeps the ID of err, so that it matches the HeaderErrorId header. Its message is the
+// explicit user-facing message of err, or the status text if there is none, so that internal error text is
+// never sent to clients. The error is built without running the hooks or adding the global attributes, which
+// may carry internal data as well.
+func public(err error, status int) error {
+	msg := fail.ExplicitUserMessage(err)
+	if msg == "" {
+		msg = http.StatusText(status)
+	}
+
+	f := fail.Fail(fail.New().
+		Code(fail.Code(err)).
+		HttpStatusCode(status).
+		UserMsg(msg).
+		HelpURL(fail.HelpURL(err)).
+		FieldErrors(fail.Fields(err)...).
+		TraceId(fail.TraceId(err)).
+		CorrelationId(fail.CorrelationId(err)).
+		Id(fail.Id(err)))
+
+	return fail.WithMessage(f, cmp.Or(msg, fail.EmptyMessage))
+}
+
+// withReferenceCode returns err with its reference code appended to its user-facing message.
+//
+// If err has no explicit user-facing message, the status text of status is used instead.
+func withReferenceCode(err error, status int) error {
+	ref := fail.ReferenceCode(err)
+	if ref == "" {
+		return err
+	}
+
+	msg := fail.ExplicitUserMessage(err)
+	if msg == "" {
+		msg = http.StatusText(status)
+	}
+
+	return fail.WithUserMessage(err, msg+" (reference "+ref+")")
+}
+
+// negotiate returns the media type of the response body preferred by the provided Accept header.
+//
+// Media types are ranked by their quality value; among equal values, the first listed wins.
+// Wildcards select problem+json, except for text/*, which selects plain text.
+func negotiate(accept string) string {
+	best, bestQ := fail.ProblemJsonContentType, -1.0
+	for _, part := range strings.Split(accept, ",") {
+		mediaType, params, err := mime.ParseMediaType(strings.TrimSpace(part))
+		if err != nil {
+			continue
+		}
+
+		q := 1.0
+		if v, ok := params["q"]; ok {
+			if parsed, err := strconv.ParseFloat(v, 64); err == nil {
+				q = parsed
+			}
+		}
+
+		var candidate string
+		switch mediaType {
+		case fail.ProblemJsonContentType, "application/*", "*/*":
+			candidate = fail.ProblemJsonContentType
+		case ContentTypeJson:
+			candidate = ContentTypeJson
+		case ContentTypeText, "text/*":
+			candidate = ContentTypeText
+		default:
+			continue
+		}
+
+		if q > 0 && q > bestQ {
+			best, bestQ = candidate, q
+		}
+	}
+
+	return best
+}
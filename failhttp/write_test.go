@@ -0,0 +1,41 @@
+package failhttp_test
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/FlowSeer/fail"
+	"github.com/FlowSeer/fail/failhttp"
+)
+
+func TestRenderPublic(t *testing.T) {
+	fail.SetGlobalAttributes(map[string]any{"hostname": "db-host-1", "internal_dsn": "postgres://admin@10.0.0.5"})
+	fail.AddHook(func(b fail.Builder) fail.Builder {
+		return b.Tag("internal-hook-tag").Attribute("hook_attribute", "hook-value")
+	})
+	t.Cleanup(func() {
+		fail.SetGlobalAttributes(nil)
+		fail.ResetHooks()
+	})
+
+	errs := map[string]error{
+		"fail":  fail.New().Code(fail.ErrCodeNotFound).UserMsg("User not found").Msg("select from users failed"),
+		"plain": errors.New("select from users failed"),
+	}
+
+	leaks := []string{"db-host-1", "internal_dsn", "postgres://", "internal-hook-tag", "hook_attribute", "hook-value", "select from users"}
+
+	for name, err := range errs {
+		for _, accept := range []string{fail.ProblemJsonContentType, failhttp.ContentTypeJson, failhttp.ContentTypeText} {
+			t.Run(name+"/"+accept, func(t *testing.T) {
+				_, _, body := failhttp.Render(accept, err)
+				for _, leak := range leaks {
+					if strings.Contains(string(body), leak) {
+						t.Errorf("body %s contains %q", body, leak)
+					}
+				}
+			})
+		}
+	}
+}
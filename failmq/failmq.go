@@ -0,0 +1,166 @@
+// Package failmq propagates fail errors through message queues using dead-letter envelopes.
+//
+// A dead-letter envelope is the original message, with its payload and headers unchanged, extended
+// with headers describing the error that prevented it from being processed. The headers are plain
+// strings, so that envelopes can be sent through Kafka, AMQP, NATS or any other broker supporting
+// message headers, and parsed back by the consumers of the dead-letter queue.
+package failmq
+
+import (
+	"encoding/json"
+	"maps"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/FlowSeer/fail"
+)
+
+// DeadLetterSuffix is appended to the topic of a message to obtain the topic of its dead-letter envelope.
+const DeadLetterSuffix = ".dlq"
+
+// HeaderPrefix is the prefix of all headers added by DeadLetter.
+const HeaderPrefix = "fail-"
+
+// Headers added to dead-letter envelopes.
+const (
+	// HeaderError is the header carrying the JSON-encoded fail.Snapshot of the error.
+	HeaderError = HeaderPrefix + "error"
+	// HeaderErrorCode is the header carrying the error code, for routing without decoding HeaderError.
+	HeaderErrorCode = HeaderPrefix + "error-code"
+	// HeaderErrorMessage is the header carrying the developer-facing message of the error.
+	HeaderErrorMessage = HeaderPrefix + "error-message"
+	// HeaderOriginalTopic is the header carrying the topic of the original message.
+	HeaderOriginalTopic = HeaderPrefix + "original-topic"
+	// HeaderFailedAt is the header carrying the time the envelope was created, in RFC 3339 format.
+	HeaderFailedAt = HeaderPrefix + "failed-at"
+	// HeaderAttempts is the header carrying the number of processing attempts, if known.
+	HeaderAttempts = HeaderPrefix + "attempts"
+)
+
+// Message is a broker-agnostic message, as consumed from or produced to a queue.
+type Message struct {
+	// Topic is the topic, queue or subject of the message.
+	Topic string
+	// Key is the partitioning or routing key of the message, if any.
+	Key []byte
+	// Headers are the headers of the message.
+	Headers map[string]string
+	// Payload is the body of the message.
+	Payload []byte
+}
+
+// DeadLetter returns the dead-letter envelope of msg, which could not be processed because of err.
+//
+// The envelope has the topic of msg followed by DeadLetterSuffix, and the key and payload of msg.
+// Its headers are the headers of msg extended with HeaderError, containing the JSON-encoded
+// fail.Details of err, HeaderErrorCode, HeaderErrorMessage, HeaderOriginalTopic and HeaderFailedAt.
+// If attempts is positive, it is recorded as HeaderAttempts. Headers of msg starting with HeaderPrefix,
+// for example from an earlier dead-letter envelope, are replaced. The headers of msg are not modified.
+//
+// Example:
+//
+//	if err := process(msg); err != nil {
+//		dlq := failmq.DeadLetter(msg, err, attempts)
+//		producer.Send(dlq.Topic, dlq.Key, dlq.Headers, dlq.Payload)
+//	}
+func DeadLetter(msg Message, err error, attempts int) Message {
+	headers := make(map[string]string, len(msg.Headers)+6)
+	for k, v := range msg.Headers {
+		if !strings.HasPrefix(k, HeaderPrefix) {
+			headers[k] = v
+		}
+	}
+
+	details, _ := json.Marshal(fail.Details(err))
+	headers[HeaderError] = string(details)
+	headers[HeaderErrorCode] = fail.Code(err)
+	headers[HeaderErrorMessage] = fail.Message(err)
+	headers[HeaderOriginalTopic] = msg.Topic
+	headers[HeaderFailedAt] = time.Now().UTC().Format(time.RFC3339Nano)
+	if attempts > 0 {
+		headers[HeaderAttempts] = strconv.Itoa(attempts)
+	}
+
+	return Message{
+		Topic:   msg.Topic + DeadLetterSuffix,
+		Key:     msg.Key,
+		Headers: headers,
+		Payload: msg.Payload,
+	}
+}
+
+// Envelope is a parsed dead-letter envelope.
+type Envelope struct {
+	// Original is the original message, with its original topic and without the headers added by DeadLetter.
+	Original Message
+	// Err is the error that prevented the original message from being processed.
+	Err fail.Fail
+	// FailedAt is the time the envelope was created, or the zero time if unknown.
+	FailedAt time.Time
+	// Attempts is the number of processing attempts, or 0 if unknown.
+	Attempts int
+}
+
+// Parse parses a dead-letter envelope created by DeadLetter.
+//
+// The error is restored from HeaderError using fail.FromSnapshot. If the envelope has no HeaderError
+// header, it is restored from HeaderErrorCode and HeaderErrorMessage. Parse returns an error if
+// msg is not a dead-letter envelope, or if HeaderError cannot be decoded.
+//
+// Example:
+//
+//	env, err := failmq.Parse(msg)
+//	if err != nil {
+//		return err
+//	}
+//	if fail.Retryable(env.Err) {
+//		producer.Send(env.Original.Topic, env.Original.Key, env.Original.Headers, env.Original.Payload)
+//	}
+func Parse(msg Message) (Envelope, error) {
+	topic, ok := msg.Headers[HeaderOriginalTopic]
+	if !ok {
+		return Envelope{}, fail.New().
+			Code(fail.ErrCodeInvalidFormat).
+			Attribute("topic", msg.Topic).
+			Msgf("message is not a dead-letter envelope: missing header %s", HeaderOriginalTopic)
+	}
+
+	env := Envelope{
+		Original: Message{
+			Topic:   topic,
+			Key:     msg.Key,
+			Headers: maps.Clone(msg.Headers),
+			Payload: msg.Payload,
+		},
+	}
+	maps.DeleteFunc(env.Original.Headers, func(k, _ string) bool {
+		return strings.HasPrefix(k, HeaderPrefix)
+	})
+
+	if raw, ok := msg.Headers[HeaderError]; ok {
+		var s fail.Snapshot
+		if err := json.Unmarshal([]byte(raw), &s); err != nil {
+			return Envelope{}, fail.New().
+				Code(fail.ErrCodeInvalidFormat).
+				Cause(err).
+				Msgf("failed to decode header %s", HeaderError)
+		}
+		env.Err = fail.FromSnapshot(s)
+	} else {
+		env.Err = fail.FromSnapshot(fail.Snapshot{
+			Msg:  msg.Headers[HeaderErrorMessage],
+			Code: msg.Headers[HeaderErrorCode],
+		})
+	}
+
+	if t, err := time.Parse(time.RFC3339Nano, msg.Headers[HeaderFailedAt]); err == nil {
+		env.FailedAt = t
+	}
+
+	if attempts, err := strconv.Atoi(msg.Headers[HeaderAttempts]); err == nil {
+		env.Attempts = attempts
+	}
+
+	return env, nil
+}
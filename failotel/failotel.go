@@ -0,0 +1,254 @@
+// Package failotel bridges fail errors to OpenTelemetry logs.
+//
+// Errors are converted into OpenTelemetry log records with a severity derived from the error,
+// the developer-facing message as body, the error metadata as attributes, and the trace context
+// of the error, so that errors appear in OpenTelemetry-native log pipelines without custom glue.
+package failotel
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/FlowSeer/fail"
+	"go.opentelemetry.io/otel/log"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// Emit converts err into a log record and emits it using the provided logger.
+//
+// If ctx does not carry a valid span context but the error has a trace ID and span ID
+// (see fail.TraceLink), the error's trace context is used, so that the record is correlated
+// with the trace in which the error occurred. If err is nil, nothing is emitted.
+//
+// Example:
+//
+//	logger := global.GetLoggerProvider().Logger("checkout")
+//	failotel.Emit(ctx, logger, err)
+func Emit(ctx context.Context, logger log.Logger, err error) {
+	if err == nil {
+		return
+	}
+
+	ctx = ContextWithTrace(ctx, err)
+
+	r := Record(err)
+	if !logger.Enabled(ctx, log.EnabledParameters{Severity: r.Severity()}) {
+		return
+	}
+
+	logger.Emit(ctx, r)
+}
+
+// ContextWithTrace returns ctx with the trace context of err, unless ctx already carries a valid span context.
+//
+// The trace context is taken from fail.TraceLink. If err does not carry both a trace ID and
+// a span ID, ctx is returned unchanged.
+func ContextWithTrace(ctx context.Context, err error) context.Context {
+	if trace.SpanContextFromContext(ctx).IsValid() {
+		return ctx
+	}
+
+	traceId, spanId := fail.TraceLink(err)
+	if traceId == "" || spanId == "" {
+		return ctx
+	}
+
+	tid, tErr := trace.TraceIDFromHex(traceId)
+	sid, sErr := trace.SpanIDFromHex(spanId)
+	if tErr != nil || sErr != nil {
+		return ctx
+	}
+
+	return trace.ContextWithSpanContext(ctx, trace.NewSpanContext(trace.SpanContextConfig{
+		TraceID: tid,
+		SpanID:  sid,
+		Remote:  true,
+	}))
+}
+
+// Record converts err into an OpenTelemetry log record.
+//
+// The record is populated as follows:
+//   - timestamp: the time of the error, or the current time if it has none
+//   - severity: derived from fail.LogLevel
+//   - body: the developer-facing message
+//   - attributes: the error metadata, including causes and associated errors as nested maps
+//     up to fail.LogDepth
+//
+// The trace context is not part of the record; it is taken from the context passed to
+// log.Logger.Emit. Use ContextWithTrace or Emit to attach the trace context of the error.
+func Record(err error) log.Record {
+	var r log.Record
+
+	details := fail.Details(err)
+
+	now := time.Now()
+	r.SetObservedTimestamp(now)
+	if details.Time.IsZero() {
+		r.SetTimestamp(now)
+	} else {
+		r.SetTimestamp(details.Time)
+	}
+
+	severity, text := Severity(err)
+	r.SetSeverity(severity)
+	r.SetSeverityText(text)
+	r.SetBody(log.StringValue(details.Msg))
+	r.AddAttributes(attributes(details, 1, false)...)
+
+	return r
+}
+
+// Severity returns the OpenTelemetry log severity and severity text for err.
+//
+// The severity is derived from fail.LogLevel: slog.LevelInfo maps to log.SeverityInfo, slog.LevelWarn
+// to log.SeverityWarn, slog.LevelError to log.SeverityError, and higher levels to log.SeverityFatal.
+func Severity(err error) (log.Severity, string) {
+	switch level := fail.LogLevel(err); {
+	case level > slog.LevelError:
+		return log.SeverityFatal, "FATAL"
+	case level == slog.LevelError:
+		return log.SeverityError, "ERROR"
+	case level >= slog.LevelWarn:
+		return log.SeverityWarn, "WARN"
+	default:
+		return log.SeverityInfo, "INFO"
+	}
+}
+
+// attributes converts the snapshot of an error into log attributes.
+//
+// If nested is true, the message is included as an attribute, since nested errors have no body.
+func attributes(d fail.Snapshot, depth int, nested bool) []log.KeyValue {
+	var kvs []log.KeyValue
+	if nested {
+		kvs = append(kvs, log.String("msg", d.Msg))
+	}
+
+	kvs = appendString(kvs, "user_msg", d.UserMsg)
+	kvs = appendString(kvs, "op", d.Op)
+	kvs = appendString(kvs, "code", d.Code)
+	kvs = appendString(kvs, "domain", d.Domain)
+	kvs = appendString(kvs, "help_url", d.HelpURL)
+	kvs = appendString(kvs, "correlation_id", d.CorrelationId)
+	kvs = appendString(kvs, "error_id", d.Id)
+	kvs = appendString(kvs, "reference_code", d.ReferenceCode())
+	kvs = appendString(kvs, "label", d.Label)
+	kvs = appendString(kvs, "role", d.Role)
+
+	if d.ExitCode > 0 {
+		kvs = append(kvs, log.Int("exit_code", d.ExitCode))
+	}
+
+	if d.HTTPStatus > 0 {
+		kvs = append(kvs, log.Int("http_status_code", d.HTTPStatus))
+	}
+
+	if !d.Source.IsZero() {
+		kvs = append(kvs, log.String("source", d.Source.String()))
+	}
+
+	if d.Duration > 0 {
+		kvs = append(kvs, log.String("duration", d.Duration.String()))
+	}
+
+	if d.RetryAfter > 0 {
+		kvs = append(kvs, log.String("retry_after", d.RetryAfter.String()))
+	}
+
+	if d.Transience != fail.TransienceUnknown {
+		kvs = append(kvs, log.String("transience", d.Transience.String()))
+	}
+
+	if len(d.Tags) > 0 {
+		tags := make([]log.Value, len(d.Tags))
+		for i, tag := range d.Tags {
+			tags[i] = log.StringValue(tag)
+		}
+		kvs = append(kvs, log.Slice("tags", tags...))
+	}
+
+	if len(d.Attrs) > 0 {
+		attrs := make([]log.KeyValue, 0, len(d.Attrs))
+		for k, v := range d.Attrs {
+			attrs = append(attrs, log.KeyValue{Key: k, Value: value(v)})
+		}
+		kvs = append(kvs, log.Map("attributes", attrs...))
+	}
+
+	if depth < fail.LogDepth() {
+		kvs = appendNested(kvs, "causes", d.Causes, depth)
+		kvs = appendNested(kvs, "associated", d.Associated, depth)
+	}
+
+	return kvs
+}
+
+// appendNested appends nested snapshots as a slice of maps, if there are any.
+func appendNested(kvs []log.KeyValue, key string, nested []fail.Snapshot, depth int) []log.KeyValue {
+	if len(nested) == 0 {
+		return kvs
+	}
+
+	values := make([]log.Value, len(nested))
+	for i, n := range nested {
+		values[i] = log.MapValue(attributes(n, depth+1, true)...)
+	}
+
+	return append(kvs, log.Slice(key, values...))
+}
+
+// appendString appends a string attribute if the value is not empty.
+func appendString(kvs []log.KeyValue, key, value string) []log.KeyValue {
+	if value == "" {
+		return kvs
+	}
+
+	return append(kvs, log.String(key, value))
+}
+
+// value converts an attribute value into a log value.
+func value(v any) log.Value {
+	switch v := v.(type) {
+	case nil:
+		return log.Value{}
+	case string:
+		return log.StringValue(v)
+	case bool:
+		return log.BoolValue(v)
+	case int:
+		return log.IntValue(v)
+	case int8:
+		return log.Int64Value(int64(v))
+	case int16:
+		return log.Int64Value(int64(v))
+	case int32:
+		return log.Int64Value(int64(v))
+	case int64:
+		return log.Int64Value(v)
+	case uint8:
+		return log.Int64Value(int64(v))
+	case uint16:
+		return log.Int64Value(int64(v))
+	case uint32:
+		return log.Int64Value(int64(v))
+	case float32:
+		return log.Float64Value(float64(v))
+	case float64:
+		return log.Float64Value(v)
+	case []byte:
+		return log.BytesValue(v)
+	case time.Time:
+		return log.StringValue(v.Format(time.RFC3339Nano))
+	case time.Duration:
+		return log.StringValue(v.String())
+	case fmt.Stringer:
+		return log.StringValue(v.String())
+	case error:
+		return log.StringValue(v.Error())
+	default:
+		return log.StringValue(fmt.Sprint(v))
+	}
+}
@@ -0,0 +1,21 @@
+module github.com/FlowSeer/fail/failotel
+
+go 1.24.5
+
+require (
+	github.com/FlowSeer/fail v0.0.0-00010101000000-000000000000
+	go.opentelemetry.io/otel/log v0.14.0
+	go.opentelemetry.io/otel/trace v1.38.0
+)
+
+require (
+	github.com/FlowSeer/wz v0.0.3 // indirect
+	github.com/go-logr/logr v1.4.3 // indirect
+	github.com/go-logr/stdr v1.2.2 // indirect
+	go.opentelemetry.io/auto/sdk v1.1.0 // indirect
+	go.opentelemetry.io/otel v1.38.0 // indirect
+	go.opentelemetry.io/otel/metric v1.38.0 // indirect
+	golang.org/x/exp v0.0.0-20250819193227-8b4c13bb791b // indirect
+)
+
+replace github.com/FlowSeer/fail => ../
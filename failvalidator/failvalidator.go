@@ -0,0 +1,174 @@
+// Package failvalidator converts validation errors of github.com/go-playground/validator into fail errors.
+//
+// The converted errors carry one fail.FieldError per invalid field, so that the printers and
+// failhttp render them as a structured "fields" array instead of a single opaque message.
+package failvalidator
+
+import (
+	"errors"
+	"strconv"
+	"strings"
+
+	"github.com/FlowSeer/fail"
+	ut "github.com/go-playground/universal-translator"
+	"github.com/go-playground/validator/v10"
+)
+
+// AttributeFieldPrefix is the prefix of the attribute keys under which the failed validation tag of each
+// field is recorded, such as "validation.email" with the value "required" or "min=3".
+const AttributeFieldPrefix = "validation" + fail.AttributeGroupSeparator
+
+// Option configures the conversion performed by FromError.
+type Option func(*options)
+
+// options holds the configuration of FromError.
+type options struct {
+	translator ut.Translator
+}
+
+// Translator sets the translator used to generate the messages of the field errors.
+//
+// The translations must be registered with the validator, for example using the packages in
+// github.com/go-playground/validator/v10/translations. Without a translator, English messages are
+// generated for common tags.
+//
+// Example:
+//
+//	err = failvalidator.FromError(validate.Struct(req), failvalidator.Translator(trans))
+func Translator(translator ut.Translator) Option {
+	return func(o *options) {
+		o.translator = translator
+	}
+}
+
+// FromError converts the validator.ValidationErrors in err into a Fail describing the invalid fields.
+//
+// The returned Fail has ErrCodeValidation, DomainValidation and the HTTP status code 400, and carries
+// one fail.FieldError per invalid field. The field of a FieldError is the namespace of the field without
+// the name of the top-level struct, such as "Address.City" or "Items[2].Quantity" (or the names returned
+// by the tag name function registered with the validator), its code is the failed validation tag, and
+// its message describes the failure. The failed tag of each field, including its parameter, is also
+// recorded as an attribute with the key AttributeFieldPrefix followed by the field.
+//
+// The values of the invalid fields are not recorded, since they may contain secrets. Errors that do not
+// contain validator.ValidationErrors, such as *validator.InvalidValidationError, are returned unchanged.
+// If err is nil, FromError returns nil.
+//
+// Example:
+//
+//	if err := validate.Struct(req); err != nil {
+//		_ = failhttp.Write(w, r, failvalidator.FromError(err))
+//		return
+//	}
+func FromError(err error, opts ...Option) error {
+	if err == nil {
+		return nil
+	}
+
+	var validationErrs validator.ValidationErrors
+	if !errors.As(err, &validationErrs) || len(validationErrs) == 0 {
+		return err
+	}
+
+	var o options
+	for _, opt := range opts {
+		opt(&o)
+	}
+
+	b := fail.New().
+		Code(fail.ErrCodeValidation).
+		Domain(fail.DomainValidation).
+		HttpStatusCode(400)
+
+	for _, fe := range validationErrs {
+		field := fieldName(fe)
+
+		tag := fe.Tag()
+		if fe.Param() != "" {
+			tag += "=" + fe.Param()
+		}
+
+		b = b.
+			FieldErrors(fail.FieldError{Field: field, Code: fe.Tag(), Msg: message(fe, o)}).
+			Attribute(AttributeFieldPrefix+field, tag)
+	}
+
+	if len(validationErrs) == 1 {
+		return b.Msg("validation failed for 1 field")
+	}
+
+	return b.Msgf("validation failed for %d fields", len(validationErrs))
+}
+
+// fieldName returns the namespace of the field without the name of the top-level struct.
+func fieldName(fe validator.FieldError) string {
+	namespace := fe.Namespace()
+	if _, rest, ok := strings.Cut(namespace, "."); ok {
+		return rest
+	}
+
+	return fe.Field()
+}
+
+// message returns a human-readable description of the validation failure of fe.
+func message(fe validator.FieldError, o options) string {
+	if o.translator != nil {
+		if msg := fe.Translate(o.translator); msg != "" {
+			return msg
+		}
+	}
+
+	param := fe.Param()
+	switch fe.Tag() {
+	case "required", "required_if", "required_unless", "required_with", "required_with_all",
+		"required_without", "required_without_all":
+		return "is required"
+	case "email":
+		return "must be a valid email address"
+	case "url", "uri", "http_url":
+		return "must be a valid URL"
+	case "uuid", "uuid3", "uuid4", "uuid5":
+		return "must be a valid UUID"
+	case "oneof":
+		return "must be one of " + strings.Join(strings.Fields(param), ", ")
+	case "len":
+		return "must have a length of " + param + sizeUnit(fe)
+	case "min":
+		return "must be at least " + param + sizeUnit(fe)
+	case "max":
+		return "must be at most " + param + sizeUnit(fe)
+	case "gte":
+		return "must be greater than or equal to " + param
+	case "gt":
+		return "must be greater than " + param
+	case "lte":
+		return "must be less than or equal to " + param
+	case "lt":
+		return "must be less than " + param
+	case "eqfield":
+		return "must be equal to " + param
+	case "nefield":
+		return "must not be equal to " + param
+	default:
+		return "failed on the " + strconv.Quote(fe.Tag()) + " validation"
+	}
+}
+
+// sizeUnit returns the unit of the parameter of length-based tags such as min and max, if the field has one.
+func sizeUnit(fe validator.FieldError) string {
+	unit := ""
+	switch fe.Kind().String() {
+	case "string":
+		unit = " character"
+	case "slice", "array", "map":
+		unit = " item"
+	default:
+		return ""
+	}
+
+	if fe.Param() != "1" {
+		unit += "s"
+	}
+
+	return unit
+}
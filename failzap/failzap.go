@@ -0,0 +1,152 @@
+// Package failzap expands fail errors into zap fields.
+//
+// The error and its causes are encoded as a nested object using zap's native field system,
+// so that all metadata of a Fail error is available to zap encoders and sinks.
+package failzap
+
+import (
+	"time"
+
+	"github.com/FlowSeer/fail"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+// Field returns a zap field containing err and all of its metadata under the "error" key.
+//
+// Causes are included up to fail.LogDepth. If err is nil, Field returns zap.Skip().
+//
+// Example:
+//
+//	logger.Error("failed to save order", failzap.Field(err))
+func Field(err error) zap.Field {
+	return NamedField("error", err)
+}
+
+// NamedField returns a zap field containing err and all of its metadata under the given key.
+//
+// If err is nil, NamedField returns zap.Skip().
+func NamedField(key string, err error) zap.Field {
+	if err == nil {
+		return zap.Skip()
+	}
+
+	return zap.Object(key, Object(err))
+}
+
+// Object returns a zapcore.ObjectMarshaler encoding err and all of its metadata.
+//
+// Causes and associated errors are included up to fail.LogDepth.
+func Object(err error) zapcore.ObjectMarshaler {
+	return object{details: fail.Details(err), depth: 1}
+}
+
+// object encodes a snapshot of an error.
+type object struct {
+	details fail.Snapshot
+	depth   int
+}
+
+// MarshalLogObject implements zapcore.ObjectMarshaler.
+func (o object) MarshalLogObject(enc zapcore.ObjectEncoder) error {
+	d := o.details
+
+	enc.AddString("msg", d.Msg)
+	addString(enc, "user_msg", d.UserMsg)
+	addString(enc, "op", d.Op)
+	addString(enc, "code", d.Code)
+	addString(enc, "domain", d.Domain)
+	addString(enc, "help_url", d.HelpURL)
+	addString(enc, "trace_id", d.TraceId)
+	addString(enc, "span_id", d.SpanId)
+	addString(enc, "correlation_id", d.CorrelationId)
+	addString(enc, "error_id", d.Id)
+	addString(enc, "reference_code", d.ReferenceCode())
+	addString(enc, "label", d.Label)
+	addString(enc, "role", d.Role)
+
+	if d.ExitCode > 0 {
+		enc.AddInt("exit_code", d.ExitCode)
+	}
+
+	if d.HTTPStatus > 0 {
+		enc.AddInt("http_status_code", d.HTTPStatus)
+	}
+
+	if !d.Time.IsZero() {
+		enc.AddTime("time", d.Time)
+	}
+
+	if !d.Source.IsZero() {
+		enc.AddString("source", d.Source.String())
+	}
+
+	addDuration(enc, "duration", d.Duration)
+	addDuration(enc, "retry_after", d.RetryAfter)
+
+	if d.Transience != fail.TransienceUnknown {
+		enc.AddString("transience", d.Transience.String())
+	}
+
+	if len(d.Tags) > 0 {
+		if err := enc.AddArray("tags", zapcore.ArrayMarshalerFunc(func(arr zapcore.ArrayEncoder) error {
+			for _, tag := range d.Tags {
+				arr.AppendString(tag)
+			}
+			return nil
+		})); err != nil {
+			return err
+		}
+	}
+
+	if len(d.Attrs) > 0 {
+		if err := enc.AddObject("attributes", zapcore.ObjectMarshalerFunc(func(obj zapcore.ObjectEncoder) error {
+			for k, v := range d.Attrs {
+				zap.Any(k, v).AddTo(obj)
+			}
+			return nil
+		})); err != nil {
+			return err
+		}
+	}
+
+	if o.depth >= fail.LogDepth() {
+		return nil
+	}
+
+	if err := o.addNested(enc, "causes", d.Causes); err != nil {
+		return err
+	}
+
+	return o.addNested(enc, "associated", d.Associated)
+}
+
+// addNested encodes nested snapshots as an array of objects, if there are any.
+func (o object) addNested(enc zapcore.ObjectEncoder, key string, nested []fail.Snapshot) error {
+	if len(nested) == 0 {
+		return nil
+	}
+
+	return enc.AddArray(key, zapcore.ArrayMarshalerFunc(func(arr zapcore.ArrayEncoder) error {
+		for _, n := range nested {
+			if err := arr.AppendObject(object{details: n, depth: o.depth + 1}); err != nil {
+				return err
+			}
+		}
+		return nil
+	}))
+}
+
+// addString adds a string field if it is not empty.
+func addString(enc zapcore.ObjectEncoder, key, value string) {
+	if value != "" {
+		enc.AddString(key, value)
+	}
+}
+
+// addDuration adds a duration field if it is positive.
+func addDuration(enc zapcore.ObjectEncoder, key string, value time.Duration) {
+	if value > 0 {
+		enc.AddDuration(key, value)
+	}
+}
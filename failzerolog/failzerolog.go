@@ -0,0 +1,127 @@
+// Package failzerolog expands fail errors into zerolog events.
+//
+// The error and its causes are encoded as a nested dictionary using zerolog's native field system,
+// so that all metadata of a Fail error is available in the structured log output.
+package failzerolog
+
+import (
+	"github.com/FlowSeer/fail"
+	"github.com/rs/zerolog"
+)
+
+// Marshal adds err and all of its metadata to the event under the zerolog.ErrorFieldName key.
+//
+// Causes are included up to fail.LogDepth. If err is nil, the event is returned unchanged.
+// The event is returned to allow chaining.
+//
+// Example:
+//
+//	failzerolog.Marshal(log.Error(), err).Msg("failed to save order")
+func Marshal(e *zerolog.Event, err error) *zerolog.Event {
+	if err == nil {
+		return e
+	}
+
+	return e.Object(zerolog.ErrorFieldName, Object(err))
+}
+
+// Object returns a zerolog.LogObjectMarshaler encoding err and all of its metadata.
+//
+// Causes and associated errors are included up to fail.LogDepth.
+// Object can be used as zerolog.ErrorMarshalFunc to expand all errors logged using Event.Err:
+//
+//	zerolog.ErrorMarshalFunc = func(err error) any {
+//		return failzerolog.Object(err)
+//	}
+func Object(err error) zerolog.LogObjectMarshaler {
+	return object{details: fail.Details(err), depth: 1}
+}
+
+// object encodes a snapshot of an error.
+type object struct {
+	details fail.Snapshot
+	depth   int
+}
+
+// MarshalZerologObject implements zerolog.LogObjectMarshaler.
+func (o object) MarshalZerologObject(e *zerolog.Event) {
+	d := o.details
+
+	e.Str("msg", d.Msg)
+	addStr(e, "user_msg", d.UserMsg)
+	addStr(e, "op", d.Op)
+	addStr(e, "code", d.Code)
+	addStr(e, "domain", d.Domain)
+	addStr(e, "help_url", d.HelpURL)
+	addStr(e, "trace_id", d.TraceId)
+	addStr(e, "span_id", d.SpanId)
+	addStr(e, "correlation_id", d.CorrelationId)
+	addStr(e, "error_id", d.Id)
+	addStr(e, "reference_code", d.ReferenceCode())
+	addStr(e, "label", d.Label)
+	addStr(e, "role", d.Role)
+
+	if d.ExitCode > 0 {
+		e.Int("exit_code", d.ExitCode)
+	}
+
+	if d.HTTPStatus > 0 {
+		e.Int("http_status_code", d.HTTPStatus)
+	}
+
+	if !d.Time.IsZero() {
+		e.Time("time", d.Time)
+	}
+
+	if !d.Source.IsZero() {
+		e.Str("source", d.Source.String())
+	}
+
+	if d.Duration > 0 {
+		e.Dur("duration", d.Duration)
+	}
+
+	if d.RetryAfter > 0 {
+		e.Dur("retry_after", d.RetryAfter)
+	}
+
+	if d.Transience != fail.TransienceUnknown {
+		e.Str("transience", d.Transience.String())
+	}
+
+	if len(d.Tags) > 0 {
+		e.Strs("tags", d.Tags)
+	}
+
+	if len(d.Attrs) > 0 {
+		e.Dict("attributes", zerolog.Dict().Fields(d.Attrs))
+	}
+
+	if o.depth >= fail.LogDepth() {
+		return
+	}
+
+	o.addNested(e, "causes", d.Causes)
+	o.addNested(e, "associated", d.Associated)
+}
+
+// addNested encodes nested snapshots as an array of objects, if there are any.
+func (o object) addNested(e *zerolog.Event, key string, nested []fail.Snapshot) {
+	if len(nested) == 0 {
+		return
+	}
+
+	arr := zerolog.Arr()
+	for _, n := range nested {
+		arr = arr.Object(object{details: n, depth: o.depth + 1})
+	}
+
+	e.Array(key, arr)
+}
+
+// addStr adds a string field if it is not empty.
+func addStr(e *zerolog.Event, key, value string) {
+	if value != "" {
+		e.Str(key, value)
+	}
+}
@@ -0,0 +1,64 @@
+package fail
+
+import "strconv"
+
+// FirstError returns the first non-nil error of errs, or nil if all are nil.
+//
+// Example:
+//
+//	err := fail.FirstError(validateName(u), validateEmail(u), validateAge(u))
+func FirstError(errs ...error) error {
+	for _, err := range errs {
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// LastError returns the last non-nil error of errs, or nil if all are nil.
+//
+// Example:
+//
+//	err := fail.LastError(attempts...)
+func LastError(errs ...error) error {
+	for i := len(errs) - 1; i >= 0; i-- {
+		if errs[i] != nil {
+			return errs[i]
+		}
+	}
+
+	return nil
+}
+
+// Any calls the provided functions in order until one of them succeeds, as a chain of fallbacks.
+//
+// Any returns nil as soon as a function returns nil; the remaining functions are not called.
+// If all functions fail, Any returns a Fail whose causes are the errors of all attempts, in order,
+// labeled "attempt-1", "attempt-2" and so on (see CauseLabels). If fns is empty, Any returns nil.
+//
+// Example:
+//
+//	err := fail.Any(
+//		func() error { return loadFromCache(key) },
+//		func() error { return loadFromReplica(key) },
+//		func() error { return loadFromPrimary(key) },
+//	)
+func Any(fns ...func() error) error {
+	if len(fns) == 0 {
+		return nil
+	}
+
+	b := New()
+	for i, fn := range fns {
+		err := fn()
+		if err == nil {
+			return nil
+		}
+
+		b = b.CauseLabeled("attempt-"+strconv.Itoa(i+1), err)
+	}
+
+	return b.Msgf("all %d attempts failed", len(fns))
+}
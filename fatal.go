@@ -1,7 +1,8 @@
 package fail
 
 // Fatal prints the provided error to standard output and exits the program with a non-zero exit code.
-// If the error is nil, it does nothing.
+// If the error is nil, it does nothing. The error is printed using PrintPretty, so the printer options
+// set for its domain using SetDomainPrinterOptions apply.
 //
 // Example:
 //
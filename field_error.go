@@ -0,0 +1,91 @@
+package fail
+
+// FieldError describes a validation failure of a single field of a form or request.
+//
+// Field is the name or path of the invalid field, such as "email" or "items[2].quantity", Code is an
+// optional machine-readable reason, such as "required" or "too_long", and Msg is a human-readable
+// description of the failure.
+type FieldError struct {
+	Field string `json:"field"`
+	Code  string `json:"code,omitempty"`
+	Msg   string `json:"msg"`
+}
+
+// String returns the field error in the form "field: msg".
+func (e FieldError) String() string {
+	if e.Field == "" {
+		return e.Msg
+	}
+
+	return e.Field + ": " + e.Msg
+}
+
+// ErrorFields is an error type that provides field-level details of a validation failure.
+//
+// Implementations of this interface should return one FieldError per invalid field, in the
+// order in which they were detected. The returned slice may be empty if no field-level details
+// are available, and should be a copy that callers may modify.
+//
+// Example usage:
+//
+//	type FormError struct{ fields []fail.FieldError }
+//	func (e *FormError) Error() string { return "invalid form" }
+//	func (e *FormError) ErrorFields() []fail.FieldError { return slices.Clone(e.fields) }
+//
+//	err := &FormError{fields: []fail.FieldError{{Field: "email", Msg: "must not be empty"}}}
+//	fields := fail.Fields(err) // returns the field errors
+type ErrorFields interface {
+	error
+
+	// ErrorFields returns the field-level details of the validation failure.
+	ErrorFields() []FieldError
+}
+
+// Fields returns the field-level details of the validation failure described by the provided error, if any.
+//
+// This function attempts to extract the field errors from the error as follows:
+//  1. If err is nil, it returns nil.
+//  2. If err implements ErrorFields, it returns the result of ErrorFields().
+//  3. Otherwise, it returns nil.
+func Fields(err error) []FieldError {
+	if err == nil {
+		return nil
+	}
+
+	if f, ok := err.(ErrorFields); ok {
+		return f.ErrorFields()
+	}
+
+	return nil
+}
+
+// WithFields returns a new error with the specified field errors attached.
+//
+// This function takes an existing error and field errors, and returns a new error that includes
+// the provided field errors in addition to those the error already has. If the provided error is
+// nil, it returns nil. If no field errors are provided, the original error is returned unchanged.
+//
+// The returned error will implement the ErrorFields interface, and the field errors can be
+// retrieved using the fail.Fields function.
+//
+// Example:
+//
+//	err := fail.WithFields(primaryErr, fail.FieldError{Field: "email", Code: "required", Msg: "must not be empty"})
+//
+// Parameters:
+//   - err: The original error to which the field errors will be attached.
+//   - fields: The field errors to attach.
+//
+// Returns:
+//   - A new error with the field errors attached, or nil if err is nil. If no field errors are provided, returns the original error.
+func WithFields(err error, fields ...FieldError) error {
+	if err == nil {
+		return nil
+	}
+
+	if len(fields) == 0 {
+		return err
+	}
+
+	return From(err).FieldErrors(fields...).asFail()
+}
@@ -0,0 +1,37 @@
+package fail
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+)
+
+// FingerprintAttribute is the key under which the fingerprint of an error is recorded by the NDJSON writer.
+const FingerprintAttribute = "fingerprint"
+
+// Fingerprint returns a short, stable identifier of the kind of the provided error.
+//
+// Errors with the same domain, code, operation and message have the same fingerprint, which makes it
+// suitable for grouping and deduplicating occurrences of the same error in logs and reports. Other
+// metadata, such as timestamps, trace IDs and attributes, is ignored, as it differs between occurrences.
+// The fingerprint is the hex encoding of the first 8 bytes of a SHA-256 hash.
+// If err is nil, Fingerprint returns the empty string.
+//
+// Example:
+//
+//	seen := map[string]int{}
+//	for _, err := range errs {
+//		seen[fail.Fingerprint(err)]++
+//	}
+func Fingerprint(err error) string {
+	if err == nil {
+		return ""
+	}
+
+	h := sha256.New()
+	for _, part := range []string{Domain(err), Code(err), Op(err), Message(err)} {
+		h.Write([]byte(part))
+		h.Write([]byte{0})
+	}
+
+	return hex.EncodeToString(h.Sum(nil)[:8])
+}
@@ -0,0 +1,141 @@
+package fail
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"time"
+)
+
+// jsonFail mirrors the JSON document produced by the JSON printer.
+type jsonFail struct {
+	Msg            string            `json:"msg"`
+	UserMsg        string            `json:"user_msg"`
+	Op             string            `json:"op"`
+	HelpURL        string            `json:"help_url"`
+	Fields         []FieldError      `json:"fields"`
+	Code           string            `json:"code"`
+	Domain         string            `json:"domain"`
+	ExitCode       int               `json:"exit_code"`
+	HttpStatusCode int               `json:"http_status_code"`
+	Time           string            `json:"time"`
+	Duration       string            `json:"duration"`
+	Tags           []string          `json:"tags"`
+	Attributes     map[string]any    `json:"attributes"`
+	TraceId        string            `json:"trace_id"`
+	SpanId         string            `json:"span_id"`
+	CorrelationId  string            `json:"correlation_id"`
+	Id             string            `json:"error_id"`
+	Source         SourceLocation    `json:"source"`
+	Retryable      bool              `json:"retryable"`
+	Transience     string            `json:"transience"`
+	RetryAfter     string            `json:"retry_after"`
+	Causes         []json.RawMessage `json:"causes"`
+	CauseLabels    []string          `json:"cause_labels"`
+	Associated     []json.RawMessage `json:"associated"`
+	AssocRoles     []string          `json:"associated_roles"`
+}
+
+// FromJson parses a JSON document produced by the JSON printer back into a Fail.
+//
+// All fields written by JsonPrinter are restored, including nested causes and associated errors.
+// Nested errors that are plain JSON strings are restored as message-only errors.
+// Nested attribute objects are restored as grouped attributes, see Builder.AttributeGroup.
+// Unknown fields are ignored, fields with an unexpected type are skipped, and missing fields keep
+// their default values, so documents produced by other versions of this package, whatever their
+// SchemaVersion, can still be parsed. Only malformed JSON is reported as an error.
+// Nesting beyond MaxDepth is dropped.
+//
+// Example:
+//
+//	f, err := fail.FromJson([]byte(`{"msg":"user not found","code":"ERR_NOT_FOUND"}`))
+func FromJson(data []byte) (Fail, error) {
+	return fromJson(data, 0)
+}
+
+// fromJson implements FromJson, tracking the nesting depth of causes and associated errors.
+func fromJson(data []byte, depth int) (Fail, error) {
+	data = bytes.TrimSpace(data)
+
+	// Nested errors that are not Fail documents may be encoded as plain strings.
+	if len(data) > 0 && data[0] == '"' {
+		var msg string
+		if err := json.Unmarshal(data, &msg); err != nil {
+			return Fail{}, err
+		}
+
+		b := New()
+		b.msg = msg
+		return b.asFail(), nil
+	}
+
+	// json.Unmarshal decodes all other fields before reporting a field with an unexpected type.
+	var j jsonFail
+	var typeErr *json.UnmarshalTypeError
+	if err := json.Unmarshal(data, &j); err != nil && !errors.As(err, &typeErr) {
+		return Fail{}, err
+	}
+
+	b := New().
+		UserMsg(j.UserMsg).
+		Op(j.Op).
+		HelpURL(j.HelpURL).
+		FieldErrors(j.Fields...).
+		Code(j.Code).
+		Domain(j.Domain).
+		ExitCode(j.ExitCode).
+		HttpStatusCode(j.HttpStatusCode).
+		TagSlice(j.Tags).
+		AttributeMap(flattenAttributes(j.Attributes)).
+		TraceId(j.TraceId).
+		SpanId(j.SpanId).
+		CorrelationId(j.CorrelationId).
+		Id(j.Id).
+		Retryable(j.Retryable)
+
+	b.msg = j.Msg
+	if b.msg == "" {
+		b.msg = EmptyMessage
+	}
+
+	b.source = j.Source
+
+	var transience TransienceKind
+	if err := transience.UnmarshalText([]byte(j.Transience)); err == nil && transience != TransienceUnknown {
+		b = b.Transience(transience)
+	}
+
+	if t, err := time.Parse(time.RFC3339Nano, j.Time); err == nil {
+		b = b.Time(t)
+	}
+
+	if d, err := time.ParseDuration(j.Duration); err == nil {
+		b = b.Duration(d)
+	}
+
+	if d, err := time.ParseDuration(j.RetryAfter); err == nil {
+		b = b.RetryAfter(d)
+	}
+
+	if depth < MaxDepth() {
+		labels := labelList(j.CauseLabels)
+		for i, raw := range limitWidth(j.Causes) {
+			cause, err := fromJson(raw, depth+1)
+			if err != nil {
+				return Fail{}, err
+			}
+			b = b.CauseLabeled(labels.at(i), cause)
+		}
+
+		roles := labelList(j.AssocRoles)
+		for i, raw := range limitWidth(j.Associated) {
+			associated, err := fromJson(raw, depth+1)
+			if err != nil {
+				return Fail{}, err
+			}
+			b = b.AssociateRole(roles.at(i), associated)
+		}
+	}
+
+	return b.asFail(), nil
+}
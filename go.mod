@@ -4,10 +4,8 @@ go 1.24.5
 
 require (
 	github.com/FlowSeer/wz v0.0.3
+	go.opentelemetry.io/otel v1.38.0
 	go.opentelemetry.io/otel/trace v1.38.0
 )
 
-require (
-	go.opentelemetry.io/otel v1.38.0 // indirect
-	golang.org/x/exp v0.0.0-20250819193227-8b4c13bb791b // indirect
-)
+require golang.org/x/exp v0.0.0-20250819193227-8b4c13bb791b // indirect
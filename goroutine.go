@@ -0,0 +1,63 @@
+package fail
+
+import "context"
+
+// GoC runs fn in a new goroutine and returns a channel receiving its error once it returns.
+//
+// fn receives ctx, so that it inherits its cancellation and the request-scoped values stored by this
+// package, such as tags, attributes, the domain and the scope. The error returned by fn is enriched with
+// these values (see WithContext), so that errors created without the context still carry them. A panic
+// in fn is recovered and reported as an error created by FromPanic, enriched with the same values.
+//
+// The returned channel receives exactly one value, nil if fn succeeded, and is then closed. It is
+// buffered, so the goroutine does not leak if the result is never received.
+//
+// Example:
+//
+//	ctx = fail.ContextWithScope(ctx, "thumbnail")
+//	done := fail.GoC(ctx, func(ctx context.Context) error {
+//		return renderThumbnail(ctx, img)
+//	})
+//	...
+//	if err := <-done; err != nil {
+//		logger.Error("background task failed", "error", err)
+//	}
+func GoC(ctx context.Context, fn func(ctx context.Context) error) <-chan error {
+	done := make(chan error, 1)
+	GoCFunc(ctx, fn, func(err error) {
+		done <- err
+		close(done)
+	})
+
+	return done
+}
+
+// GoCFunc runs fn in a new goroutine like GoC, and calls callback with its error once it returns.
+//
+// The callback is called from the new goroutine, with nil if fn succeeded. If callback is nil,
+// the error is discarded.
+//
+// Example:
+//
+//	fail.GoCFunc(ctx, syncInventory, func(err error) {
+//		fail.LogIfError(logger, err, "inventory sync failed")
+//	})
+func GoCFunc(ctx context.Context, fn func(ctx context.Context) error, callback func(err error)) {
+	go func() {
+		err := runC(ctx, fn)
+		if callback != nil {
+			callback(err)
+		}
+	}()
+}
+
+// runC calls fn with ctx, recovering panics, and enriches the resulting error with the values of ctx.
+func runC(ctx context.Context, fn func(ctx context.Context) error) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = WithContext(FromPanic(r), ctx)
+		}
+	}()
+
+	return WithContext(fn(ctx), ctx)
+}
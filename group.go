@@ -0,0 +1,155 @@
+package fail
+
+import (
+	"cmp"
+	"encoding/json"
+	"fmt"
+	"io"
+	"slices"
+	"strings"
+	"time"
+)
+
+// KeyFunc returns the key under which GroupBy groups an error.
+type KeyFunc func(err error) string
+
+// ByCode is a KeyFunc grouping errors by their error code, see Code.
+func ByCode(err error) string {
+	return Code(err)
+}
+
+// ByDomain is a KeyFunc grouping errors by their domain, see Domain.
+func ByDomain(err error) string {
+	return Domain(err)
+}
+
+// ByFingerprint is a KeyFunc grouping errors by their fingerprint, see Fingerprint.
+//
+// Errors with the same domain, code, operation and message end up in the same group.
+func ByFingerprint(err error) string {
+	return Fingerprint(err)
+}
+
+// Groups maps the keys returned by a KeyFunc to the errors with that key, as returned by GroupBy.
+//
+// Groups can be rendered as a grouped report using String or WriteText, or as JSON using WriteJson.
+type Groups map[string][]error
+
+// GroupBy groups the provided errors by the key returned by keyFn.
+//
+// Within a group, errors keep their order in errs. Nil errors are ignored. Use ByCode, ByDomain or
+// ByFingerprint as keyFn, or any other function computing a key from an error.
+//
+// Example:
+//
+//	groups := fail.GroupBy(errs, fail.ByCode)
+//	for code, errs := range groups {
+//		fmt.Printf("%s: %d errors\n", code, len(errs))
+//	}
+func GroupBy(errs []error, keyFn KeyFunc) Groups {
+	groups := make(Groups)
+	for _, err := range errs {
+		if err == nil {
+			continue
+		}
+
+		key := keyFn(err)
+		groups[key] = append(groups[key], err)
+	}
+
+	return groups
+}
+
+// Keys returns the keys of the groups, largest group first, and groups of equal size sorted by key.
+func (g Groups) Keys() []string {
+	keys := make([]string, 0, len(g))
+	for k := range g {
+		keys = append(keys, k)
+	}
+
+	slices.SortFunc(keys, func(a, b string) int {
+		if c := cmp.Compare(len(g[b]), len(g[a])); c != 0 {
+			return c
+		}
+		return strings.Compare(a, b)
+	})
+
+	return keys
+}
+
+// Summarize returns a Summary of every group, see fail.Summarize.
+func (g Groups) Summarize() map[string]Summary {
+	summaries := make(map[string]Summary, len(g))
+	for k, errs := range g {
+		summaries[k] = Summarize(errs)
+	}
+
+	return summaries
+}
+
+// String returns a human-readable grouped report of the errors.
+//
+// Groups are listed largest first, each with the number of errors, the range of their timestamps,
+// and their most frequent messages. Errors with an empty key are listed under "(none)".
+//
+// Example output:
+//
+//	ERR_TIMEOUT: 15 errors between 2024-01-02T03:04:05Z and 2024-01-02T03:09:41Z
+//	  12x query timed out
+//	  3x dial timed out
+//	ERR_CONNECTION: 2 errors between 2024-01-02T03:05:00Z and 2024-01-02T03:06:10Z
+//	  2x connection refused
+func (g Groups) String() string {
+	var sb strings.Builder
+	_ = g.WriteText(&sb)
+	return sb.String()
+}
+
+// WriteText writes a human-readable grouped report of the errors to w. See Groups.String for the format.
+//
+// It returns the first error encountered while writing.
+func (g Groups) WriteText(w io.Writer) error {
+	pw := &printWriter{w: w}
+
+	if len(g) == 0 {
+		pw.WriteString("no errors occurred")
+		return pw.err
+	}
+
+	for i, key := range g.Keys() {
+		s := Summarize(g[key])
+
+		if i > 0 {
+			pw.WriteString("\n")
+		}
+
+		if key == "" {
+			key = "(none)"
+		}
+
+		if s.Total == 1 {
+			pw.WriteString(key + ": 1 error")
+		} else {
+			pw.WriteString(fmt.Sprintf("%s: %d errors", key, s.Total))
+		}
+
+		if !s.Earliest.IsZero() {
+			pw.WriteString(" between " + s.Earliest.Format(time.RFC3339) + " and " + s.Latest.Format(time.RFC3339))
+		}
+
+		for _, m := range s.Messages {
+			pw.WriteString(fmt.Sprintf("\n  %dx %s", m.Count, m.Message))
+		}
+	}
+
+	return pw.err
+}
+
+// WriteJson writes an indented JSON object mapping every key to the Summary of its group to w.
+//
+// It returns the first error encountered while encoding or writing.
+func (g Groups) WriteJson(w io.Writer) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(g.Summarize())
+}
@@ -0,0 +1,73 @@
+package fail
+
+// ErrorHelpURL is an error type that provides a link to documentation about the error.
+//
+// Implementations of this interface should return an absolute URL pointing to a runbook,
+// documentation page or support article that helps users or operators resolve the error.
+// The returned string may be empty if no such page exists.
+//
+// Example usage:
+//
+//	type MyError struct{}
+//	func (e *MyError) Error() string { return "quota exceeded" }
+//	func (e *MyError) ErrorHelpURL() string { return "https://docs.example.com/errors/quota" }
+//
+//	err := &MyError{}
+//	url := fail.HelpURL(err) // returns "https://docs.example.com/errors/quota"
+type ErrorHelpURL interface {
+	error
+
+	// ErrorHelpURL returns a link to documentation about the error.
+	//
+	// The returned string may be empty if no documentation is available.
+	ErrorHelpURL() string
+}
+
+// HelpURL returns the documentation link associated with the provided error, if any.
+//
+// This function attempts to extract the link from the error as follows:
+//  1. If err is nil, it returns an empty string.
+//  2. If err implements ErrorHelpURL, it returns the result of ErrorHelpURL().
+//  3. Otherwise, it returns an empty string.
+func HelpURL(err error) string {
+	if err == nil {
+		return ""
+	}
+
+	if h, ok := err.(ErrorHelpURL); ok {
+		return h.ErrorHelpURL()
+	}
+
+	return ""
+}
+
+// WithHelpURL returns a new error with the specified documentation link attached.
+//
+// This function takes an existing error and a URL, and returns a new error that includes
+// the provided link. If the provided error is nil, it returns nil.
+// If the URL is empty, the original error is returned unchanged.
+//
+// The returned error will implement the ErrorHelpURL interface, and the link can be
+// retrieved using the fail.HelpURL function.
+//
+// Example:
+//
+//	err := fail.WithHelpURL(primaryErr, "https://docs.example.com/errors/quota")
+//
+// Parameters:
+//   - err: The original error to which the link will be attached.
+//   - url: The documentation link.
+//
+// Returns:
+//   - A new error with the link attached, or nil if err is nil. If url is empty, returns the original error.
+func WithHelpURL(err error, url string) error {
+	if err == nil {
+		return nil
+	}
+
+	if url == "" {
+		return err
+	}
+
+	return From(err).HelpURL(url).asFail()
+}
@@ -0,0 +1,70 @@
+package fail
+
+import (
+	"slices"
+	"sync"
+	"sync/atomic"
+)
+
+// hooks holds the FailOptions applied to every error built by Builder.Msg, or nil if there are none.
+var hooks atomic.Pointer[[]FailOption]
+
+// hooksMu serializes modifications of hooks.
+var hooksMu sync.Mutex
+
+// AddHook registers a hook applied to every error when it is built by Builder.Msg or Builder.Msgf.
+//
+// Hooks are applied in registration order, after the registered defaults of the error code and
+// before the size limits (see SetMaxAttributeSize). They receive the Builder with the message set
+// and can use any Builder method, for example to add attributes to every error of the process.
+// Hooks must be safe for concurrent use and must not build errors themselves, as those would be
+// passed to the hooks again. Hooks are skipped for errors that are not sampled by the Sampler set
+// using SetSampler. It is safe to call AddHook concurrently. Nil hooks are ignored.
+//
+// Example:
+//
+//	fail.AddHook(func(b fail.Builder) fail.Builder {
+//		return b.Attribute("region", region)
+//	})
+func AddHook(hook FailOption) {
+	if hook == nil {
+		return
+	}
+
+	hooksMu.Lock()
+	defer hooksMu.Unlock()
+
+	var next []FailOption
+	if current := hooks.Load(); current != nil {
+		next = slices.Clone(*current)
+	}
+	next = append(next, hook)
+
+	hooks.Store(&next)
+}
+
+// ResetHooks removes all hooks registered using AddHook.
+//
+// It is safe to call ResetHooks concurrently.
+func ResetHooks() {
+	hooksMu.Lock()
+	defer hooksMu.Unlock()
+
+	hooks.Store(nil)
+}
+
+// applyHooks adds the global attributes to the builder and applies the registered hooks, if sampled.
+func (b Builder) applyHooks(sampled bool) Builder {
+	b = b.applyGlobalAttributes()
+
+	current := hooks.Load()
+	if current == nil || !sampled {
+		return b
+	}
+
+	for _, hook := range *current {
+		b = hook(b)
+	}
+
+	return b
+}
@@ -1,8 +1,46 @@
 package fail
 
+import (
+	"context"
+	"sync/atomic"
+)
+
 // DefaultHttpStatusCode is the default HTTP status code to use when no specific status code is set.
 const DefaultHttpStatusCode = 500
 
+// relaxedHttpStatusCodes controls whether HTTP status codes in the 200-399 range are accepted.
+var relaxedHttpStatusCodes atomic.Bool
+
+// SetRelaxedHttpStatusCodes enables or disables accepting HTTP status codes in the 200-399 range.
+//
+// By default, Builder.HttpStatusCode and WithHttpStatusCode only accept status codes in the 400-599
+// range. Some flows use errors for non-error statuses, such as redirects reported as errors (3xx)
+// or partial failures reported as 207 Multi-Status. When relaxed, status codes in the 200-599 range
+// are accepted. Errors created by PartialSuccess carry 207 regardless of this setting.
+// It is safe to call SetRelaxedHttpStatusCodes concurrently.
+//
+// Example:
+//
+//	fail.SetRelaxedHttpStatusCodes(true)
+//	err := fail.New().HttpStatusCode(http.StatusFound).Attribute("location", url).Msg("moved")
+func SetRelaxedHttpStatusCodes(relaxed bool) {
+	relaxedHttpStatusCodes.Store(relaxed)
+}
+
+// RelaxedHttpStatusCodes reports whether HTTP status codes in the 200-399 range are accepted.
+func RelaxedHttpStatusCodes() bool {
+	return relaxedHttpStatusCodes.Load()
+}
+
+// validHttpStatusCode reports whether httpStatusCode may be set on an error.
+func validHttpStatusCode(httpStatusCode int) bool {
+	if RelaxedHttpStatusCodes() {
+		return httpStatusCode >= 200 && httpStatusCode < 600
+	}
+
+	return httpStatusCode >= 400 && httpStatusCode < 600
+}
+
 // ErrorHttpStatusCode is an error type that provides an associated HTTP status code.
 //
 // Implementations of this interface should return a valid HTTP status code (such as 404, 500)
@@ -31,10 +69,17 @@ type ErrorHttpStatusCode interface {
 //
 // This function determines the HTTP status code as follows:
 //  1. If err is nil, it returns 200 (success).
-//  2. If err implements ErrorHttpStatusCode, it returns the result of ErrorHttpStatusCode().
-//  3. Otherwise, it recursively examines the direct causes of err (using Causes(err)).
-//     If any cause implements ErrorHttpStatusCode, it returns the maximum status code found among them.
-//  4. If no status code is found, it returns DefaultHttpStatusCode.
+//  2. If err implements ErrorHttpStatusCode and its status code was set explicitly, it returns that status code.
+//  3. If err does not implement ErrorHttpStatusCode, it examines the direct causes of err (using Causes(err)).
+//     If any cause has an explicitly set status code of DefaultHttpStatusCode or above, it returns the
+//     maximum status code found among them.
+//  4. Otherwise, it returns the status code mapped to the error code or domain of err (see
+//     SetHttpStatusCodeForCode and SetHttpStatusCodeForDomain), or the default status code set using
+//     SetDefaults (DefaultHttpStatusCode unless changed) if neither is mapped.
+//
+// The status code of a Fail counts as set explicitly once it has been set using Builder.HttpStatusCode
+// or WithHttpStatusCode, even if it equals DefaultHttpStatusCode. For other implementations of
+// ErrorHttpStatusCode, any status code other than DefaultHttpStatusCode counts as set explicitly.
 //
 // This allows error types to specify custom HTTP status codes, and for composed/multi-cause errors
 // to propagate the most severe status code.
@@ -43,27 +88,54 @@ func HttpStatusCode(err error) int {
 		return 200
 	}
 
-	if httpStatusCode, ok := err.(ErrorHttpStatusCode); ok {
-		return httpStatusCode.ErrorHttpStatusCode()
+	if status, ok := explicitHttpStatusCode(err); ok {
+		return status
 	}
 
-	maxHttpStatusCode := DefaultHttpStatusCode
-	for _, cause := range Causes(err) {
-		if httpStatusCode, ok := cause.(ErrorHttpStatusCode); ok {
-			if httpStatusCode.ErrorHttpStatusCode() > maxHttpStatusCode {
-				maxHttpStatusCode = httpStatusCode.ErrorHttpStatusCode()
-			}
+	return mappedHttpStatusCode(err)
+}
+
+// explicitHttpStatusCode returns the explicitly set HTTP status code of err, or the maximum one of its direct causes.
+func explicitHttpStatusCode(err error) (int, bool) {
+	if _, ok := err.(ErrorHttpStatusCode); ok {
+		return ownHttpStatusCode(err)
+	}
+
+	maxHttpStatusCode, found := DefaultHttpStatusCode, false
+	for _, cause := range limitWidth(Causes(err)) {
+		if status, ok := ownHttpStatusCode(cause); ok && status >= maxHttpStatusCode {
+			maxHttpStatusCode, found = status, true
 		}
 	}
 
-	return maxHttpStatusCode
+	return maxHttpStatusCode, found
+}
+
+// ownHttpStatusCode returns the HTTP status code of err itself and whether it was set explicitly.
+func ownHttpStatusCode(err error) (int, bool) {
+	switch err := err.(type) {
+	case Fail:
+		return err.httpStatusCode, err.httpStatusCodeSet
+	case *Fail:
+		if err == nil {
+			return 0, false
+		}
+
+		return err.httpStatusCode, err.httpStatusCodeSet
+	case ErrorHttpStatusCode:
+		status := err.ErrorHttpStatusCode()
+		return status, status != DefaultHttpStatusCode
+	}
+
+	return 0, false
 }
 
 // WithHttpStatusCode returns a new error with the specified HTTP status code attached.
 //
 // This function takes an existing error and an integer HTTP status code, and returns a new error
 // that includes the provided status code. If the provided error is nil, it returns nil.
-// If the HTTP status code is less than 400, the original error is returned unchanged.
+// If the HTTP status code is not in the 400-599 range, or the 200-599 range if relaxed
+// (see SetRelaxedHttpStatusCodes), the original error is returned unchanged.
 //
 // The returned error will implement the ErrorHttpStatusCode interface, and the status code can be
 // retrieved using the fail.HttpStatusCode function.
@@ -80,15 +152,47 @@ func HttpStatusCode(err error) int {
 //   - httpStatusCode: The integer HTTP status code to associate with the error.
 //
 // Returns:
-//   - A new error with the HTTP status code attached, or nil if err is nil. If httpStatusCode < 400, returns the original error.
+//   - A new error with the HTTP status code attached, or nil if err is nil. If httpStatusCode is not accepted, returns the original error.
 func WithHttpStatusCode(err error, httpStatusCode int) error {
 	if err == nil {
 		return nil
 	}
 
-	if httpStatusCode < 400 {
+	if !validHttpStatusCode(httpStatusCode) {
 		return err
 	}
 
 	return From(err).HttpStatusCode(httpStatusCode).asFail()
 }
+
+// httpStatusCodeContextKey is an unexported type used as the key for storing
+// and retrieving the default HTTP status code in a context.Context.
+type httpStatusCodeContextKey struct{}
+
+// ContextWithHttpStatusCode returns a new context.Context that carries the provided default HTTP status code.
+// If a status code is already set in the context, it is overwritten with the new value.
+//
+// Errors built using NewC, MsgC, WrapC or Builder.Context use the status code from the context
+// unless a status code is set explicitly on the Builder.
+//
+// Example usage:
+//
+//	ctx := ContextWithHttpStatusCode(context.Background(), 502)
+func ContextWithHttpStatusCode(ctx context.Context, httpStatusCode int) context.Context {
+	return context.WithValue(ctx, httpStatusCodeContextKey{}, httpStatusCode)
+}
+
+// HttpStatusCodeFromContext extracts the default HTTP status code from the provided context.
+// If no status code is set in the context, HttpStatusCodeFromContext returns 0.
+//
+// Example usage:
+//
+//	status := HttpStatusCodeFromContext(ctx)
+func HttpStatusCodeFromContext(ctx context.Context) int {
+	httpStatusCode, ok := ctx.Value(httpStatusCodeContextKey{}).(int)
+	if !ok {
+		return 0
+	}
+
+	return httpStatusCode
+}
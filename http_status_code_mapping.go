@@ -0,0 +1,89 @@
+package fail
+
+// defaultHttpStatusCodeMappings returns the HTTP status code mappings in effect unless changed.
+func defaultHttpStatusCodeMappings() *codeMappingTable {
+	return &codeMappingTable{
+		codes: map[string]int{
+			ErrCodeValidation:         400,
+			ErrCodeInvalidInput:       400,
+			ErrCodeMissingRequired:    400,
+			ErrCodeInvalidFormat:      400,
+			ErrCodeOutOfRange:         400,
+			ErrCodeUnauthorized:       401,
+			ErrCodeForbidden:          403,
+			ErrCodeAuthentication:     401,
+			ErrCodeTokenExpired:       401,
+			ErrCodeInvalidToken:       401,
+			ErrCodeNotFound:           404,
+			ErrCodeAlreadyExists:      409,
+			ErrCodeConflict:           409,
+			ErrCodeResourceGone:       410,
+			ErrCodeNetwork:            502,
+			ErrCodeTimeout:            504,
+			ErrCodeConnection:         502,
+			ErrCodeUnreachable:        502,
+			ErrCodeServiceUnavailable: 503,
+			ErrCodeBusinessRule:       422,
+			ErrCodeQuotaExceeded:      429,
+			ErrCodeRateLimited:        429,
+			ErrCodeMaintenance:        503,
+		},
+		domains: map[string]int{
+			DomainValidation: 400,
+			DomainAuth:       401,
+			DomainRateLimit:  429,
+			DomainTimeout:    504,
+			DomainNetwork:    502,
+			DomainDependency: 502,
+		},
+	}
+}
+
+// httpStatusCodes holds the HTTP status code mappings consulted by HttpStatusCode.
+var httpStatusCodes = newCodeMapping(defaultHttpStatusCodeMappings)
+
+// SetHttpStatusCodeForCode sets the HTTP status code returned by HttpStatusCode for errors with the
+// provided error code and no explicit HTTP status code.
+//
+// By default, the predefined error codes are mapped to their conventional status codes, for example
+// ErrCodeNotFound to 404, ErrCodeUnauthorized to 401, ErrCodeRateLimited to 429 and ErrCodeTimeout
+// to 504. Passing a status code less than or equal to zero removes the mapping of the code.
+// It is safe to call SetHttpStatusCodeForCode concurrently.
+//
+// Example:
+//
+//	fail.SetHttpStatusCodeForCode("ERR_PAYMENT_REQUIRED", 402)
+func SetHttpStatusCodeForCode(code string, httpStatusCode int) {
+	httpStatusCodes.setCode(code, httpStatusCode)
+}
+
+// SetHttpStatusCodeForDomain sets the HTTP status code returned by HttpStatusCode for errors with the
+// provided domain and neither an explicit HTTP status code nor a mapped error code.
+//
+// By default, DomainValidation is mapped to 400, DomainAuth to 401, DomainRateLimit to 429,
+// DomainTimeout to 504, and DomainNetwork and DomainDependency to 502. Passing a status code less
+// than or equal to zero removes the mapping of the domain. It is safe to call SetHttpStatusCodeForDomain
+// concurrently.
+//
+// Example:
+//
+//	fail.SetHttpStatusCodeForDomain("billing", 402)
+func SetHttpStatusCodeForDomain(domain string, httpStatusCode int) {
+	httpStatusCodes.setDomain(domain, httpStatusCode)
+}
+
+// ResetHttpStatusCodes restores the default mappings of error codes and domains to HTTP status codes.
+//
+// It is safe to call ResetHttpStatusCodes concurrently.
+func ResetHttpStatusCodes() {
+	httpStatusCodes.reset()
+}
+
+// mappedHttpStatusCode returns the HTTP status code mapped to the error code or domain of err, or the default HTTP status code (see SetDefaults).
+func mappedHttpStatusCode(err error) int {
+	if httpStatusCode, ok := httpStatusCodes.lookup(err); ok {
+		return httpStatusCode
+	}
+
+	return CurrentDefaults().HttpStatusCode
+}
@@ -0,0 +1,149 @@
+package fail
+
+import (
+	"crypto/rand"
+	"encoding/binary"
+	"encoding/hex"
+	"time"
+)
+
+// ErrorId is an error type that provides the unique ID of an error instance.
+//
+// The ID identifies a specific occurrence of an error, rather than its kind, so that it can be
+// referenced in support tickets ("error ID 0192f0c4-...") and correlated across log lines and the
+// messages shown to users. Every Fail is assigned a new ID when it is built, unless one is set using
+// Builder.Id. The returned string may be empty if no ID is known.
+//
+// Example usage:
+//
+//	type MyError struct{ id string }
+//	func (e *MyError) Error() string { return "request failed" }
+//	func (e *MyError) ErrorId() string { return e.id }
+//
+//	err := &MyError{id: "0192f0c4-5d2e-7c4b-9a51-6f0e8b7d3a21"}
+//	id := fail.Id(err) // returns "0192f0c4-5d2e-7c4b-9a51-6f0e8b7d3a21"
+type ErrorId interface {
+	error
+
+	// ErrorId returns the unique ID of this error instance.
+	//
+	// The returned string may be empty if no ID is set.
+	ErrorId() string
+}
+
+// Id returns the unique ID of the error instance described by the provided error, if any.
+//
+// This function attempts to extract the ID from the error as follows:
+//  1. If err is nil, it returns an empty string.
+//  2. If err implements ErrorId and the result of ErrorId() is not empty, it returns the result.
+//  3. Otherwise, it recursively examines the causes of err (using Causes(err)) and returns the
+//     first non-empty ID found, so that errors wrapping a Fail report its ID.
+//
+// The recursion is bounded by MaxDepth and MaxWidth, and cycles in the cause graph are ignored.
+func Id(err error) string {
+	return errorId(err, 0, newVisitSet())
+}
+
+// errorId implements Id, tracking the current depth and the visited errors to guard against cycles.
+func errorId(err error, depth int, visited visitSet) string {
+	if err == nil {
+		return ""
+	}
+
+	if i, ok := err.(ErrorId); ok {
+		if id := i.ErrorId(); id != "" {
+			return id
+		}
+	}
+
+	if depth >= MaxDepth() || !visited.enter(err) {
+		return ""
+	}
+	defer visited.leave(err)
+
+	for _, cause := range limitWidth(Causes(err)) {
+		if id := errorId(cause, depth+1, visited); id != "" {
+			return id
+		}
+	}
+
+	return ""
+}
+
+// ownId returns the ID of err if it implements ErrorId, ignoring the IDs of its causes.
+func ownId(err error) string {
+	if i, ok := err.(ErrorId); ok {
+		return i.ErrorId()
+	}
+
+	return ""
+}
+
+// WithId returns a new error with the specified instance ID attached.
+//
+// This function takes an existing error and an ID, and returns a new error that includes the provided
+// ID instead of the ID the error already has. If the provided error is nil, it returns nil.
+// If the ID is empty, the original error is returned unchanged.
+//
+// The returned error will implement the ErrorId interface, and the ID can be retrieved using the
+// fail.Id function.
+//
+// Example:
+//
+//	err := fail.WithId(primaryErr, incidentId)
+//
+// Parameters:
+//   - err: The original error to which the ID will be attached.
+//   - id: The unique ID of the error instance.
+//
+// Returns:
+//   - A new error with the ID attached, or nil if err is nil. If id is empty, returns the original error.
+func WithId(err error, id string) error {
+	if err == nil {
+		return nil
+	}
+
+	if id == "" {
+		return err
+	}
+
+	return From(err).Id(id).asFail()
+}
+
+// NewId returns a new unique error instance ID, as assigned to every Fail when it is built.
+//
+// IDs are version 7 UUIDs (RFC 9562), which are ordered by the time they were generated, like ULIDs:
+// the first 48 bits hold the Unix time in milliseconds, and the remaining bits are random.
+// NewId is useful for giving other errors an ID using WithId.
+//
+// Example:
+//
+//	if fail.Id(err) == "" {
+//		err = fail.WithId(err, fail.NewId())
+//	}
+func NewId() string {
+	var id [16]byte
+	if _, err := rand.Read(id[6:]); err != nil {
+		return ""
+	}
+
+	var ms [8]byte
+	binary.BigEndian.PutUint64(ms[:], uint64(time.Now().UnixMilli()))
+	copy(id[:6], ms[2:])
+
+	id[6] = id[6]&0x0f | 0x70 // version 7
+	id[8] = id[8]&0x3f | 0x80 // RFC 9562 variant
+
+	var buf [36]byte
+	hex.Encode(buf[0:8], id[0:4])
+	buf[8] = '-'
+	hex.Encode(buf[9:13], id[4:6])
+	buf[13] = '-'
+	hex.Encode(buf[14:18], id[6:8])
+	buf[18] = '-'
+	hex.Encode(buf[19:23], id[8:10])
+	buf[23] = '-'
+	hex.Encode(buf[24:], id[10:])
+
+	return string(buf[:])
+}
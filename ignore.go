@@ -0,0 +1,60 @@
+package fail
+
+import "sync/atomic"
+
+// IgnoreHook is called by Ignore for every error it suppresses, with the Matcher that matched it.
+type IgnoreHook func(err error, matcher Matcher)
+
+// ignoreHook is the IgnoreHook called by Ignore, or nil if suppressions are not recorded.
+var ignoreHook atomic.Pointer[IgnoreHook]
+
+// SetIgnoreHook sets the hook called by Ignore for every suppressed error, for auditing suppressions.
+//
+// Passing nil removes the hook (the default). The hook is called synchronously by Ignore and must be
+// safe for concurrent use. It is safe to call SetIgnoreHook concurrently.
+//
+// Example:
+//
+//	fail.SetIgnoreHook(func(err error, m fail.Matcher) {
+//		logger.Debug("error suppressed", "error", err)
+//		suppressed.Inc()
+//	})
+func SetIgnoreHook(hook IgnoreHook) {
+	if hook == nil {
+		ignoreHook.Store(nil)
+		return
+	}
+
+	ignoreHook.Store(&hook)
+}
+
+// Ignore returns nil if err matches any of the provided matchers, and err otherwise.
+//
+// It is used to suppress errors that are benign in a given situation, such as context.Canceled when
+// a client disconnects, or sql.ErrNoRows in an optional lookup. Matchers are checked in order; the
+// first match is reported to the hook set using SetIgnoreHook, if any. Since the zero Matcher matches
+// every error, it must not be passed unless all errors are to be ignored.
+//
+// Example:
+//
+//	err := fail.Ignore(loadPreferences(ctx, userID),
+//		fail.Matcher{Is: sql.ErrNoRows},
+//		fail.Matcher{Is: context.Canceled},
+//	)
+func Ignore(err error, matchers ...Matcher) error {
+	if err == nil {
+		return nil
+	}
+
+	for _, m := range matchers {
+		if m.Match(err) {
+			if hook := ignoreHook.Load(); hook != nil {
+				(*hook)(err, m)
+			}
+
+			return nil
+		}
+	}
+
+	return err
+}
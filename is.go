@@ -0,0 +1,165 @@
+package fail
+
+import (
+	"context"
+	"reflect"
+	"slices"
+)
+
+// IsCanceled reports whether the provided error, or any error in its cause tree, represents a canceled operation.
+//
+// An error represents a canceled operation if errors.Is reports it as context.Canceled, or if it
+// carries TagCanceled, as set by Builder.Context for canceled contexts. This allows callers to
+// skip logging cancellations initiated by the user, such as closed client connections.
+// The search is bounded by MaxDepth and MaxWidth. If err is nil, IsCanceled returns false.
+//
+// Example:
+//
+//	if err != nil && !fail.IsCanceled(err) {
+//		logger.Error("request failed", "error", err)
+//	}
+func IsCanceled(err error) bool {
+	return search(err, func(err error) bool {
+		return isOwn(err, context.Canceled) || hasOwnTag(err, TagCanceled)
+	})
+}
+
+// IsTimeout reports whether the provided error, or any error in its cause tree, represents a timeout.
+//
+// An error represents a timeout if errors.Is reports it as context.DeadlineExceeded, if it
+// implements Timeout() bool (as net.Error and os.ErrDeadlineExceeded do) and reports true,
+// if its domain is DomainTimeout, if its code is ErrCodeTimeout, or if it carries TagTimeout.
+// The search is bounded by MaxDepth and MaxWidth. If err is nil, IsTimeout returns false.
+//
+// Example:
+//
+//	if fail.IsTimeout(err) {
+//		w.WriteHeader(http.StatusGatewayTimeout)
+//	}
+func IsTimeout(err error) bool {
+	return search(err, func(err error) bool {
+		if isOwn(err, context.DeadlineExceeded) {
+			return true
+		}
+
+		if t, ok := err.(interface{ Timeout() bool }); ok && t.Timeout() {
+			return true
+		}
+
+		if d, ok := err.(ErrorDomain); ok && d.ErrorDomain() == DomainTimeout {
+			return true
+		}
+
+		if c, ok := err.(ErrorCode); ok && c.ErrorCode() == ErrCodeTimeout {
+			return true
+		}
+
+		return hasOwnTag(err, TagTimeout)
+	})
+}
+
+// IsCode reports whether the provided error, or any error in its cause tree, has the given code.
+//
+// Unlike Code, which returns a single code chosen among the causes, IsCode checks the code of
+// every error in the tree, mirroring errors.Is. The search is bounded by MaxDepth and MaxWidth.
+// If err is nil, IsCode returns false.
+//
+// Example:
+//
+//	if fail.IsCode(err, fail.ErrCodeNotFound) {
+//		return nil
+//	}
+func IsCode(err error, code string) bool {
+	return search(err, func(err error) bool {
+		c, ok := err.(ErrorCode)
+		return ok && c.ErrorCode() == code
+	})
+}
+
+// IsDomain reports whether the provided error, or any error in its cause tree, belongs to the given domain.
+//
+// The search is bounded by MaxDepth and MaxWidth. If err is nil, IsDomain returns false.
+//
+// Example:
+//
+//	if fail.IsDomain(err, fail.DomainDatabase) {
+//		metrics.DatabaseErrors.Inc()
+//	}
+func IsDomain(err error, domain string) bool {
+	return search(err, func(err error) bool {
+		d, ok := err.(ErrorDomain)
+		return ok && d.ErrorDomain() == domain
+	})
+}
+
+// IsHTTPStatus reports whether the provided error, or any error in its cause tree, has the given HTTP status code.
+//
+// Unlike HttpStatusCode, which returns a single status code chosen among the causes, IsHTTPStatus
+// checks the status code of every error in the tree. The search is bounded by MaxDepth and MaxWidth.
+// If err is nil, IsHTTPStatus returns false.
+//
+// Example:
+//
+//	if fail.IsHTTPStatus(err, http.StatusTooManyRequests) {
+//		time.Sleep(fail.RetryAfter(err))
+//	}
+func IsHTTPStatus(err error, status int) bool {
+	return search(err, func(err error) bool {
+		h, ok := err.(ErrorHttpStatusCode)
+		return ok && h.ErrorHttpStatusCode() == status
+	})
+}
+
+// hasOwnTag reports whether err itself carries tag, without looking at its causes.
+func hasOwnTag(err error, tag string) bool {
+	t, ok := err.(ErrorTags)
+	return ok && slices.Contains(t.ErrorTags(), tag)
+}
+
+// isOwn reports whether err itself matches target as errors.Is does, without looking at its causes.
+//
+// It is used instead of errors.Is when the cause tree is searched anyway, so that the search remains
+// bounded by the limits of this package.
+func isOwn(err, target error) bool {
+	if target == nil {
+		return err == nil
+	}
+
+	if reflect.TypeOf(target).Comparable() && err == target {
+		return true
+	}
+
+	i, ok := err.(interface{ Is(error) bool })
+	return ok && i.Is(target)
+}
+
+// search reports whether match returns true for err or any error in its cause tree.
+//
+// The search is bounded by MaxDepth, MaxWidth and MaxNodes, and cycles in the cause graph are not followed.
+func search(err error, match func(error) bool) bool {
+	return searchDepth(err, match, 0, newVisitSet())
+}
+
+// searchDepth implements search, tracking the current depth and the visited errors to guard against cycles.
+func searchDepth(err error, match func(error) bool, depth int, visited visitSet) bool {
+	if err == nil {
+		return false
+	}
+
+	if match(err) {
+		return true
+	}
+
+	if depth >= MaxDepth() || !visited.enter(err) {
+		return false
+	}
+	defer visited.leave(err)
+
+	for _, cause := range limitWidth(Causes(err)) {
+		if searchDepth(cause, match, depth+1, visited) {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,162 @@
+package fail
+
+// ErrorHandled is an error type that reports whether it has been handled.
+//
+// An error is handled once a layer has dealt with it, for example by falling back to a default value or
+// by responding to the client, but still returns it so that callers can observe the failure.
+//
+// Example usage:
+//
+//	type MyError struct{}
+//	func (e *MyError) Error() string { return "cache miss" }
+//	func (e *MyError) ErrorHandled() bool { return true }
+//
+//	err := &MyError{}
+//	handled := fail.Handled(err) // returns true
+type ErrorHandled interface {
+	error
+
+	// ErrorHandled reports whether this error has been handled.
+	ErrorHandled() bool
+}
+
+// ErrorReported is an error type that reports whether it has been reported.
+//
+// An error is reported once it has been logged or sent to an error tracker, so that it does not need
+// to be reported again by the layers it is returned to.
+//
+// Example usage:
+//
+//	type MyError struct{}
+//	func (e *MyError) Error() string { return "payment declined" }
+//	func (e *MyError) ErrorReported() bool { return true }
+//
+//	err := &MyError{}
+//	reported := fail.Reported(err) // returns true
+type ErrorReported interface {
+	error
+
+	// ErrorReported reports whether this error has been reported.
+	ErrorReported() bool
+}
+
+// Handled reports whether the provided error, or any of its causes, has been marked as handled.
+//
+// This function determines whether the error is handled as follows:
+//  1. If err is nil, it returns false.
+//  2. If err implements ErrorHandled and ErrorHandled() returns true, it returns true.
+//  3. Otherwise, it recursively examines the causes of err (using Causes(err)) and returns true
+//     if any of them is handled.
+//
+// The recursion is bounded by MaxDepth and MaxWidth, and cycles in the cause graph are ignored.
+func Handled(err error) bool {
+	return marked(err, ownHandled, 0, newVisitSet())
+}
+
+// Reported reports whether the provided error, or any of its causes, has been marked as reported.
+//
+// This allows top-level middleware to avoid logging errors a second time that have already been
+// logged by a lower layer. The error is examined like in Handled.
+//
+// Example:
+//
+//	if err := next(ctx); err != nil && !fail.Reported(err) {
+//		fail.LogIfError(logger, err, "request failed")
+//	}
+func Reported(err error) bool {
+	return marked(err, ownReported, 0, newVisitSet())
+}
+
+// MarkHandled returns a copy of the provided error marked as handled.
+//
+// The returned error is a Fail reporting true from ErrorHandled, so fail.Handled returns true for it
+// and for any error wrapping it. All other information of the error is kept, and errors.Is and
+// errors.As still match the provided error and its causes. The marker is local to the process; it is
+// not included in the output of the printers and serializers.
+// If the provided error is nil, it returns nil.
+//
+// Example:
+//
+//	prices, err := loadPrices(ctx)
+//	if err != nil {
+//		prices = cachedPrices
+//		errs = append(errs, fail.MarkHandled(err))
+//	}
+//
+// Parameters:
+//   - err: The error to mark as handled.
+//
+// Returns:
+//   - A copy of err marked as handled, or nil if err is nil.
+func MarkHandled(err error) error {
+	if err == nil {
+		return nil
+	}
+
+	return fromOrigin(err).Handled().asFail()
+}
+
+// MarkReported returns a copy of the provided error marked as reported.
+//
+// The returned error is a Fail reporting true from ErrorReported, so fail.Reported returns true for it
+// and for any error wrapping it. All other information of the error is kept, and errors.Is and
+// errors.As still match the provided error and its causes. The marker is local to the process; it is
+// not included in the output of the printers and serializers.
+// If the provided error is nil, it returns nil.
+//
+// Example:
+//
+//	if err := charge(ctx, order); err != nil {
+//		fail.LogIfError(logger, err, "failed to charge card", "order", order.ID)
+//		return fail.MarkReported(err)
+//	}
+//
+// Parameters:
+//   - err: The error to mark as reported.
+//
+// Returns:
+//   - A copy of err marked as reported, or nil if err is nil.
+func MarkReported(err error) error {
+	if err == nil {
+		return nil
+	}
+
+	return fromOrigin(err).Reported().asFail()
+}
+
+// ownHandled reports whether err itself implements ErrorHandled and is handled, ignoring its causes.
+func ownHandled(err error) bool {
+	h, ok := err.(ErrorHandled)
+	return ok && h.ErrorHandled()
+}
+
+// ownReported reports whether err itself implements ErrorReported and is reported, ignoring its causes.
+func ownReported(err error) bool {
+	r, ok := err.(ErrorReported)
+	return ok && r.ErrorReported()
+}
+
+// marked reports whether err or any of its causes is marked according to own, tracking the current
+// depth and the visited errors to guard against cycles.
+func marked(err error, own func(error) bool, depth int, visited visitSet) bool {
+	if err == nil {
+		return false
+	}
+
+	if own(err) {
+		return true
+	}
+
+	if depth >= MaxDepth() || !visited.enter(err) {
+		return false
+	}
+	defer visited.leave(err)
+
+	for _, cause := range limitWidth(Causes(err)) {
+		if marked(cause, own, depth+1, visited) {
+			return true
+		}
+	}
+
+	return false
+}
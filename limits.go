@@ -0,0 +1,156 @@
+package fail
+
+import (
+	"reflect"
+	"sync/atomic"
+)
+
+// DefaultMaxDepth is the default maximum depth to which recursive functions descend into the causes of an error.
+const DefaultMaxDepth = 64
+
+// DefaultMaxWidth is the default maximum number of direct causes recursive functions examine per error.
+const DefaultMaxWidth = 1024
+
+// DefaultMaxNodes is the default maximum number of errors recursive functions descend into per traversal.
+const DefaultMaxNodes = 4096
+
+var (
+	maxDepth atomic.Int64
+	maxWidth atomic.Int64
+	maxNodes atomic.Int64
+)
+
+func init() {
+	maxDepth.Store(DefaultMaxDepth)
+	maxWidth.Store(DefaultMaxWidth)
+	maxNodes.Store(DefaultMaxNodes)
+}
+
+// SetMaxDepth sets the maximum depth to which recursive functions descend into the causes of an error.
+//
+// Functions such as Code, ExitCode and HttpStatusCode, as well as the printers, stop descending
+// once this depth is reached. This protects against stack exhaustion on adversarial or very deep
+// error graphs. Values less than or equal to zero reset the limit to DefaultMaxDepth.
+// It is safe to call SetMaxDepth concurrently.
+//
+// Example:
+//
+//	fail.SetMaxDepth(16)
+func SetMaxDepth(depth int) {
+	if depth <= 0 {
+		depth = DefaultMaxDepth
+	}
+
+	maxDepth.Store(int64(depth))
+}
+
+// MaxDepth returns the maximum depth to which recursive functions descend into the causes of an error.
+func MaxDepth() int {
+	return int(maxDepth.Load())
+}
+
+// SetMaxWidth sets the maximum number of direct causes recursive functions examine per error.
+//
+// Causes beyond this limit are ignored by functions such as Code, ExitCode and HttpStatusCode, as well as the printers.
+// Values less than or equal to zero reset the limit to DefaultMaxWidth.
+// It is safe to call SetMaxWidth concurrently.
+//
+// Example:
+//
+//	fail.SetMaxWidth(100)
+func SetMaxWidth(width int) {
+	if width <= 0 {
+		width = DefaultMaxWidth
+	}
+
+	maxWidth.Store(int64(width))
+}
+
+// MaxWidth returns the maximum number of direct causes recursive functions examine per error.
+func MaxWidth() int {
+	return int(maxWidth.Load())
+}
+
+// SetMaxNodes sets the maximum number of errors recursive functions descend into per traversal.
+//
+// Errors shared by several branches of an error graph are traversed once per branch, so a graph in
+// which every error refers to the same cause twice grows exponentially with its depth, even though
+// it holds few distinct errors. Once a traversal has descended into this many errors, it examines no
+// further causes. Values less than or equal to zero reset the limit to DefaultMaxNodes.
+// It is safe to call SetMaxNodes concurrently.
+//
+// Example:
+//
+//	fail.SetMaxNodes(1000)
+func SetMaxNodes(nodes int) {
+	if nodes <= 0 {
+		nodes = DefaultMaxNodes
+	}
+
+	maxNodes.Store(int64(nodes))
+}
+
+// MaxNodes returns the maximum number of errors recursive functions descend into per traversal.
+func MaxNodes() int {
+	return int(maxNodes.Load())
+}
+
+// limitWidth truncates s to at most MaxWidth elements.
+func limitWidth[T any](s []T) []T {
+	if width := MaxWidth(); len(s) > width {
+		return s[:width]
+	}
+
+	return s
+}
+
+// visitSet tracks the errors visited during a recursive traversal of an error graph.
+//
+// Errors are identified by pointer identity. Errors that are not pointers cannot form cycles
+// on their own and are therefore not tracked; the depth limit still applies to them.
+// Independently of cycles, the number of errors descended into is limited to MaxNodes.
+type visitSet struct {
+	active map[uintptr]struct{} // Pointer errors on the current path
+	nodes  *int                 // Number of errors entered so far, shared by all copies
+}
+
+// newVisitSet returns an empty visitSet for a new traversal.
+func newVisitSet() visitSet {
+	return visitSet{active: map[uintptr]struct{}{}, nodes: new(int)}
+}
+
+// enter marks err as visited and reports whether its causes may be traversed: it reports false if err
+// is already being traversed, or if the traversal has reached MaxNodes.
+func (v visitSet) enter(err error) bool {
+	if *v.nodes >= MaxNodes() {
+		return false
+	}
+
+	rv := reflect.ValueOf(err)
+	if rv.Kind() != reflect.Pointer || rv.IsNil() {
+		*v.nodes++
+		return true
+	}
+
+	ptr := rv.Pointer()
+	if _, ok := v.active[ptr]; ok {
+		return false
+	}
+
+	v.active[ptr] = struct{}{}
+	*v.nodes++
+	return true
+}
+
+// leave removes err from the set of visited errors once its subtree has been traversed.
+//
+// This allows the same error to appear in several branches of the graph without being
+// mistaken for a cycle.
+func (v visitSet) leave(err error) {
+	rv := reflect.ValueOf(err)
+	if rv.Kind() != reflect.Pointer || rv.IsNil() {
+		return
+	}
+
+	delete(v.active, rv.Pointer())
+}
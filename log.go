@@ -0,0 +1,158 @@
+package fail
+
+import (
+	"context"
+	"log/slog"
+	"runtime"
+	"strconv"
+	"sync/atomic"
+	"time"
+)
+
+// DefaultLogDepth is the default maximum depth to which LogIfError and LogAndReturn include causes.
+const DefaultLogDepth = 4
+
+var logDepth atomic.Int64
+
+func init() {
+	logDepth.Store(DefaultLogDepth)
+}
+
+// SetLogDepth sets the maximum depth to which LogIfError and LogAndReturn include the causes of an error.
+//
+// A depth of 1 logs only the error itself, 2 also its direct causes, and so on. The depth is
+// additionally bounded by MaxDepth. Values less than or equal to zero reset the limit to DefaultLogDepth.
+// It is safe to call SetLogDepth concurrently.
+//
+// Example:
+//
+//	fail.SetLogDepth(2)
+func SetLogDepth(depth int) {
+	if depth <= 0 {
+		depth = DefaultLogDepth
+	}
+
+	logDepth.Store(int64(depth))
+}
+
+// LogDepth returns the maximum depth to which LogIfError and LogAndReturn include the causes of an error.
+func LogDepth() int {
+	return int(logDepth.Load())
+}
+
+// LogLevel returns the slog level at which the provided error should be logged.
+//
+// The level is derived as follows:
+//  1. If the error has a SeverityAttribute attribute (see Register), the severity is mapped to
+//     slog.LevelInfo, slog.LevelWarn, slog.LevelError or slog.LevelError+4 for SeverityCritical.
+//  2. If the error has a 4xx HTTP status code, it is a client error and slog.LevelWarn is returned.
+//  3. Otherwise, slog.LevelError is returned.
+func LogLevel(err error) slog.Level {
+	if severity, ok := Attributes(err)[SeverityAttribute].(string); ok {
+		switch severity {
+		case SeverityInfo:
+			return slog.LevelInfo
+		case SeverityWarning:
+			return slog.LevelWarn
+		case SeverityError:
+			return slog.LevelError
+		case SeverityCritical:
+			return slog.LevelError + 4
+		}
+	}
+
+	if status := HttpStatusCode(err); status >= 400 && status < 500 {
+		return slog.LevelWarn
+	}
+
+	return slog.LevelError
+}
+
+// LogIfError logs the provided error with the given message, if it is not nil.
+//
+// The error is logged at the level returned by LogLevel, under the "error" key, with all of its
+// structured fields and its causes up to LogDepth. Additional attributes can be provided as args,
+// in the same form as accepted by slog.Logger.Log. If logger is nil, slog.Default() is used.
+//
+// Example:
+//
+//	defer func() {
+//		fail.LogIfError(logger, file.Close(), "failed to close file", "path", path)
+//	}()
+func LogIfError(logger *slog.Logger, err error, msg string, args ...any) {
+	if err == nil {
+		return
+	}
+
+	logError(logger, err, msg, args)
+}
+
+// LogAndReturn logs the provided error like LogIfError and returns it unchanged.
+//
+// This standardizes the "log then return" pattern. If err is nil, nothing is logged and nil is returned.
+//
+// Example:
+//
+//	if err := store.Save(ctx, order); err != nil {
+//		return fail.LogAndReturn(logger, fail.Wrap(err, "failed to save order"), "order not saved")
+//	}
+func LogAndReturn(logger *slog.Logger, err error, msg string, args ...any) error {
+	if err == nil {
+		return nil
+	}
+
+	logError(logger, err, msg, args)
+	return err
+}
+
+// logError logs err using the caller of the exported logging function as source location.
+func logError(logger *slog.Logger, err error, msg string, args []any) {
+	if logger == nil {
+		logger = slog.Default()
+	}
+
+	ctx := context.Background()
+	level := LogLevel(err)
+	if !logger.Enabled(ctx, level) {
+		return
+	}
+
+	// Skip runtime.Callers, logError and the exported function.
+	var pcs [1]uintptr
+	runtime.Callers(3, pcs[:])
+
+	r := slog.NewRecord(time.Now(), level, msg, pcs[0])
+	r.Add(args...)
+	r.AddAttrs(slog.Attr{Key: "error", Value: logValue(err, 1, newVisitSet())})
+
+	_ = logger.Handler().Handle(ctx, r)
+}
+
+// logValue returns the slog representation of err including its causes up to LogDepth.
+func logValue(err error, depth int, visited visitSet) slog.Value {
+	var attrs []slog.Attr
+	if v, ok := err.(slog.LogValuer); ok {
+		attrs = v.LogValue().Resolve().Group()
+	}
+	if len(attrs) == 0 {
+		attrs = []slog.Attr{slog.String("msg", Message(err))}
+	}
+
+	if depth >= LogDepth() || depth >= MaxDepth() || !visited.enter(err) {
+		return slog.GroupValue(attrs...)
+	}
+	defer visited.leave(err)
+
+	var causes []slog.Attr
+	for i, cause := range limitWidth(Causes(err)) {
+		if cause != nil {
+			causes = append(causes, slog.Attr{Key: strconv.Itoa(i), Value: logValue(cause, depth+1, visited)})
+		}
+	}
+
+	if len(causes) > 0 {
+		attrs = append(attrs, slog.Attr{Key: "causes", Value: slog.GroupValue(causes...)})
+	}
+
+	return slog.GroupValue(attrs...)
+}
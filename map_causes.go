@@ -0,0 +1,165 @@
+package fail
+
+import "reflect"
+
+// MapCauses returns a copy of err in which every cause in the error tree has been replaced by the result of fn.
+//
+// The function fn is called for each cause, starting with the direct causes of err and descending into
+// the causes of the errors it returns. If fn returns nil, the cause is removed from the tree together
+// with its own causes. The root error itself is never passed to fn. Causes beyond MaxWidth are kept
+// unchanged without being passed to fn.
+//
+// Errors whose causes have not changed are kept as-is. Errors whose causes have changed are converted
+// to a Fail using From, preserving all of their metadata. The original error is never modified.
+// If err is nil, MapCauses returns nil. If fn is nil or nothing changed, err is returned unchanged.
+//
+// Example:
+//
+//	// Hide a third-party error behind a stable, client-safe error.
+//	err = fail.MapCauses(err, func(cause error) error {
+//		var pgErr *pgconn.PgError
+//		if errors.As(cause, &pgErr) {
+//			return fail.New().Domain(fail.DomainDatabase).Msg("database error")
+//		}
+//		return cause
+//	})
+func MapCauses(err error, fn func(cause error) error) error {
+	if err == nil || fn == nil {
+		return err
+	}
+
+	res, _ := mapCauses(err, fn, 0, newVisitSet())
+	return res
+}
+
+// PruneCauses returns a copy of err with every cause for which prune returns true removed from the error tree.
+//
+// Removing a cause also removes all of its own causes. Causes that are kept are examined recursively.
+// The root error itself is never pruned. If err is nil, PruneCauses returns nil. If prune is nil or
+// no cause was removed, err is returned unchanged.
+//
+// Example:
+//
+//	// Drop noisy cancellation leaves before logging.
+//	err = fail.PruneCauses(err, func(cause error) bool {
+//		return errors.Is(cause, context.Canceled)
+//	})
+func PruneCauses(err error, prune func(cause error) bool) error {
+	if err == nil || prune == nil {
+		return err
+	}
+
+	return MapCauses(err, func(cause error) error {
+		if prune(cause) {
+			return nil
+		}
+
+		return cause
+	})
+}
+
+// mapCauses applies fn to the causes of err recursively and reports whether anything changed.
+func mapCauses(err error, fn func(error) error, depth int, visited visitSet) (error, bool) {
+	if depth >= MaxDepth() || !visited.enter(err) {
+		return err, false
+	}
+	defer visited.leave(err)
+
+	causes := Causes(err)
+	width := len(limitWidth(causes))
+	labels := labelList(CauseLabels(err))
+	mapped := make([]error, 0, len(causes))
+	var mappedLabels labelList
+	changed := false
+
+	for i, cause := range causes {
+		if cause == nil {
+			continue
+		}
+
+		// Causes beyond the width limit are kept, but not mapped.
+		if i >= width {
+			mappedLabels = mappedLabels.with(len(mapped), labels.at(i))
+			mapped = append(mapped, cause)
+			continue
+		}
+
+		res := fn(cause)
+		if res == nil {
+			changed = true
+			continue
+		}
+
+		res, resChanged := mapCauses(res, fn, depth+1, visited)
+		if resChanged || !identical(res, cause) {
+			changed = true
+		}
+
+		mappedLabels = mappedLabels.with(len(mapped), labels.at(i))
+		mapped = append(mapped, res)
+	}
+
+	if !changed {
+		return err, false
+	}
+
+	// The origin of a converted error still holds the original causes.
+	b := From(err)
+	b.causes = mapped
+	b.causeLabels = mappedLabels
+	b.origin = nil
+
+	return b.asFail(), true
+}
+
+// identical reports whether a and b are the same error value.
+//
+// Errors of uncomparable types, such as Fail, are identical if all of their fields are equal,
+// comparing slices and maps by identity rather than by their elements. A Fail returned unchanged
+// by the mapping function is therefore identical to the original cause.
+func identical(a, b error) bool {
+	ta := reflect.TypeOf(a)
+	if ta != reflect.TypeOf(b) {
+		return false
+	}
+
+	if ta.Comparable() {
+		return a == b
+	}
+
+	return shallowEqual(reflect.ValueOf(a), reflect.ValueOf(b))
+}
+
+// shallowEqual reports whether a and b, of the same type, hold equal values, comparing slices and maps by identity.
+func shallowEqual(a, b reflect.Value) bool {
+	switch a.Kind() {
+	case reflect.Slice:
+		return a.Len() == b.Len() && (a.Len() == 0 || a.Pointer() == b.Pointer())
+	case reflect.Map, reflect.Func:
+		return a.Pointer() == b.Pointer()
+	case reflect.Interface:
+		if a.IsNil() || b.IsNil() {
+			return a.IsNil() == b.IsNil()
+		}
+		if a.Elem().Type() != b.Elem().Type() {
+			return false
+		}
+		return shallowEqual(a.Elem(), b.Elem())
+	case reflect.Array:
+		for i := range a.Len() {
+			if !shallowEqual(a.Index(i), b.Index(i)) {
+				return false
+			}
+		}
+		return true
+	case reflect.Struct:
+		for i := range a.NumField() {
+			if !shallowEqual(a.Field(i), b.Field(i)) {
+				return false
+			}
+		}
+		return true
+	}
+
+	return a.Equal(b)
+}
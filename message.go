@@ -53,3 +53,36 @@ func Message(err error) string {
 
 	return err.Error()
 }
+
+// WithMessage returns a new error with the specified developer-facing message.
+//
+// This function takes an existing error and a message, and returns a new error carrying the provided
+// message instead of the message the error already has. Unlike Builder.Msg, it does not run the hooks
+// registered using AddHook or add the global attributes, so the returned error carries exactly the
+// metadata of err. If the provided error is nil, it returns nil. If the message is empty, the original
+// error is returned unchanged.
+//
+// Example:
+//
+//	err := fail.WithMessage(primaryErr, "failed to load user profile")
+//
+// Parameters:
+//   - err: The original error whose message is replaced.
+//   - message: The developer-facing message of the returned error.
+//
+// Returns:
+//   - A new error with the message set, or nil if err is nil. If message is empty, returns the original error.
+func WithMessage(err error, message string) error {
+	if err == nil {
+		return nil
+	}
+
+	if message == "" {
+		return err
+	}
+
+	b := From(err)
+	b.msg = message
+
+	return b.asFail()
+}
@@ -0,0 +1,62 @@
+package fail
+
+// FailOption is a modification applied to a copy of a Fail by Fail.With or Modify.
+//
+// A FailOption receives a Builder initialized from the copied error and returns the modified Builder.
+// Any Builder method can be used, which keeps the validation rules of the Builder in place.
+//
+// Example:
+//
+//	addRequestId := func(b fail.Builder) fail.Builder {
+//		return b.Attribute("request_id", requestId)
+//	}
+type FailOption func(b Builder) Builder
+
+// With returns a copy of the Fail with the provided options applied.
+//
+// The receiver is not modified, so a Fail can safely be decorated in-flight, for example
+// by middleware, without affecting other holders of the original error. The message, time
+// and source location of the copy are kept unless changed by an option.
+//
+// Example:
+//
+//	decorated := f.With(func(b fail.Builder) fail.Builder {
+//		return b.Tag("edge").UserMsg("Please try again later.")
+//	})
+func (f Fail) With(opts ...FailOption) Fail {
+	b := Builder(f.Clone())
+	for _, opt := range opts {
+		if opt != nil {
+			b = opt(b)
+		}
+	}
+
+	return b.asFail()
+}
+
+// Modify returns a new error with the provided options applied to a copy of err.
+//
+// The error is converted using From, so all metadata of err is preserved and err itself is
+// not modified. If err is nil, Modify returns nil. If no options are provided, the original
+// error is returned unchanged.
+//
+// Example:
+//
+//	err = fail.Modify(err, func(b fail.Builder) fail.Builder {
+//		return b.HttpStatusCode(503)
+//	})
+func Modify(err error, opts ...FailOption) error {
+	if err == nil {
+		return nil
+	}
+
+	if len(opts) == 0 {
+		return err
+	}
+
+	if f, ok := err.(Fail); ok {
+		return f.With(opts...)
+	}
+
+	return Fail(From(err)).With(opts...)
+}
@@ -0,0 +1,109 @@
+package fail
+
+import "runtime"
+
+// MustCallerAttribute is the attribute key under which Must, Must0 and MustOk record the location of their caller.
+const MustCallerAttribute = "must_caller"
+
+// mustPanic is the value Must, Must0 and MustOk panic with, allowing Try to tell their panics apart from others.
+type mustPanic struct {
+	Fail
+}
+
+// Must returns v if err is nil, and panics with err converted to a Fail otherwise.
+//
+// The panic value preserves all metadata of err (see From) and records the location of the caller
+// under MustCallerAttribute. If err has no source location, the caller is used as its source.
+// Use Try to convert the panic back into an error. Must is intended for initialization code
+// where an error is a programming or configuration mistake.
+//
+// Example:
+//
+//	var tmpl = fail.Must(template.ParseFiles("index.html"))
+func Must[T any](v T, err error) T {
+	if err != nil {
+		panic(mustPanic{mustFail(err)})
+	}
+
+	return v
+}
+
+// Must0 panics with err converted to a Fail if err is not nil.
+//
+// The panic value is the same as for Must.
+//
+// Example:
+//
+//	fail.Must0(db.Ping())
+func Must0(err error) {
+	if err != nil {
+		panic(mustPanic{mustFail(err)})
+	}
+}
+
+// MustOk returns v if ok is true, and panics with an ErrCodeNotFound Fail otherwise.
+//
+// This is useful for functions reporting the presence of a value with a bool, such as os.LookupEnv,
+// when the value must be present unless there is a bug or a configuration mistake.
+// The panic value records the location of the caller like Must.
+//
+// Example:
+//
+//	home := fail.MustOk(os.LookupEnv("HOME"))
+func MustOk[T any](v T, ok bool) T {
+	if !ok {
+		panic(mustPanic{mustFail(New().Code(ErrCodeNotFound).Msg("value not present"))})
+	}
+
+	return v
+}
+
+// mustFail converts err into the Fail panicked with by Must, recording the caller of the Must function.
+func mustFail(err error) Fail {
+	b := From(err)
+
+	// Skip mustFail and the Must function.
+	if pc, file, line, ok := runtime.Caller(2); ok {
+		caller := SourceLocation{File: file, Line: line}
+		if fn := runtime.FuncForPC(pc); fn != nil {
+			caller.Function = fn.Name()
+		}
+
+		b = b.Attribute(MustCallerAttribute, caller.String())
+		if b.source.IsZero() {
+			b.source = caller
+		}
+	}
+
+	return b.asFail()
+}
+
+// Try calls fn and returns its error, converting panics raised by Must, Must0 and MustOk into errors.
+//
+// Other panics are not recovered and propagate unchanged. This allows code using Must to be called
+// at function boundaries that must return errors instead of panicking.
+//
+// Example:
+//
+//	func loadConfig(path string) (cfg Config, err error) {
+//		err = fail.Try(func() error {
+//			data := fail.Must(os.ReadFile(path))
+//			fail.Must0(json.Unmarshal(data, &cfg))
+//			return nil
+//		})
+//		return cfg, err
+//	}
+func Try(fn func() error) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			p, ok := r.(mustPanic)
+			if !ok {
+				panic(r)
+			}
+
+			err = p.Fail
+		}
+	}()
+
+	return fn()
+}
@@ -0,0 +1,119 @@
+package fail
+
+import (
+	"bufio"
+	"encoding/json"
+	"io"
+	"os"
+	"sync"
+	"time"
+)
+
+// NDJSONWriter writes errors as newline-delimited JSON, one object per line, for ingestion by log shippers.
+//
+// Each line holds the fields written by the JSON printer for the error itself, with its attributes
+// flattened into top-level keys of the form "attributes.<key>", the full error message including its
+// causes under "error", the Fingerprint of the error under FingerprintAttribute, and the time the line
+// was written under "timestamp". Output is buffered; call Flush to write buffered lines and Close when
+// done. NDJSONWriter is safe for concurrent use.
+type NDJSONWriter struct {
+	mu     sync.Mutex
+	w      io.Writer
+	bw     *bufio.Writer
+	opts   PrinterOptions
+	closed bool
+}
+
+// NewNDJSONWriter returns an NDJSONWriter writing to w.
+//
+// The PrinterOptions control which fields are included in each line. Causes and associated errors
+// are always summarized by the "error" field rather than serialized as nested objects.
+//
+// Example:
+//
+//	f, _ := os.OpenFile("errors.ndjson", os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o644)
+//	w := fail.NewNDJSONWriter(f)
+//	defer w.Close()
+//
+//	_ = w.Write(err)
+func NewNDJSONWriter(w io.Writer, opts ...PrinterOption) *NDJSONWriter {
+	o := DefaultOptions()
+	for _, opt := range opts {
+		opt(&o)
+	}
+	o.Causes = false
+	o.Associated = false
+
+	return &NDJSONWriter{w: w, bw: bufio.NewWriter(w), opts: o}
+}
+
+// Write appends a line describing err to the buffer, flushing it to the underlying writer when full.
+//
+// Nil errors are ignored. Write returns an error if the line cannot be encoded or written, or if the
+// writer has been closed.
+func (n *NDJSONWriter) Write(err error) error {
+	if err == nil {
+		return nil
+	}
+
+	data := printJson(err, n.opts)
+	if attrs, ok := data["attributes"].(map[string]any); ok {
+		delete(data, "attributes")
+		for k, v := range flattenAttributes(attrs) {
+			data["attributes"+AttributeGroupSeparator+k] = v
+		}
+	}
+	data["error"] = err.Error()
+	data[FingerprintAttribute] = Fingerprint(err)
+	data["timestamp"] = time.Now().UTC().Format(time.RFC3339Nano)
+
+	line, mErr := json.Marshal(data)
+	if mErr != nil {
+		return mErr
+	}
+
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	if n.closed {
+		return os.ErrClosed
+	}
+
+	_, wErr := n.bw.Write(append(line, '\n'))
+
+	return wErr
+}
+
+// Flush writes all buffered lines to the underlying writer.
+func (n *NDJSONWriter) Flush() error {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	if n.closed {
+		return os.ErrClosed
+	}
+
+	return n.bw.Flush()
+}
+
+// Close flushes all buffered lines and closes the underlying writer if it implements io.Closer.
+//
+// Subsequent calls to Write, Flush and Close return os.ErrClosed.
+func (n *NDJSONWriter) Close() error {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	if n.closed {
+		return os.ErrClosed
+	}
+	n.closed = true
+
+	fErr := n.bw.Flush()
+	if c, ok := n.w.(io.Closer); ok {
+		if cErr := c.Close(); fErr == nil {
+			fErr = cErr
+		}
+	}
+
+	return fErr
+}
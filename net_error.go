@@ -0,0 +1,120 @@
+package fail
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"net"
+	"syscall"
+)
+
+// Attribute keys set by FromNetError.
+const (
+	// HostAttribute is the attribute key for the remote host of a failed network operation.
+	HostAttribute = "host"
+	// PortAttribute is the attribute key for the remote port of a failed network operation.
+	PortAttribute = "port"
+	// NetOpAttribute is the attribute key for the network operation that failed, such as "dial" or "read".
+	NetOpAttribute = "net_op"
+	// NetworkAttribute is the attribute key for the network type, such as "tcp" or "udp".
+	NetworkAttribute = "network"
+)
+
+// FromNetError converts an error returned by the net package (or a package built on it) into a Fail.
+//
+// The returned error keeps the message and causes of err and is classified as follows:
+//   - timeouts: ErrCodeTimeout, HTTP 504, exit code ExitCodeTempFail, TagTimeout, retryable
+//   - DNS lookups of unknown hosts: ErrCodeUnreachable, HTTP 502, exit code ExitCodeNoHost, TagDNS
+//   - other DNS failures: ErrCodeUnreachable, HTTP 502, exit code ExitCodeUnavailable, TagDNS, retryable if temporary
+//   - refused or reset connections: ErrCodeConnection, HTTP 503, exit code ExitCodeUnavailable, retryable
+//   - TLS handshake and certificate errors: ErrCodeConnection, HTTP 502, exit code ExitCodeProtocol, TagTLS
+//   - anything else: ErrCodeNetwork, HTTP 502, exit code ExitCodeUnavailable, retryable if temporary
+//
+// The domain is set to DomainNetwork and TagNetwork is added. The host, port, operation and network
+// of *net.OpError values, and the host of *net.DNSError values in the error chain are recorded as attributes.
+// If err is nil, FromNetError returns nil.
+//
+// Example:
+//
+//	conn, err := net.Dial("tcp", addr)
+//	if err != nil {
+//		return fail.FromNetError(err)
+//	}
+func FromNetError(err error) error {
+	if err == nil {
+		return nil
+	}
+
+	b := From(err).Domain(DomainNetwork).Tag(TagNetwork)
+
+	var opErr *net.OpError
+	if errors.As(err, &opErr) {
+		b = b.Attribute(NetOpAttribute, opErr.Op).Attribute(NetworkAttribute, opErr.Net)
+
+		if opErr.Addr != nil {
+			if host, port, splitErr := net.SplitHostPort(opErr.Addr.String()); splitErr == nil {
+				b = b.Attribute(HostAttribute, host).Attribute(PortAttribute, port)
+			} else {
+				b = b.Attribute(HostAttribute, opErr.Addr.String())
+			}
+		}
+	}
+
+	var dnsErr *net.DNSError
+	if errors.As(err, &dnsErr) && dnsErr.Name != "" {
+		b = b.Attribute(HostAttribute, dnsErr.Name)
+	}
+
+	return classifyNetError(b, err).Msg(Message(err))
+}
+
+// classifyNetError sets the code, HTTP status code, exit code, tags and retryability of b according to err.
+func classifyNetError(b Builder, err error) Builder {
+	var netErr net.Error
+	isNetErr := errors.As(err, &netErr)
+
+	var dnsErr *net.DNSError
+	isDNSErr := errors.As(err, &dnsErr)
+
+	switch {
+	case isNetErr && netErr.Timeout():
+		return b.Code(ErrCodeTimeout).HttpStatusCode(504).ExitCode(ExitCodeTempFail).Tag(TagTimeout).Retryable(true)
+	case isDNSErr && dnsErr.IsNotFound:
+		return b.Code(ErrCodeUnreachable).HttpStatusCode(502).ExitCode(ExitCodeNoHost).Tag(TagDNS)
+	case isDNSErr:
+		return b.Code(ErrCodeUnreachable).HttpStatusCode(502).ExitCode(ExitCodeUnavailable).Tag(TagDNS).Retryable(dnsErr.IsTemporary)
+	case errors.Is(err, syscall.ECONNREFUSED), errors.Is(err, syscall.ECONNRESET), errors.Is(err, syscall.ECONNABORTED):
+		return b.Code(ErrCodeConnection).HttpStatusCode(503).ExitCode(ExitCodeUnavailable).Retryable(true)
+	case isTLSError(err):
+		return b.Code(ErrCodeConnection).HttpStatusCode(502).ExitCode(ExitCodeProtocol).Tag(TagTLS)
+	}
+
+	return b.Code(ErrCodeNetwork).HttpStatusCode(502).ExitCode(ExitCodeUnavailable).Retryable(isNetErr && isTemporary(netErr))
+}
+
+// isTLSError reports whether the chain of err contains a TLS handshake or certificate verification error.
+func isTLSError(err error) bool {
+	var (
+		recordHeaderErr   tls.RecordHeaderError
+		alertErr          tls.AlertError
+		verificationErr   *tls.CertificateVerificationError
+		unknownAuthority  x509.UnknownAuthorityError
+		hostnameErr       x509.HostnameError
+		certificateInvErr x509.CertificateInvalidError
+	)
+
+	return errors.As(err, &recordHeaderErr) ||
+		errors.As(err, &alertErr) ||
+		errors.As(err, &verificationErr) ||
+		errors.As(err, &unknownAuthority) ||
+		errors.As(err, &hostnameErr) ||
+		errors.As(err, &certificateInvErr)
+}
+
+// isTemporary reports whether err reports itself as temporary.
+//
+// net.Error.Temporary is deprecated, but it is still the only signal some errors provide.
+func isTemporary(err net.Error) bool {
+	temporary, ok := err.(interface{ Temporary() bool })
+	return ok && temporary.Temporary()
+}
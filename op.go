@@ -0,0 +1,74 @@
+package fail
+
+// ErrorOp is an error type that provides the name of the operation that failed.
+//
+// Implementations of this interface should return a short, stable name identifying the
+// failing operation, such as "userdb.Get" or "payments.Charge". Keeping the operation separate
+// from the message allows it to be matched programmatically and rendered compactly.
+// The returned string may be empty if no operation is known.
+//
+// Example usage:
+//
+//	type MyError struct{}
+//	func (e *MyError) Error() string { return "userdb.Get: user not found" }
+//	func (e *MyError) ErrorOp() string { return "userdb.Get" }
+//
+//	err := &MyError{}
+//	op := fail.Op(err) // returns "userdb.Get"
+type ErrorOp interface {
+	error
+
+	// ErrorOp returns the name of the operation that failed.
+	//
+	// The returned string may be empty if no operation is known.
+	ErrorOp() string
+}
+
+// Op returns the name of the failed operation associated with the provided error, if any.
+//
+// This function attempts to extract the operation from the error as follows:
+//  1. If err is nil, it returns an empty string.
+//  2. If err implements ErrorOp, it returns the result of ErrorOp().
+//  3. Otherwise, it returns an empty string.
+func Op(err error) string {
+	if err == nil {
+		return ""
+	}
+
+	if op, ok := err.(ErrorOp); ok {
+		return op.ErrorOp()
+	}
+
+	return ""
+}
+
+// WithOp returns a new error with the specified operation name attached.
+//
+// This function takes an existing error and an operation name, and returns a new error
+// that includes the provided operation. If the provided error is nil, it returns nil.
+// If the operation name is empty, the original error is returned unchanged.
+//
+// The returned error will implement the ErrorOp interface, and the operation can be
+// retrieved using the fail.Op function.
+//
+// Example:
+//
+//	err := fail.WithOp(primaryErr, "userdb.Get")
+//
+// Parameters:
+//   - err: The original error to which the operation will be attached.
+//   - op:  The name of the failed operation.
+//
+// Returns:
+//   - A new error with the operation attached, or nil if err is nil. If op is empty, returns the original error.
+func WithOp(err error, op string) error {
+	if err == nil {
+		return nil
+	}
+
+	if op == "" {
+		return err
+	}
+
+	return From(err).Op(op).asFail()
+}
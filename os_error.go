@@ -0,0 +1,94 @@
+package fail
+
+import (
+	"errors"
+	"io/fs"
+	"os"
+	"syscall"
+)
+
+// Attribute keys set by FromOSError.
+const (
+	// PathAttribute is the attribute key for the file system path an operation failed on.
+	PathAttribute = "path"
+	// NewPathAttribute is the attribute key for the destination path of a failed link or rename.
+	NewPathAttribute = "new_path"
+	// OpAttribute is the attribute key for the file system operation that failed, such as "open".
+	OpAttribute = "fs_op"
+	// SyscallAttribute is the attribute key for the system call that failed.
+	SyscallAttribute = "syscall"
+)
+
+// FromOSError converts an error returned by the os or io/fs packages into a Fail.
+//
+// The returned error keeps the message and causes of err and is classified as follows:
+//   - fs.ErrNotExist: ErrCodeNotFound, HTTP 404, exit code ExitCodeNoInput
+//   - fs.ErrPermission: ErrCodeForbidden, HTTP 403, exit code ExitCodeNoPerm
+//   - fs.ErrExist: ErrCodeAlreadyExists, HTTP 409, exit code ExitCodeCantCreate
+//   - timeouts (os.ErrDeadlineExceeded or Timeout() errors): ErrCodeTimeout, HTTP 504, exit code ExitCodeTempFail, TagTimeout, retryable
+//   - no space left, disk quota exceeded, read-only file system: ErrCodeStorage, HTTP 507, exit code ExitCodeCantCreate
+//   - too many open files, resource temporarily unavailable, device busy: ErrCodeServiceUnavailable, HTTP 503, exit code ExitCodeTempFail, retryable
+//   - not a directory, is a directory, invalid argument: ErrCodeInvalidInput, HTTP 400, exit code ExitCodeDataErr
+//   - anything else: ErrCodeStorage, exit code ExitCodeIOErr
+//
+// The domain is set to DomainIO and TagIO is added. The path, operation and system call of
+// *fs.PathError, *os.LinkError and *os.SyscallError values in the error chain are recorded as attributes.
+// If err is nil, FromOSError returns nil.
+//
+// Example:
+//
+//	f, err := os.Open(name)
+//	if err != nil {
+//		return fail.FromOSError(err)
+//	}
+func FromOSError(err error) error {
+	if err == nil {
+		return nil
+	}
+
+	b := From(err).Domain(DomainIO).Tag(TagIO)
+
+	var pathErr *fs.PathError
+	if errors.As(err, &pathErr) {
+		b = b.Attribute(PathAttribute, pathErr.Path).Attribute(OpAttribute, pathErr.Op)
+	}
+
+	var linkErr *os.LinkError
+	if errors.As(err, &linkErr) {
+		b = b.
+			Attribute(PathAttribute, linkErr.Old).
+			Attribute(NewPathAttribute, linkErr.New).
+			Attribute(OpAttribute, linkErr.Op)
+	}
+
+	var syscallErr *os.SyscallError
+	if errors.As(err, &syscallErr) {
+		b = b.Attribute(SyscallAttribute, syscallErr.Syscall)
+	}
+
+	return classifyOSError(b, err).Msg(Message(err))
+}
+
+// classifyOSError sets the code, HTTP status code, exit code and retryability of b according to err.
+func classifyOSError(b Builder, err error) Builder {
+	var timeout interface{ Timeout() bool }
+
+	switch {
+	case errors.Is(err, fs.ErrNotExist):
+		return b.Code(ErrCodeNotFound).HttpStatusCode(404).ExitCode(ExitCodeNoInput)
+	case errors.Is(err, fs.ErrPermission):
+		return b.Code(ErrCodeForbidden).HttpStatusCode(403).ExitCode(ExitCodeNoPerm)
+	case errors.Is(err, fs.ErrExist):
+		return b.Code(ErrCodeAlreadyExists).HttpStatusCode(409).ExitCode(ExitCodeCantCreate)
+	case errors.Is(err, os.ErrDeadlineExceeded), errors.As(err, &timeout) && timeout.Timeout():
+		return b.Code(ErrCodeTimeout).HttpStatusCode(504).ExitCode(ExitCodeTempFail).Tag(TagTimeout).Retryable(true)
+	case errors.Is(err, syscall.ENOSPC), errors.Is(err, syscall.EDQUOT), errors.Is(err, syscall.EROFS):
+		return b.Code(ErrCodeStorage).HttpStatusCode(507).ExitCode(ExitCodeCantCreate)
+	case errors.Is(err, syscall.EMFILE), errors.Is(err, syscall.ENFILE), errors.Is(err, syscall.EAGAIN), errors.Is(err, syscall.EBUSY):
+		return b.Code(ErrCodeServiceUnavailable).HttpStatusCode(503).ExitCode(ExitCodeTempFail).Retryable(true)
+	case errors.Is(err, syscall.ENOTDIR), errors.Is(err, syscall.EISDIR), errors.Is(err, syscall.EINVAL):
+		return b.Code(ErrCodeInvalidInput).HttpStatusCode(400).ExitCode(ExitCodeDataErr)
+	}
+
+	return b.Code(ErrCodeStorage).ExitCode(ExitCodeIOErr)
+}
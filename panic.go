@@ -0,0 +1,76 @@
+package fail
+
+import "runtime/debug"
+
+// ExitCodePanic is the exit code of errors created by FromPanic, matching the exit code of the Go
+// runtime for unrecovered panics.
+const ExitCodePanic = 2
+
+// Attribute keys under which FromPanic records recovered panics.
+const (
+	// PanicAttribute is the attribute key under which the value passed to panic is recorded.
+	PanicAttribute = "panic"
+	// PanicStackAttribute is the attribute key under which the stack of the panicking goroutine is recorded.
+	PanicStackAttribute = "panic_stack"
+)
+
+// FromPanic converts a value returned by recover into an error.
+//
+// If the recovered value is an error, it becomes the cause of the returned error. If it is a string,
+// it is used as the message; other values are formatted into the message using fmt. The returned
+// Fail always has DomainInternal, ErrCodeInternal and ExitCodePanic, records the recovered value
+// under PanicAttribute, and the stack of the panicking goroutine under PanicStackAttribute.
+// FromPanic must be called from the deferred function that recovered the panic for the stack to
+// include the panicking function.
+//
+// Panics raised by Must, Must0 and MustOk are returned as the error they carry. If recovered is nil,
+// FromPanic returns nil.
+//
+// Example:
+//
+//	defer func() {
+//		if r := recover(); r != nil {
+//			fail.LogIfError(logger, fail.FromPanic(r), "worker crashed")
+//		}
+//	}()
+func FromPanic(recovered any) error {
+	switch r := recovered.(type) {
+	case nil:
+		return nil
+	case mustPanic:
+		return r.Fail
+	}
+
+	b := New().
+		Domain(DomainInternal).
+		Code(ErrCodeInternal).
+		ExitCode(ExitCodePanic).
+		Attribute(PanicAttribute, recovered).
+		Attribute(PanicStackAttribute, string(debug.Stack()))
+
+	switch r := recovered.(type) {
+	case error:
+		return b.Cause(r).Msg("panic")
+	case string:
+		return b.Msg(r)
+	default:
+		return b.Msgf("panic: %v", r)
+	}
+}
+
+// Recover converts a panic of the calling function into an error stored in *errp, see FromPanic.
+//
+// Recover must be deferred directly, as recover only stops a panic when called by a deferred function.
+// If there is no panic, *errp is left unchanged.
+//
+// Example:
+//
+//	func (h *Handler) process(job Job) (err error) {
+//		defer fail.Recover(&err)
+//		return h.run(job)
+//	}
+func Recover(errp *error) {
+	if r := recover(); r != nil {
+		*errp = FromPanic(r)
+	}
+}
@@ -0,0 +1,207 @@
+package fail
+
+import (
+	"encoding/json"
+	"strconv"
+)
+
+// Attribute keys under which PartialSuccess and Partial.Err records the outcome of the operations.
+const (
+	// SucceededAttribute is the attribute key for the number of operations that succeeded.
+	SucceededAttribute = "succeeded"
+	// FailedAttribute is the attribute key for the number of operations that failed.
+	FailedAttribute = "failed"
+)
+
+// PartialSuccess returns an aggregate error describing a batch of operations of which some failed.
+//
+// results are the results of the operations that succeeded and errs the errors of those that failed;
+// nil errors are ignored. If no operation failed, PartialSuccess returns nil. Otherwise, the returned
+// Fail has the errors as causes and records the number of succeeded and failed operations under
+// SucceededAttribute and FailedAttribute. If at least one operation succeeded, it is tagged with
+// TagPartial and carries the HTTP status code 207 (Multi-Status), regardless of
+// SetRelaxedHttpStatusCodes; if all operations failed, its HTTP status code is derived from the
+// causes as usual.
+//
+// Example:
+//
+//	var saved []Order
+//	var errs []error
+//	for _, order := range orders {
+//		if err := store.Save(ctx, order); err != nil {
+//			errs = append(errs, fail.Wrapf(err, "failed to save order %s", order.ID))
+//			continue
+//		}
+//		saved = append(saved, order)
+//	}
+//
+//	if err := fail.PartialSuccess(saved, errs); err != nil {
+//		_ = failhttp.Write(w, r, err)
+//	}
+func PartialSuccess[T any](results []T, errs []error) error {
+	return partialError(len(results), nil, errs)
+}
+
+// partialError implements PartialSuccess for succeeded operations and the labeled errors of the failed ones.
+func partialError(succeeded int, labels labelList, errs []error) error {
+	b := New()
+	failed := 0
+	for i, err := range errs {
+		if err != nil {
+			b = b.CauseLabeled(labels.at(i), err)
+			failed++
+		}
+	}
+
+	if failed == 0 {
+		return nil
+	}
+
+	b = b.Attribute(SucceededAttribute, succeeded).Attribute(FailedAttribute, failed)
+
+	if succeeded == 0 {
+		return b.Msgf("all %d operations failed", failed)
+	}
+
+	b = b.Tag(TagPartial)
+	b.httpStatusCode, b.httpStatusCodeSet = 207, true
+
+	return b.Msgf("%d of %d operations failed", failed, succeeded+failed)
+}
+
+// Partial holds the outcome of a batch operation of which some items may have failed.
+//
+// It holds the items that succeeded and the errors of the items that failed, keyed by the index or ID
+// of the item, such as the ID of a record in a bulk API request. Err aggregates the errors into a single
+// Fail, and MarshalJSON renders the outcome for bulk API responses. The zero value is an empty Partial
+// ready to use. A Partial is not safe for concurrent use.
+//
+// Example:
+//
+//	var res fail.Partial[Order]
+//	for _, order := range orders {
+//		res.Add(order.ID, order, store.Save(ctx, order))
+//	}
+//
+//	fmt.Println(res.String()) // 37 succeeded, 3 failed
+//	_ = json.NewEncoder(w).Encode(&res)
+type Partial[T any] struct {
+	items []T
+	keys  []string
+	errs  []error
+}
+
+// Add records the outcome of the item with the provided key: item if err is nil, and err otherwise.
+func (p *Partial[T]) Add(key string, item T, err error) {
+	if err != nil {
+		p.AddError(key, err)
+		return
+	}
+
+	p.AddItem(item)
+}
+
+// AddItem records an item that succeeded.
+func (p *Partial[T]) AddItem(item T) {
+	p.items = append(p.items, item)
+}
+
+// AddError records the error of the item with the provided key. Nil errors are ignored.
+//
+// Items identified by their position can use strconv.Itoa(i) as key.
+func (p *Partial[T]) AddError(key string, err error) {
+	if err == nil {
+		return
+	}
+
+	p.keys = append(p.keys, key)
+	p.errs = append(p.errs, err)
+}
+
+// Items returns the items that succeeded, in the order they were added.
+func (p *Partial[T]) Items() []T {
+	return p.items
+}
+
+// Errors returns the errors of the items that failed keyed by the keys of the items.
+//
+// If several errors were recorded for the same key, the last one is returned.
+func (p *Partial[T]) Errors() map[string]error {
+	res := make(map[string]error, len(p.errs))
+	for i, err := range p.errs {
+		res[p.keys[i]] = err
+	}
+
+	return res
+}
+
+// Succeeded returns the number of items that succeeded.
+func (p *Partial[T]) Succeeded() int {
+	return len(p.items)
+}
+
+// Failed returns the number of items that failed.
+func (p *Partial[T]) Failed() int {
+	return len(p.errs)
+}
+
+// Err returns an aggregate error of the items that failed, or nil if no item failed.
+//
+// The errors are the causes of the returned Fail, labeled with the keys of their items.
+// See PartialSuccess for the remaining fields.
+func (p *Partial[T]) Err() error {
+	return partialError(len(p.items), p.keys, p.errs)
+}
+
+// String returns a summary of the outcome, such as "37 succeeded, 3 failed".
+func (p *Partial[T]) String() string {
+	return strconv.Itoa(p.Succeeded()) + " succeeded, " + strconv.Itoa(p.Failed()) + " failed"
+}
+
+// MarshalJSON renders the outcome as a JSON object for bulk API responses.
+//
+// The object has the following fields:
+//   - "summary": the result of String
+//   - "succeeded" and "failed": the number of items that succeeded and failed
+//   - "items": the items that succeeded
+//   - "errors": an array of objects with the "key" of an item that failed and its "error",
+//     rendered like by the JSON printer with the default options
+//
+// The object records the SchemaVersion under SchemaVersionKey.
+//
+// Example output:
+//
+//	{"summary":"2 succeeded, 1 failed","succeeded":2,"failed":1,"items":[...],
+//	 "errors":[{"key":"17","error":{"msg":"order not found","code":"ERR_NOT_FOUND",...}}],"schema_version":1}
+func (p *Partial[T]) MarshalJSON() ([]byte, error) {
+	type itemError struct {
+		Key   string         `json:"key"`
+		Error map[string]any `json:"error"`
+	}
+
+	errs := make([]itemError, len(p.errs))
+	for i, err := range p.errs {
+		errs[i] = itemError{Key: p.keys[i], Error: printJsonDepth(err, 0, DefaultOptions(), newVisitSet())}
+	}
+
+	items := p.items
+	if items == nil {
+		items = []T{}
+	}
+
+	return json.Marshal(struct {
+		Summary       string      `json:"summary"`
+		Succeeded     int         `json:"succeeded"`
+		Failed        int         `json:"failed"`
+		Items         []T         `json:"items"`
+		Errors        []itemError `json:"errors"`
+		SchemaVersion int         `json:"schema_version"`
+	}{
+		Summary:       p.String(),
+		Succeeded:     p.Succeeded(),
+		Failed:        p.Failed(),
+		Items:         items,
+		Errors:        errs,
+		SchemaVersion: SchemaVersion,
+	})
+}
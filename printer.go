@@ -1,5 +1,13 @@
 package fail
 
+import (
+	"io"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
+
 // Printer is an interface for formatting errors as strings.
 //
 // Implementations of Printer can be used to customize how errors are rendered
@@ -10,6 +18,18 @@ type Printer interface {
 	Print(err error) string
 }
 
+// WriterPrinter is a Printer that can also write its output directly to an io.Writer.
+//
+// Implementations should stream the rendered error to the writer instead of building
+// the complete output in memory first, which allows large error trees to be written
+// to files or sockets efficiently. PrintTo returns the first error encountered while writing.
+type WriterPrinter interface {
+	Printer
+
+	// PrintTo writes the representation of err to w.
+	PrintTo(w io.Writer, err error) error
+}
+
 // PrinterFunc is an adapter to allow the use of ordinary functions as Printers.
 //
 // Any function with the appropriate signature can be converted to a Printer
@@ -20,3 +40,173 @@ type PrinterFunc func(err error) string
 func (f PrinterFunc) Print(err error) string {
 	return f(err)
 }
+
+// PrintTo calls the underlying function and writes the result to w.
+//
+// Implements WriterPrinter interface.
+func (f PrinterFunc) PrintTo(w io.Writer, err error) error {
+	_, wErr := io.WriteString(w, f(err))
+	return wErr
+}
+
+// PrintTo writes the representation of err produced by the given Printer to w.
+//
+// If the Printer implements WriterPrinter, its PrintTo method is used so the output
+// is streamed. Otherwise, the result of Print is written to w.
+//
+// Example:
+//
+//	err := fail.PrintTo(os.Stderr, fail.JsonPrinter(), someErr)
+func PrintTo(w io.Writer, p Printer, err error) error {
+	if wp, ok := p.(WriterPrinter); ok {
+		return wp.PrintTo(w, err)
+	}
+
+	_, wErr := io.WriteString(w, p.Print(err))
+	return wErr
+}
+
+// printWriter wraps an io.Writer and remembers the first error returned by it.
+//
+// Once an error occurred, all further writes are discarded. This allows printers to
+// write their output piece by piece without checking for errors after every write.
+type printWriter struct {
+	w   io.Writer
+	err error
+}
+
+// WriteString writes s to the underlying writer, unless a previous write failed.
+func (pw *printWriter) WriteString(s string) {
+	if pw.err != nil {
+		return
+	}
+
+	_, pw.err = io.WriteString(pw.w, s)
+}
+
+// fieldKind describes how a printer should render the value of a metadataField.
+type fieldKind int
+
+const (
+	// fieldText is a value rendered as plain text.
+	fieldText fieldKind = iota
+	// fieldCode is an identifier, such as a code or an ID, rendered as code if supported.
+	fieldCode
+	// fieldLink is a URL, rendered as a link if supported.
+	fieldLink
+	// fieldCodeList is a list of identifiers, such as tags, stored in metadataField.values.
+	fieldCodeList
+)
+
+// metadataField is a named metadata value of an error, as displayed by the document printers.
+type metadataField struct {
+	name   string
+	value  string
+	values []string
+	kind   fieldKind
+}
+
+// metadataFields returns the metadata of err enabled by o, in display order, omitting unset values.
+func metadataFields(err error, o PrinterOptions) []metadataField {
+	var fields []metadataField
+	add := func(name, value string, kind fieldKind) {
+		fields = append(fields, metadataField{name: name, value: value, kind: kind})
+	}
+
+	if o.UserMsg {
+		if userMsg := UserMessage(err); userMsg != "" {
+			add("User message", userMsg, fieldText)
+		}
+	}
+	if o.Code {
+		if code := Code(err); code != "" && code != ErrCodeUnspecified {
+			add("Code", code, fieldCode)
+		}
+	}
+	if o.Domain {
+		if domain := Domain(err); domain != "" {
+			add("Domain", domain, fieldCode)
+		}
+	}
+	if o.HttpStatusCode {
+		if status := HttpStatusCode(err); status > 0 {
+			add("HTTP status", strconv.Itoa(status), fieldText)
+		}
+	}
+	if o.ExitCode {
+		if exitCode := ExitCode(err); exitCode > 0 {
+			add("Exit code", strconv.Itoa(exitCode), fieldText)
+		}
+	}
+	if o.Time {
+		if t := Time(err); !t.IsZero() {
+			format := time.RFC3339
+			if o.TimeFormat != "" {
+				format = o.TimeFormat
+			}
+			add("Time", t.Format(format), fieldText)
+		}
+	}
+	if o.TraceId {
+		if traceId := TraceId(err); traceId != "" {
+			add("Trace ID", traceId, fieldCode)
+		}
+	}
+	if o.SpanId {
+		if spanId := SpanId(err); spanId != "" {
+			add("Span ID", spanId, fieldCode)
+		}
+	}
+	if o.CorrelationId {
+		if correlationId := CorrelationId(err); correlationId != "" {
+			add("Correlation ID", correlationId, fieldCode)
+		}
+	}
+	if o.Id {
+		if id := ownId(err); id != "" {
+			add("Error ID", id, fieldCode)
+		}
+	}
+	if o.Source {
+		if source := Source(err); !source.IsZero() {
+			add("Source", source.String(), fieldCode)
+		}
+	}
+	if o.Duration {
+		if duration := Duration(err); duration > 0 {
+			add("Duration", duration.String(), fieldText)
+		}
+	}
+	if o.Retryable {
+		if transience := Transience(err); transience != TransienceUnknown {
+			add("Transience", transience.String(), fieldText)
+		}
+	}
+	if o.RetryAfter {
+		if retryAfter := RetryAfter(err); retryAfter > 0 {
+			add("Retry after", retryAfter.String(), fieldText)
+		}
+	}
+	if o.Tags {
+		if tags := Tags(err); len(tags) > 0 {
+			slices.Sort(tags)
+			fields = append(fields, metadataField{name: "Tags", value: strings.Join(tags, ", "), values: tags, kind: fieldCodeList})
+		}
+	}
+	if o.HelpURL {
+		if helpURL := HelpURL(err); helpURL != "" {
+			add("Help", helpURL, fieldLink)
+		}
+	}
+	if o.Fields {
+		if fieldErrs := Fields(err); len(fieldErrs) > 0 {
+			values := make([]string, len(fieldErrs))
+			for i, field := range fieldErrs {
+				values[i] = field.String()
+			}
+			fields = append(fields, metadataField{name: "Fields", value: strings.Join(values, "; "), values: values, kind: fieldText})
+		}
+	}
+
+	return fields
+}
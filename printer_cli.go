@@ -0,0 +1,161 @@
+package fail
+
+import (
+	"io"
+	"os"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+// ANSI escape sequences used by the CLI printer.
+const (
+	ansiReset   = "\x1b[0m"
+	ansiBoldRed = "\x1b[1;31m"
+	ansiDim     = "\x1b[2m"
+)
+
+// PrintCLI prints a representation of the provided error suitable for command-line tools to standard error.
+//
+// See CLIPrinter for details.
+//
+// Example:
+//
+//	if err := run(); err != nil {
+//		fail.PrintCLI(err)
+//		os.Exit(fail.ExitCode(err))
+//	}
+func PrintCLI(err error, opts ...PrinterOption) {
+	_ = FprintCLI(os.Stderr, err, opts...)
+}
+
+// PrintsCLI returns a representation of the provided error suitable for command-line tools.
+//
+// Since the result is not written to a terminal, it contains no colors or hyperlinks, and it is only
+// wrapped if a width is set using PrintWidth.
+//
+// Example:
+//
+//	out := fail.PrintsCLI(err, fail.PrintWidth(100))
+func PrintsCLI(err error, opts ...PrinterOption) string {
+	return CLIPrinter(opts...).Print(err)
+}
+
+// FprintCLI writes a representation of the provided error suitable for command-line tools to w.
+//
+// See CLIPrinter for details. It returns the first error encountered while writing.
+//
+// Example:
+//
+//	err := fail.FprintCLI(os.Stderr, someErr)
+func FprintCLI(w io.Writer, err error, opts ...PrinterOption) error {
+	return CLIPrinter(opts...).PrintTo(w, err)
+}
+
+// CLIPrinter returns a Printer that formats errors for display in a terminal.
+//
+// The output starts with a compact one-line summary of the error and its causes, followed by the
+// code, if set, and then by the detailed, indented output of the pretty printer. When writing to a
+// terminal, the summary is colored, long lines are wrapped to the width of the terminal as reported by
+// the COLUMNS environment variable, and help URLs are rendered as OSC 8 hyperlinks. Colors and hyperlinks
+// are disabled when the output is not a terminal or when the NO_COLOR environment variable is set (see
+// https://no-color.org). A width set using PrintWidth takes precedence over the width of the terminal.
+// The returned Printer also implements WriterPrinter.
+//
+// Example:
+//
+//	printer := fail.CLIPrinter(fail.PrintSource(false))
+//	_ = printer.PrintTo(os.Stderr, err)
+func CLIPrinter(opts ...PrinterOption) WriterPrinter {
+	o := DefaultOptions()
+	o.Hyperlinks = true
+	for _, opt := range opts {
+		opt(&o)
+	}
+
+	return cliPrinter{opts: o}
+}
+
+// cliPrinter is the WriterPrinter returned by CLIPrinter.
+type cliPrinter struct {
+	opts PrinterOptions
+}
+
+// Print returns the CLI representation of err, as if it was written to a writer that is not a terminal.
+func (p cliPrinter) Print(err error) string {
+	sb := strings.Builder{}
+	_ = p.PrintTo(&sb, err)
+
+	return sb.String()
+}
+
+// PrintTo writes the CLI representation of err to w, adapting the output to the terminal w refers to, if any.
+func (p cliPrinter) PrintTo(w io.Writer, err error) error {
+	opts := p.opts
+	if opts.Stable {
+		stabilize(&opts)
+	}
+
+	if !isTerminal(w) {
+		opts.Color = false
+		opts.Hyperlinks = false
+	} else if opts.Width == 0 {
+		if width, convErr := strconv.Atoi(os.Getenv("COLUMNS")); convErr == nil && width > 0 {
+			opts.Width = width
+		}
+	}
+
+	if os.Getenv("NO_COLOR") != "" {
+		opts.Color = false
+	}
+
+	pw := &printWriter{w: w}
+	printCLI(pw, err, opts)
+
+	return pw.err
+}
+
+// isTerminal reports whether w is a file referring to a terminal, i.e. a character device.
+func isTerminal(w io.Writer) bool {
+	f, ok := w.(*os.File)
+	if !ok {
+		return false
+	}
+
+	info, err := f.Stat()
+	return err == nil && info.Mode()&os.ModeCharDevice != 0
+}
+
+// printCLI writes the summary line of err followed by its detailed pretty output.
+func printCLI(pw *printWriter, err error, opts PrinterOptions) {
+	if err == nil {
+		return
+	}
+
+	summary := verboseError(err, 0, newVisitSet())
+	if opts.Code {
+		if code := Code(err); code != "" && code != ErrCodeUnspecified {
+			summary += " [" + code + "]"
+		}
+	}
+
+	if opts.Width > 0 && utf8.RuneCountInString(summary)+len("error: ") > opts.Width {
+		runes := []rune(summary)
+		summary = string(runes[:max(opts.Width-len("error: ")-1, 0)]) + "…"
+	}
+
+	if opts.Color {
+		pw.WriteString(ansiBoldRed + "error:" + ansiReset + " " + summary + "\n\n")
+	} else {
+		pw.WriteString("error: " + summary + "\n\n")
+	}
+
+	if opts.Color {
+		pw.WriteString(ansiDim)
+	}
+	printPretty(pw, 0, "", err, opts, newVisitSet())
+	if opts.Color {
+		pw.WriteString(ansiReset)
+	}
+	pw.WriteString("\n")
+}
@@ -0,0 +1,82 @@
+package fail
+
+import (
+	"maps"
+	"slices"
+	"sync"
+	"sync/atomic"
+)
+
+var (
+	// domainPrinterOptionsMu serializes modifications of domainPrinterOptions.
+	domainPrinterOptionsMu sync.Mutex
+	// domainPrinterOptions maps domains to the printer options set using SetDomainPrinterOptions.
+	// The map is never modified after it is stored; modifications store a copy.
+	domainPrinterOptions atomic.Pointer[map[string][]PrinterOption]
+)
+
+// SetDomainPrinterOptions sets the printer options used by PrintPretty and Fatal for errors of the provided domain.
+//
+// This allows the verbosity of the output to adapt to the class of the error, for example printing
+// validation errors compactly without source locations, while printing internal errors in full detail.
+// The domain of an error is determined using Domain. The options are applied on top of the default
+// options, and the options passed to PrintPretty are applied last, so they take precedence.
+// Calling SetDomainPrinterOptions without options removes the options of the domain.
+// It is safe to call SetDomainPrinterOptions concurrently.
+//
+// Example:
+//
+//	fail.SetDomainPrinterOptions(fail.DomainValidation, fail.PrintCompact(true), fail.PrintSource(false))
+//	fail.SetDomainPrinterOptions(fail.DomainInternal, fail.PrintAssociated(true), fail.PrintCauseDepth(0))
+func SetDomainPrinterOptions(domain string, opts ...PrinterOption) {
+	domainPrinterOptionsMu.Lock()
+	defer domainPrinterOptionsMu.Unlock()
+
+	next := map[string][]PrinterOption{}
+	if current := domainPrinterOptions.Load(); current != nil {
+		next = maps.Clone(*current)
+	}
+
+	if len(opts) == 0 {
+		delete(next, domain)
+	} else {
+		next[domain] = slices.Clone(opts)
+	}
+
+	domainPrinterOptions.Store(&next)
+}
+
+// DomainPrinterOptions returns the printer options set for the provided domain using SetDomainPrinterOptions, if any.
+func DomainPrinterOptions(domain string) []PrinterOption {
+	current := domainPrinterOptions.Load()
+	if current == nil {
+		return nil
+	}
+
+	return slices.Clone((*current)[domain])
+}
+
+// ResetDomainPrinterOptions removes the printer options of all domains.
+//
+// It is safe to call ResetDomainPrinterOptions concurrently.
+func ResetDomainPrinterOptions() {
+	domainPrinterOptionsMu.Lock()
+	defer domainPrinterOptionsMu.Unlock()
+
+	domainPrinterOptions.Store(nil)
+}
+
+// withDomainPrinterOptions returns the printer options of the domain of err followed by opts.
+func withDomainPrinterOptions(err error, opts []PrinterOption) []PrinterOption {
+	current := domainPrinterOptions.Load()
+	if current == nil || err == nil {
+		return opts
+	}
+
+	domainOpts := (*current)[Domain(err)]
+	if len(domainOpts) == 0 {
+		return opts
+	}
+
+	return append(slices.Clip(domainOpts), opts...)
+}
@@ -0,0 +1,208 @@
+package fail
+
+import (
+	"io"
+	"reflect"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// PrintDot prints a Graphviz DOT graph of the provided error to standard output.
+//
+// See DotPrinter for details.
+//
+// Example:
+//
+//	fail.PrintDot(err) // pipe into `dot -Tsvg -o error.svg`
+func PrintDot(err error, opts ...PrinterOption) {
+	println(PrintsDot(err, opts...))
+}
+
+// PrintsDot returns a Graphviz DOT graph of the provided error.
+//
+// Example:
+//
+//	graph := fail.PrintsDot(err)
+func PrintsDot(err error, opts ...PrinterOption) string {
+	return DotPrinter(opts...).Print(err)
+}
+
+// FprintDot writes a Graphviz DOT graph of the provided error to w.
+//
+// It returns the first error encountered while writing.
+//
+// Example:
+//
+//	f, _ := os.Create("error.dot")
+//	err := fail.FprintDot(f, someErr)
+func FprintDot(w io.Writer, err error, opts ...PrinterOption) error {
+	return DotPrinter(opts...).PrintTo(w, err)
+}
+
+// DotPrinter returns a Printer that formats errors as Graphviz DOT graphs.
+//
+// Every error in the tree becomes a node whose label holds its message and the metadata and
+// attributes enabled by the PrinterOptions. Causes are connected to the errors they caused by solid
+// edges, labeled with the cause label, if any, and associated errors by dashed edges, labeled with
+// their role. Pointer errors shared by several branches are rendered as a single node, so that complex
+// multi-cause failures, such as those of distributed batch jobs, are displayed as the graph they form;
+// value errors, such as Fail, are rendered once per occurrence.
+// The returned Printer also implements WriterPrinter.
+//
+// Example:
+//
+//	printer := fail.DotPrinter(fail.PrintAttributes(false))
+//	_ = printer.PrintTo(os.Stdout, err)
+func DotPrinter(opts ...PrinterOption) WriterPrinter {
+	o := DefaultOptions()
+	for _, opt := range opts {
+		opt(&o)
+	}
+
+	return dotPrinter{opts: o}
+}
+
+// dotPrinter is the WriterPrinter returned by DotPrinter.
+type dotPrinter struct {
+	opts PrinterOptions
+}
+
+// Print returns the DOT graph of err.
+func (p dotPrinter) Print(err error) string {
+	sb := strings.Builder{}
+	_ = p.PrintTo(&sb, err)
+
+	return sb.String()
+}
+
+// PrintTo writes the DOT graph of err to w.
+func (p dotPrinter) PrintTo(w io.Writer, err error) error {
+	if err == nil {
+		return nil
+	}
+
+	g := &dotGraph{pw: &printWriter{w: w}, opts: p.opts, ids: map[uintptr]string{}}
+	g.pw.WriteString("digraph fail {\n")
+	g.pw.WriteString("  rankdir=TB;\n")
+	g.pw.WriteString("  node [shape=box, style=rounded, fontname=\"Helvetica\", fontsize=10];\n")
+	g.pw.WriteString("  edge [fontname=\"Helvetica\", fontsize=9];\n")
+	g.node(0, err)
+	g.pw.WriteString("}\n")
+
+	return g.pw.err
+}
+
+// dotGraph holds the state of the DOT printer while traversing an error graph.
+type dotGraph struct {
+	pw   *printWriter
+	opts PrinterOptions
+	// ids maps pointer errors to the IDs of their nodes, so that shared errors are rendered once.
+	ids  map[uintptr]string
+	next int
+}
+
+// node writes the node of err, followed by its children and the edges to them, and returns its ID.
+//
+// Pointer errors that already have a node are not written again, which also terminates cycles.
+// Children are not written beyond MaxDepth or CauseDepth, or once MaxNodes nodes have been written.
+func (g *dotGraph) node(depth int, err error) string {
+	var ptr uintptr
+	if rv := reflect.ValueOf(err); rv.Kind() == reflect.Pointer && !rv.IsNil() {
+		ptr = rv.Pointer()
+		if id, ok := g.ids[ptr]; ok {
+			return id
+		}
+	}
+
+	id := "e" + strconv.Itoa(g.next)
+	g.next++
+	if ptr != 0 {
+		g.ids[ptr] = id
+	}
+
+	attrs := ""
+	if depth == 0 {
+		attrs = ", color=\"#cf222e\", penwidth=2"
+	}
+	g.pw.WriteString("  " + id + " [label=" + dotLines(dotLabel(err, g.opts)) + attrs + "];\n")
+
+	if depth+1 >= MaxDepth() || (g.opts.CauseDepth > 0 && depth >= g.opts.CauseDepth) || g.next >= MaxNodes() {
+		return id
+	}
+
+	if g.opts.Causes {
+		causes, labels := filterPrinted(limitWidth(Causes(err)), CauseLabels(err), g.opts)
+		for i, cause := range causes {
+			child := g.node(depth+1, cause)
+			edge := "  " + id + " -> " + child
+			if label := labels.at(i); label != "" && g.opts.CauseLabels {
+				edge += " [label=" + dotQuote(label) + "]"
+			}
+			g.pw.WriteString(edge + ";\n")
+		}
+	}
+
+	if g.opts.Associated {
+		associatedErrs, roles := filterPrinted(limitWidth(Associated(err)), AssociatedRoles(err), g.opts)
+		for i, associated := range associatedErrs {
+			child := g.node(depth+1, associated)
+			edge := "  " + id + " -> " + child + " [style=dashed"
+			if role := roles.at(i); role != "" {
+				edge += ", label=" + dotQuote(role)
+			}
+			g.pw.WriteString(edge + "];\n")
+		}
+	}
+
+	return id
+}
+
+// dotLabel returns the lines of the label of the node of err: its message, followed by its metadata and attributes.
+func dotLabel(err error, o PrinterOptions) []string {
+	title := Message(err)
+	if o.Op {
+		if op := Op(err); op != "" {
+			title = op + ": " + title
+		}
+	}
+
+	lines := []string{title}
+	for _, f := range metadataFields(err, o) {
+		lines = append(lines, f.name+": "+f.value)
+	}
+
+	if o.Attributes {
+		attrs := Attributes(err)
+		keys := make([]string, 0, len(attrs))
+		for k := range attrs {
+			keys = append(keys, k)
+		}
+		slices.Sort(keys)
+		for _, k := range keys {
+			lines = append(lines, k+"="+printValue(attrs[k], o))
+		}
+	}
+
+	return lines
+}
+
+// dotEscaper escapes the characters that have a special meaning in DOT quoted strings.
+var dotEscaper = strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\r", "", "\n", `\n`)
+
+// dotQuote returns s as a DOT quoted string.
+func dotQuote(s string) string {
+	return `"` + dotEscaper.Replace(s) + `"`
+}
+
+// dotLines returns lines as a DOT quoted string with left-justified lines.
+func dotLines(lines []string) string {
+	sb := strings.Builder{}
+	sb.WriteString(`"`)
+	for _, line := range lines {
+		sb.WriteString(dotEscaper.Replace(line) + `\l`)
+	}
+	sb.WriteString(`"`)
+
+	return sb.String()
+}
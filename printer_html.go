@@ -0,0 +1,253 @@
+package fail
+
+import (
+	"html/template"
+	"io"
+	"os"
+	"slices"
+	"strings"
+)
+
+// HTMLField is a named value displayed by the HTML printer.
+type HTMLField struct {
+	// Name is the display name of the field, or the attribute key.
+	Name string
+	// Value is the value of the field, formatted as text.
+	Value string
+	// Values holds the individual values of list fields, such as tags, and is nil otherwise.
+	Values []string
+	// Code is true if the value is an identifier, such as a code or an ID, that should be rendered as code.
+	Code bool
+	// Link is true if the value is a URL that should be rendered as a link.
+	Link bool
+}
+
+// HTMLNode is the data passed to the templates of the HTML printer for an error and each of its
+// causes and associated errors.
+//
+// Custom templates (see HTMLTemplatePrinter) are executed with the HTMLNode of the top-level error.
+// Values are plain strings; html/template escapes them according to the context they are used in.
+type HTMLNode struct {
+	// Label is the label of a cause or the role of an associated error, if set and enabled.
+	Label string
+	// Op is the name of the failed operation, if set and enabled.
+	Op string
+	// Message is the developer-facing message of the error.
+	Message string
+	// Code is the error code, if set and enabled.
+	Code string
+	// Fields are the metadata of the error enabled by the PrinterOptions, in display order.
+	Fields []HTMLField
+	// Attributes are the attributes of the error, sorted by key, if enabled.
+	Attributes []HTMLField
+	// Causes are the causes of the error, if enabled.
+	Causes []HTMLNode
+	// Associated are the associated errors, if enabled.
+	Associated []HTMLNode
+}
+
+// DefaultHTMLTemplate is the template used by HTMLPrinter.
+//
+// It renders the error tree as nested details/summary elements with inline styles, so that the
+// output can be embedded in error pages and HTML emails without a stylesheet. It defines the
+// templates "error", rendering a full document fragment, and "node", rendering a single HTMLNode
+// and its children. Custom templates can reuse "node" by cloning this template.
+var DefaultHTMLTemplate = template.Must(template.New("error").Parse(`
+{{- define "node" -}}
+<details open style="margin:4px 0 4px 12px;padding-left:8px;border-left:2px solid #d0d7de">
+<summary style="cursor:pointer;font-weight:600">
+{{- if .Label}}<span style="color:#57606a">[{{.Label}}]</span> {{end -}}
+{{- if .Op}}<span style="color:#57606a">{{.Op}}:</span> {{end -}}
+{{.Message}}
+{{- if .Code}} <code style="background:#f6f8fa;padding:1px 4px;border-radius:4px;color:#cf222e">{{.Code}}</code>{{end -}}
+</summary>
+{{- if .Fields}}
+<table style="border-collapse:collapse;margin:4px 0;font-size:13px">
+{{- range .Fields}}
+<tr><th style="text-align:left;padding:2px 8px 2px 0;color:#57606a;font-weight:normal;vertical-align:top">{{.Name}}</th><td style="padding:2px 0">
+{{- if .Link}}<a href="{{.Value}}" style="color:#0969da">{{.Value}}</a>
+{{- else if .Values}}{{range $i, $v := .Values}}{{if $i}} {{end}}<code style="background:#f6f8fa;padding:1px 4px;border-radius:4px">{{$v}}</code>{{end}}
+{{- else if .Code}}<code style="background:#f6f8fa;padding:1px 4px;border-radius:4px">{{.Value}}</code>
+{{- else}}{{.Value}}{{end -}}
+</td></tr>
+{{- end}}
+</table>
+{{- end}}
+{{- if .Attributes}}
+<table style="border-collapse:collapse;margin:4px 0;font-size:13px">
+<tr><th style="text-align:left;padding:2px 8px;border-bottom:1px solid #d0d7de">Attribute</th><th style="text-align:left;padding:2px 8px;border-bottom:1px solid #d0d7de">Value</th></tr>
+{{- range .Attributes}}
+<tr><td style="padding:2px 8px"><code>{{.Name}}</code></td><td style="padding:2px 8px;white-space:pre-wrap">{{.Value}}</td></tr>
+{{- end}}
+</table>
+{{- end}}
+{{- if .Causes}}
+<div style="margin-top:4px;color:#57606a;font-size:12px;text-transform:uppercase">Caused by</div>
+{{- range .Causes}}
+{{template "node" .}}
+{{- end}}
+{{- end}}
+{{- if .Associated}}
+<div style="margin-top:4px;color:#57606a;font-size:12px;text-transform:uppercase">Associated</div>
+{{- range .Associated}}
+{{template "node" .}}
+{{- end}}
+{{- end}}
+</details>
+{{- end -}}
+<div style="font-family:-apple-system,BlinkMacSystemFont,'Segoe UI',Helvetica,Arial,sans-serif;font-size:14px;color:#1f2328">
+{{template "node" .}}
+</div>
+`))
+
+// PrintHTML prints an HTML representation of the provided error to standard output.
+//
+// Example:
+//
+//	err := fail.New().Msg("something went wrong")
+//	fail.PrintHTML(err)
+func PrintHTML(err error, opts ...PrinterOption) {
+	_ = FprintHTML(os.Stdout, err, opts...)
+}
+
+// PrintsHTML returns an HTML representation of the provided error.
+//
+// Example:
+//
+//	body := fail.PrintsHTML(err)
+func PrintsHTML(err error, opts ...PrinterOption) string {
+	return HTMLPrinter(opts...).Print(err)
+}
+
+// FprintHTML writes an HTML representation of the provided error to w.
+//
+// It returns the first error encountered while writing.
+//
+// Example:
+//
+//	err := fail.FprintHTML(w, someErr)
+func FprintHTML(w io.Writer, err error, opts ...PrinterOption) error {
+	return HTMLPrinter(opts...).PrintTo(w, err)
+}
+
+// HTMLPrinter returns a Printer that formats errors as HTML fragments using DefaultHTMLTemplate.
+//
+// The error tree is rendered as collapsible details/summary elements with inline styles, so the output
+// can be used for internal error pages and alert emails. All values are escaped. The PrinterOptions
+// control which fields are included.
+// The returned Printer also implements WriterPrinter.
+//
+// Example:
+//
+//	printer := fail.HTMLPrinter(fail.PrintSource(false))
+//	_ = printer.PrintTo(w, err)
+func HTMLPrinter(opts ...PrinterOption) WriterPrinter {
+	return HTMLTemplatePrinter(DefaultHTMLTemplate, opts...)
+}
+
+// HTMLTemplatePrinter returns a Printer that formats errors as HTML using the provided template.
+//
+// The template is executed with the HTMLNode of the error, built according to the PrinterOptions.
+// If tmpl is nil, DefaultHTMLTemplate is used. Errors executing the template are returned by PrintTo;
+// Print returns the output written up to the error.
+// The returned Printer also implements WriterPrinter.
+//
+// Example:
+//
+//	tmpl := template.Must(template.New("alert").Parse(`<h1>{{.Message}}</h1>`))
+//	printer := fail.HTMLTemplatePrinter(tmpl)
+func HTMLTemplatePrinter(tmpl *template.Template, opts ...PrinterOption) WriterPrinter {
+	if tmpl == nil {
+		tmpl = DefaultHTMLTemplate
+	}
+
+	o := DefaultOptions()
+	for _, opt := range opts {
+		opt(&o)
+	}
+
+	return htmlPrinter{tmpl: tmpl, opts: o}
+}
+
+// htmlPrinter is the WriterPrinter returned by HTMLPrinter and HTMLTemplatePrinter.
+type htmlPrinter struct {
+	tmpl *template.Template
+	opts PrinterOptions
+}
+
+// Print returns the HTML representation of err.
+func (p htmlPrinter) Print(err error) string {
+	sb := strings.Builder{}
+	_ = p.PrintTo(&sb, err)
+
+	return sb.String()
+}
+
+// PrintTo writes the HTML representation of err to w.
+func (p htmlPrinter) PrintTo(w io.Writer, err error) error {
+	if err == nil {
+		return nil
+	}
+
+	return p.tmpl.Execute(w, htmlNode(0, "", err, p.opts, newVisitSet()))
+}
+
+// htmlNode returns the HTMLNode of err and, recursively, of its causes and associated errors.
+//
+// Causes are not included beyond MaxDepth or CauseDepth, and cycles in the cause graph are not followed.
+func htmlNode(depth int, label string, err error, o PrinterOptions, visited visitSet) HTMLNode {
+	node := HTMLNode{Message: Message(err)}
+	if o.CauseLabels {
+		node.Label = label
+	}
+	if o.Op {
+		node.Op = Op(err)
+	}
+
+	for _, f := range metadataFields(err, o) {
+		if f.name == "Code" {
+			node.Code = f.value
+			continue
+		}
+		node.Fields = append(node.Fields, HTMLField{
+			Name:   f.name,
+			Value:  f.value,
+			Values: f.values,
+			Code:   f.kind == fieldCode,
+			Link:   f.kind == fieldLink,
+		})
+	}
+
+	if o.Attributes {
+		attrs := Attributes(err)
+		keys := make([]string, 0, len(attrs))
+		for k := range attrs {
+			keys = append(keys, k)
+		}
+		slices.Sort(keys)
+		for _, k := range keys {
+			node.Attributes = append(node.Attributes, HTMLField{Name: k, Value: printValue(attrs[k], o)})
+		}
+	}
+
+	if depth+1 >= MaxDepth() || (o.CauseDepth > 0 && depth >= o.CauseDepth) || !visited.enter(err) {
+		return node
+	}
+	defer visited.leave(err)
+
+	if o.Causes {
+		causes, labels := filterPrinted(limitWidth(Causes(err)), CauseLabels(err), o)
+		for i, cause := range causes {
+			node.Causes = append(node.Causes, htmlNode(depth+1, labels.at(i), cause, o, visited))
+		}
+	}
+
+	if o.Associated {
+		associatedErrs, roles := filterPrinted(limitWidth(Associated(err)), AssociatedRoles(err), o)
+		for i, associated := range associatedErrs {
+			node.Associated = append(node.Associated, htmlNode(depth+1, roles.at(i), associated, o, visited))
+		}
+	}
+
+	return node
+}
@@ -2,6 +2,9 @@ package fail
 
 import (
 	"encoding/json"
+	"io"
+	"path/filepath"
+	"slices"
 	"strings"
 	"time"
 )
@@ -15,7 +18,7 @@ import (
 // Example:
 //
 //	err := fail.New().Msg("something went wrong")
-//	print.PrintJson(err)
+//	fail.PrintJson(err)
 //
 // The output format and included fields can be customized using PrinterOptions.
 func PrintJson(err error, opts ...PrinterOption) {
@@ -33,43 +36,120 @@ func PrintJson(err error, opts ...PrinterOption) {
 // Example:
 //
 //	err := fail.New().Msg("something went wrong")
-//	jsonStr := print.PrintsJson(err)
+//	jsonStr := fail.PrintsJson(err)
 //
 // The output format and included fields can be customized using PrinterOptions.
 func PrintsJson(err error, opts ...PrinterOption) string {
 	return JsonPrinter(opts...).Print(err)
 }
 
+// FprintJson writes a JSON-formatted representation of the provided error to w.
+//
+// The JSON document is encoded directly into the writer and is identical to the result
+// of PrintsJson. It returns the first error encountered while encoding or writing.
+//
+// Example:
+//
+//	err := fail.FprintJson(os.Stdout, someErr)
+func FprintJson(w io.Writer, err error, opts ...PrinterOption) error {
+	return JsonPrinter(opts...).PrintTo(w, err)
+}
+
 // JsonPrinter returns a Printer that formats errors as JSON strings.
 //
 // The returned Printer serializes errors and their metadata into JSON, using the
 // provided PrinterOptions to control which fields are included. If the error is nil,
 // the Printer returns the string "null" (the JSON null value). This is useful for
 // structured logging, diagnostics, or API error responses.
+// Causes and associated errors are serialized recursively as nested JSON objects with the
+// same fields, up to the depth configured using PrintCauseDepth. The top-level object records
+// the SchemaVersion under SchemaVersionKey.
+// The returned Printer also implements WriterPrinter.
 //
 // Example:
 //
-//	printer := print.JsonPrinter(print.WithoutColor())
+//	printer := fail.JsonPrinter(fail.PrintColor(false))
 //	out := printer.Print(err)
-func JsonPrinter(opts ...PrinterOption) Printer {
-	return PrinterFunc(func(err error) string {
-		return printJson(err, opts...)
-	})
+func JsonPrinter(opts ...PrinterOption) WriterPrinter {
+	o := DefaultOptions()
+	for _, opt := range opts {
+		opt(&o)
+	}
+
+	return jsonPrinter{opts: o}
 }
 
-// printJson serializes the provided error into a JSON string according to the given PrinterOptions.
-//
-// This is an internal helper used by JsonPrinter and PrintJson. It panics if not implemented.
-func printJson(err error, opts ...PrinterOption) string {
+// jsonPrinter is the WriterPrinter returned by JsonPrinter.
+type jsonPrinter struct {
+	opts PrinterOptions
+}
+
+// Print returns the JSON representation of err.
+func (p jsonPrinter) Print(err error) string {
+	sb := strings.Builder{}
+	if wErr := p.PrintTo(&sb, err); wErr != nil {
+		panic(wErr)
+	}
+
+	return sb.String()
+}
+
+// PrintTo encodes the JSON representation of err into w.
+func (p jsonPrinter) PrintTo(w io.Writer, err error) error {
 	if err == nil {
-		return "null"
+		_, wErr := io.WriteString(w, "null")
+		return wErr
 	}
 
-	o := DefaultOptions()
-	for _, opt := range opts {
-		opt(&o)
+	enc := json.NewEncoder(&trimNewlineWriter{w: w})
+	enc.SetIndent("", strings.Repeat(" ", p.opts.Indent))
+
+	opts := p.opts
+	if opts.Stable {
+		stabilize(&opts)
+	}
+
+	return enc.Encode(printJson(err, opts))
+}
+
+// trimNewlineWriter drops the trailing newline json.Encoder appends to every value,
+// so that streamed output matches json.MarshalIndent.
+type trimNewlineWriter struct {
+	w io.Writer
+}
+
+// Write writes p to the underlying writer, omitting a single trailing newline.
+func (t *trimNewlineWriter) Write(p []byte) (int, error) {
+	n := len(p)
+	if n > 0 && p[n-1] == '\n' {
+		p = p[:n-1]
+	}
+
+	written, err := t.w.Write(p)
+	if err == nil && written == len(p) {
+		written = n
 	}
 
+	return written, err
+}
+
+// printJson collects the fields of the provided error into a map according to the given PrinterOptions.
+//
+// This is an internal helper used by JsonPrinter and PrintJson. The returned map is ready to be encoded as JSON.
+// Only the top-level object records the SchemaVersion.
+func printJson(err error, o PrinterOptions) map[string]any {
+	data := printJsonDepth(err, 0, o, newVisitSet())
+	data[SchemaVersionKey] = SchemaVersion
+
+	return data
+}
+
+// printJsonDepth implements printJson, serializing causes and associated errors recursively.
+//
+// Causes and associated errors are collected using the same logic as err itself. They are not
+// serialized beyond MaxDepth or CauseDepth, and cycles in the cause graph are not followed; errors at
+// these limits are serialized without their causes and associated errors.
+func printJsonDepth(err error, depth int, o PrinterOptions, visited visitSet) map[string]any {
 	data := map[string]any{
 		"msg": Message(err),
 	}
@@ -86,22 +166,42 @@ func printJson(err error, opts ...PrinterOption) string {
 		}
 	}
 
-	if o.Associated {
-		associated := Associated(err)
-		if len(associated) > 0 {
-			data["associated"] = associated
+	if depth+1 < MaxDepth() && (o.CauseDepth == 0 || depth < o.CauseDepth) && visited.enter(err) {
+		defer visited.leave(err)
+
+		if o.Associated {
+			associated, roles := filterPrinted(limitWidth(Associated(err)), AssociatedRoles(err), o)
+			if len(associated) > 0 {
+				data["associated"] = printJsonList(associated, depth, o, visited)
+			}
+
+			if roles := roles.aligned(len(associated)); roles != nil {
+				data["associated_roles"] = roles
+			}
 		}
-	}
 
-	if o.Causes {
-		causes := Causes(err)
-		if len(causes) > 0 {
-			data["causes"] = causes
+		if o.Causes {
+			causes, labels := expandJoined(limitWidth(Causes(err)), CauseLabels(err))
+			causes, labels = filterPrinted(causes, labels, o)
+			if o.Stable {
+				causes, labels = sortCauses(causes, labels)
+			}
+
+			if len(causes) > 0 {
+				data["causes"] = printJsonList(causes, depth, o, visited)
+			}
+
+			if labels := labels.aligned(len(causes)); o.CauseLabels && labels != nil {
+				data["cause_labels"] = labels
+			}
 		}
 	}
 
 	if o.Tags {
 		tags := Tags(err)
+		if o.Stable {
+			slices.Sort(tags)
+		}
 		if len(tags) > 0 {
 			data["tags"] = tags
 		}
@@ -110,7 +210,7 @@ func printJson(err error, opts ...PrinterOption) string {
 	if o.Attributes {
 		attributes := Attributes(err)
 		if len(attributes) > 0 {
-			data["attributes"] = attributes
+			data["attributes"] = NestAttributes(attributes)
 		}
 	}
 
@@ -163,10 +263,85 @@ func printJson(err error, opts ...PrinterOption) string {
 		}
 	}
 
-	b, err := json.MarshalIndent(data, "", strings.Repeat(" ", o.Indent))
-	if err != nil {
-		panic(err)
+	if o.CorrelationId {
+		correlationId := CorrelationId(err)
+		if correlationId != "" {
+			data["correlation_id"] = correlationId
+		}
+	}
+
+	if o.Id {
+		id := ownId(err)
+		if id != "" {
+			data["error_id"] = id
+		}
+	}
+
+	if o.Source {
+		source := Source(err)
+		if o.Stable {
+			source.File = filepath.Base(source.File)
+		}
+		if !source.IsZero() {
+			data["source"] = source
+		}
+	}
+
+	if o.Duration {
+		duration := Duration(err)
+		if duration > 0 {
+			data["duration"] = duration.String()
+		}
+	}
+
+	if o.Op {
+		op := Op(err)
+		if op != "" {
+			data["op"] = op
+		}
+	}
+
+	if o.Retryable {
+		transience := Transience(err)
+		if transience != TransienceUnknown {
+			data["transience"] = transience.String()
+		}
+
+		if transience == TransienceTransient {
+			data["retryable"] = true
+		}
+	}
+
+	if o.HelpURL {
+		helpURL := HelpURL(err)
+		if helpURL != "" {
+			data["help_url"] = helpURL
+		}
+	}
+
+	if o.Fields {
+		fields := Fields(err)
+		if len(fields) > 0 {
+			data["fields"] = fields
+		}
+	}
+
+	if o.RetryAfter {
+		retryAfter := RetryAfter(err)
+		if retryAfter > 0 {
+			data["retry_after"] = retryAfter.String()
+		}
+	}
+
+	return data
+}
+
+// printJsonList serializes the causes or associated errors of an error at the given depth.
+func printJsonList(errs []error, depth int, o PrinterOptions, visited visitSet) []map[string]any {
+	res := make([]map[string]any, len(errs))
+	for i, err := range errs {
+		res[i] = printJsonDepth(err, depth+1, o, visited)
 	}
 
-	return string(b)
+	return res
 }
@@ -0,0 +1,216 @@
+package fail
+
+import (
+	"io"
+	"slices"
+	"strings"
+)
+
+// PrintMarkdown prints a Markdown representation of the provided error to standard output.
+//
+// Example:
+//
+//	err := fail.New().Msg("something went wrong")
+//	fail.PrintMarkdown(err)
+func PrintMarkdown(err error, opts ...PrinterOption) {
+	println(PrintsMarkdown(err, opts...))
+}
+
+// PrintsMarkdown returns a Markdown representation of the provided error.
+//
+// Example:
+//
+//	body := fail.PrintsMarkdown(err)
+func PrintsMarkdown(err error, opts ...PrinterOption) string {
+	return MarkdownPrinter(opts...).Print(err)
+}
+
+// FprintMarkdown writes a Markdown representation of the provided error to w.
+//
+// It returns the first error encountered while writing.
+//
+// Example:
+//
+//	err := fail.FprintMarkdown(issueBody, someErr)
+func FprintMarkdown(w io.Writer, err error, opts ...PrinterOption) error {
+	return MarkdownPrinter(opts...).PrintTo(w, err)
+}
+
+// MarkdownPrinter returns a Printer that formats errors as Markdown documents.
+//
+// The output is designed to be pasted into GitHub issues, tickets or chat messages: the error message
+// is rendered as a heading, the metadata as a bullet list with codes and IDs in code spans, the
+// attributes as a table, and the causes and associated errors as nested bullet lists. Messages are
+// escaped so that they are rendered verbatim. The PrinterOptions control which fields are included.
+// The returned Printer also implements WriterPrinter.
+//
+// Example:
+//
+//	printer := fail.MarkdownPrinter(fail.PrintSource(false))
+//	out := printer.Print(err)
+func MarkdownPrinter(opts ...PrinterOption) WriterPrinter {
+	o := DefaultOptions()
+	for _, opt := range opts {
+		opt(&o)
+	}
+
+	return markdownPrinter{opts: o}
+}
+
+// markdownPrinter is the WriterPrinter returned by MarkdownPrinter.
+type markdownPrinter struct {
+	opts PrinterOptions
+}
+
+// Print returns the Markdown representation of err.
+func (p markdownPrinter) Print(err error) string {
+	sb := strings.Builder{}
+	_ = p.PrintTo(&sb, err)
+
+	return sb.String()
+}
+
+// PrintTo writes the Markdown representation of err to w.
+func (p markdownPrinter) PrintTo(w io.Writer, err error) error {
+	if err == nil {
+		return nil
+	}
+
+	pw := &printWriter{w: w}
+	printMarkdown(pw, err, p.opts)
+
+	return pw.err
+}
+
+// printMarkdown writes the Markdown document for err.
+func printMarkdown(pw *printWriter, err error, o PrinterOptions) {
+	title := Message(err)
+	if o.Op {
+		if op := Op(err); op != "" {
+			title = op + ": " + title
+		}
+	}
+	pw.WriteString("## " + markdownEscape(title) + "\n")
+
+	if items := markdownMetadata(err, o); len(items) > 0 {
+		pw.WriteString("\n")
+		for _, item := range items {
+			pw.WriteString("- " + item + "\n")
+		}
+	}
+
+	if o.Attributes {
+		if attrs := Attributes(err); len(attrs) > 0 {
+			keys := make([]string, 0, len(attrs))
+			for k := range attrs {
+				keys = append(keys, k)
+			}
+			slices.Sort(keys)
+
+			pw.WriteString("\n| Attribute | Value |\n| --- | --- |\n")
+			for _, k := range keys {
+				pw.WriteString("| " + markdownCode(k) + " | " + markdownEscape(printValue(attrs[k], o)) + " |\n")
+			}
+		}
+	}
+
+	if o.Causes {
+		if causes, labels := filterPrinted(limitWidth(Causes(err)), CauseLabels(err), o); len(causes) > 0 {
+			pw.WriteString("\n**Causes**\n\n")
+			printMarkdownList(pw, 0, err, causes, labels, o, newVisitSet())
+		}
+	}
+
+	if o.Associated {
+		if associated, roles := filterPrinted(limitWidth(Associated(err)), AssociatedRoles(err), o); len(associated) > 0 {
+			pw.WriteString("\n**Associated errors**\n\n")
+			printMarkdownList(pw, 0, err, associated, roles, o, newVisitSet())
+		}
+	}
+}
+
+// printMarkdownList writes errs as a bullet list nested according to depth, recursing into their causes.
+//
+// The labels are aligned with errs. Causes are not printed beyond MaxDepth or CauseDepth, and cycles
+// in the cause graph are not followed.
+func printMarkdownList(pw *printWriter, depth int, parent error, errs []error, labels labelList, o PrinterOptions, visited visitSet) {
+	if depth >= MaxDepth() || (o.CauseDepth > 0 && depth >= o.CauseDepth) || !visited.enter(parent) {
+		return
+	}
+	defer visited.leave(parent)
+
+	indent := strings.Repeat("  ", depth)
+	for i, err := range errs {
+		line := ""
+		if label := labels.at(i); label != "" && o.CauseLabels {
+			line += "**" + markdownEscape(label) + "**: "
+		}
+		if o.Op {
+			if op := Op(err); op != "" {
+				line += markdownEscape(op) + ": "
+			}
+		}
+		line += markdownEscape(Message(err))
+		if c, ok := err.(ErrorCode); o.Code && ok && c.ErrorCode() != "" && c.ErrorCode() != ErrCodeUnspecified {
+			line += " (" + markdownCode(c.ErrorCode()) + ")"
+		}
+
+		pw.WriteString(indent + "- " + line + "\n")
+
+		if o.Causes {
+			causes, labels := filterPrinted(limitWidth(Causes(err)), CauseLabels(err), o)
+			printMarkdownList(pw, depth+1, err, causes, labels, o, visited)
+		}
+	}
+}
+
+// markdownMetadata returns the bullet list items describing the metadata of err.
+func markdownMetadata(err error, o PrinterOptions) []string {
+	fields := metadataFields(err, o)
+	items := make([]string, len(fields))
+	for i, f := range fields {
+		value := markdownEscape(f.value)
+		switch f.kind {
+		case fieldCode:
+			value = markdownCode(f.value)
+		case fieldLink:
+			value = "<" + f.value + ">"
+		case fieldCodeList:
+			codes := make([]string, len(f.values))
+			for j, v := range f.values {
+				codes[j] = markdownCode(v)
+			}
+			value = strings.Join(codes, ", ")
+		}
+		items[i] = "**" + f.name + ":** " + value
+	}
+
+	return items
+}
+
+// markdownEscaper escapes the characters that have a special meaning in Markdown text.
+var markdownEscaper = strings.NewReplacer(
+	`\`, `\\`, "`", "\\`", "*", `\*`, "_", `\_`, "[", `\[`, "]", `\]`,
+	"<", `\<`, ">", `\>`, "#", `\#`, "|", `\|`, "~", `\~`, "\n", " ",
+)
+
+// markdownEscape escapes s so that it is rendered verbatim as Markdown text on a single line.
+func markdownEscape(s string) string {
+	return markdownEscaper.Replace(s)
+}
+
+// markdownCode returns s as a Markdown code span, using a fence longer than any backtick run in s.
+func markdownCode(s string) string {
+	s = strings.ReplaceAll(s, "\n", " ")
+
+	fence := "`"
+	for strings.Contains(s, fence) {
+		fence += "`"
+	}
+
+	if strings.HasPrefix(s, "`") || strings.HasSuffix(s, "`") {
+		return fence + " " + s + " " + fence
+	}
+
+	return fence + s + fence
+}
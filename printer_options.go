@@ -1,6 +1,9 @@
 package fail
 
-import "time"
+import (
+	"slices"
+	"time"
+)
 
 // PrinterOptions configures the behavior of a Printer.
 //
@@ -22,9 +25,22 @@ type PrinterOptions struct {
 	Associated bool
 	// Causes enables printing direct causes of the error if true.
 	Causes bool
-	// CauseDepth is the maximum recursion depth to print causes.
+	// CauseDepth is the number of levels of causes to print, see PrintCauseDepth.
 	// If 0, all causes are printed.
 	CauseDepth int
+	// CauseLabels enables printing the labels of causes if true.
+	CauseLabels bool
+	// Width is the maximum line width; longer lines are wrapped at word boundaries.
+	// If 0, lines are not wrapped. Printers may ignore this value if they do not produce lines of text.
+	Width int
+	// Hyperlinks enables rendering links, such as the help URL, as OSC 8 terminal hyperlinks if true.
+	// Printers may ignore this value if they do not produce terminal output.
+	Hyperlinks bool
+	// Compact enables the single-line mode of the pretty printer if true.
+	Compact bool
+	// Stable enables deterministic output suitable for golden tests if true.
+	// Printers may ignore this value if their output is deterministic anyway.
+	Stable bool
 	// Tags enables printing error tags if true.
 	Tags bool
 	// Attributes enables printing error attributes if true.
@@ -43,6 +59,37 @@ type PrinterOptions struct {
 	TraceId bool
 	// SpanId enables printing the span ID if true.
 	SpanId bool
+	// CorrelationId enables printing the correlation ID if true.
+	CorrelationId bool
+	// Id enables printing the unique ID of the error instance if true.
+	// Printers that render causes inline may only print the ID of the printed error.
+	Id bool
+	// Source enables printing the source location of the error if true.
+	Source bool
+	// Duration enables printing the duration of the failed operation if true.
+	Duration bool
+	// Op enables printing the name of the failed operation if true.
+	Op bool
+	// Retryable enables printing whether the error is retryable if true.
+	Retryable bool
+	// RetryAfter enables printing the retry backoff if true.
+	RetryAfter bool
+	// HelpURL enables printing the documentation link if true.
+	HelpURL bool
+	// Fields enables printing the field-level details of validation failures if true.
+	Fields bool
+	// OnlyDomains restricts the printed causes and associated errors to those of the listed domains.
+	// If empty, errors of all domains are printed. The printed error itself is never filtered.
+	OnlyDomains []string
+	// ExcludeTags excludes the causes and associated errors carrying any of the listed tags from printing.
+	// The printed error itself is never filtered.
+	ExcludeTags []string
+	// ValueFormatter renders attribute values as text. If nil, FormatValue is used.
+	// Printers may ignore this value if they render attribute values in a structured format.
+	ValueFormatter ValueFormatter
+	// MaxValueLength is the maximum length, in bytes, of rendered attribute values; longer values are truncated.
+	// If 0, values are not truncated.
+	MaxValueLength int
 }
 
 // DefaultOptions returns a PrinterOptions struct with all fields set to their default values.
@@ -57,6 +104,7 @@ func DefaultOptions() PrinterOptions {
 		TimeFormat:     time.RFC3339,
 		Associated:     true,
 		Causes:         true,
+		CauseLabels:    true,
 		Tags:           true,
 		Attributes:     true,
 		Code:           true,
@@ -66,9 +114,57 @@ func DefaultOptions() PrinterOptions {
 		UserMsg:        true,
 		TraceId:        true,
 		SpanId:         true,
+		CorrelationId:  true,
+		Id:             true,
+		Source:         true,
+		Duration:       true,
+		Op:             true,
+		Retryable:      true,
+		RetryAfter:     true,
+		HelpURL:        true,
+		Fields:         true,
+		MaxValueLength: DefaultMaxValueLength,
 	}
 }
 
+// printMessagesOnly disables printing of all error metadata, leaving only messages and causes.
+//
+// It is used by Fail.Error, which must not include metadata in its result.
+// The operation and the labels of causes are kept, as they are rendered as part of the message.
+func printMessagesOnly(opts *PrinterOptions) {
+	opts.Time = false
+	opts.Associated = false
+	opts.Tags = false
+	opts.Attributes = false
+	opts.Code = false
+	opts.Domain = false
+	opts.ExitCode = false
+	opts.HttpStatusCode = false
+	opts.UserMsg = false
+	opts.TraceId = false
+	opts.SpanId = false
+	opts.CorrelationId = false
+	opts.Id = false
+	opts.Source = false
+	opts.Duration = false
+	opts.Retryable = false
+	opts.RetryAfter = false
+	opts.HelpURL = false
+	opts.Fields = false
+}
+
+// stabilize adjusts opts for deterministic output, as enabled by PrinterOptions.Stable.
+//
+// Colors and hyperlinks are disabled, and the time, duration and ID of errors, which differ between
+// runs, are not printed.
+func stabilize(opts *PrinterOptions) {
+	opts.Color = false
+	opts.Hyperlinks = false
+	opts.Time = false
+	opts.Duration = false
+	opts.Id = false
+}
+
 // PrinterOption is a functional option for configuring PrinterOptions.
 //
 // Use PrinterOption functions to set fields on PrinterOptions when constructing
@@ -77,7 +173,7 @@ type PrinterOption func(*PrinterOptions)
 
 // PrintIndent sets the indentation level (number of spaces) for nested errors.
 //
-// Example: print.PrintIndent(4)
+// Example: fail.PrintIndent(4)
 func PrintIndent(indent int) PrinterOption {
 	return func(opts *PrinterOptions) {
 		opts.Indent = indent
@@ -86,7 +182,7 @@ func PrintIndent(indent int) PrinterOption {
 
 // PrintColor enables or disables ANSI color output.
 //
-// Example: print.WithColor(false)
+// Example: fail.PrintColor(false)
 func PrintColor(color bool) PrinterOption {
 	return func(opts *PrinterOptions) {
 		opts.Color = color
@@ -95,7 +191,7 @@ func PrintColor(color bool) PrinterOption {
 
 // PrintTime enables or disables printing the error's timestamp.
 //
-// Example: print.WithTime(false)
+// Example: fail.PrintTime(false)
 func PrintTime(time bool) PrinterOption {
 	return func(opts *PrinterOptions) {
 		opts.Time = time
@@ -104,7 +200,7 @@ func PrintTime(time bool) PrinterOption {
 
 // PrintTimeFormat sets the layout for formatting the time, if time printing is enabled.
 //
-// Example: print.PrintTimeFormat(time.RFC1123)
+// Example: fail.PrintTimeFormat(time.RFC1123)
 func PrintTimeFormat(timeFormat string) PrinterOption {
 	return func(opts *PrinterOptions) {
 		opts.TimeFormat = timeFormat
@@ -113,7 +209,7 @@ func PrintTimeFormat(timeFormat string) PrinterOption {
 
 // PrintAssociated enables or disables printing associated (non-causal) errors.
 //
-// Example: print.PrintAssociated(false)
+// Example: fail.PrintAssociated(false)
 func PrintAssociated(associated bool) PrinterOption {
 	return func(opts *PrinterOptions) {
 		opts.Associated = associated
@@ -122,25 +218,85 @@ func PrintAssociated(associated bool) PrinterOption {
 
 // PrintCauses enables or disables printing direct causes of the error.
 //
-// Example: print.PrintCauses(false)
+// Example: fail.PrintCauses(false)
 func PrintCauses(causes bool) PrinterOption {
 	return func(opts *PrinterOptions) {
 		opts.Causes = causes
 	}
 }
 
-// PrintCauseDepth sets the recursion depth of causes to print.
+// PrintCauseDepth sets the number of levels of causes to print.
 //
-// Example: print.PrintTags(false)
+// The depth counts the levels below the printed error: with a depth of 1, only its direct causes are
+// printed, with a depth of 2, their causes as well, and so on. A depth of 0 prints all causes, up to
+// MaxDepth. All printers interpret the depth the same way.
+//
+// Example: fail.PrintCauseDepth(2)
 func PrintCauseDepth(depth int) PrinterOption {
 	return func(opts *PrinterOptions) {
 		opts.CauseDepth = depth
 	}
 }
 
+// PrintCauseLabels enables or disables printing the labels of causes.
+//
+// Example: fail.PrintCauseLabels(false)
+func PrintCauseLabels(labels bool) PrinterOption {
+	return func(opts *PrinterOptions) {
+		opts.CauseLabels = labels
+	}
+}
+
+// PrintWidth sets the maximum line width, wrapping longer lines at word boundaries.
+//
+// A width of 0 disables wrapping.
+//
+// Example: fail.PrintWidth(80)
+func PrintWidth(width int) PrinterOption {
+	return func(opts *PrinterOptions) {
+		opts.Width = width
+	}
+}
+
+// PrintHyperlinks enables or disables rendering links as OSC 8 terminal hyperlinks.
+//
+// Example: fail.PrintHyperlinks(true)
+func PrintHyperlinks(hyperlinks bool) PrinterOption {
+	return func(opts *PrinterOptions) {
+		opts.Hyperlinks = hyperlinks
+	}
+}
+
+// PrintCompact enables or disables the single-line mode of the pretty printer.
+//
+// In compact mode, an error and its causes are printed on a single line, separated by " <- ",
+// with key metadata such as the code and domain in brackets after each message. This is
+// designed for grepping logs when JSON is overkill.
+//
+// Example: fail.PrintCompact(true)
+func PrintCompact(compact bool) PrinterOption {
+	return func(opts *PrinterOptions) {
+		opts.Compact = compact
+	}
+}
+
+// PrintStable enables or disables the deterministic mode of the pretty, CLI and JSON printers.
+//
+// In deterministic mode, colors and hyperlinks are stripped, timestamps and durations are omitted,
+// source locations are reduced to the base name of the file, and causes and tags are sorted, so that
+// the output of the same error is identical across runs and machines. This is designed for comparing
+// printer output against golden files in tests.
+//
+// Example: fail.PrintStable(true)
+func PrintStable(stable bool) PrinterOption {
+	return func(opts *PrinterOptions) {
+		opts.Stable = stable
+	}
+}
+
 // PrintTags enables or disables printing error tags.
 //
-// Example: print.PrintTags(false)
+// Example: fail.PrintTags(false)
 func PrintTags(tags bool) PrinterOption {
 	return func(opts *PrinterOptions) {
 		opts.Tags = tags
@@ -149,7 +305,7 @@ func PrintTags(tags bool) PrinterOption {
 
 // PrintAttributes enables or disables printing error attributes.
 //
-// Example: print.PrintAttributes(false)
+// Example: fail.PrintAttributes(false)
 func PrintAttributes(attributes bool) PrinterOption {
 	return func(opts *PrinterOptions) {
 		opts.Attributes = attributes
@@ -158,7 +314,7 @@ func PrintAttributes(attributes bool) PrinterOption {
 
 // PrintCode enables or disables printing the error code.
 //
-// Example: print.PrintCode(false)
+// Example: fail.PrintCode(false)
 func PrintCode(code bool) PrinterOption {
 	return func(opts *PrinterOptions) {
 		opts.Code = code
@@ -167,7 +323,7 @@ func PrintCode(code bool) PrinterOption {
 
 // PrintDomain enables or disables printing the error domain.
 //
-// Example: print.PrintDomain(false)
+// Example: fail.PrintDomain(false)
 func PrintDomain(domain bool) PrinterOption {
 	return func(opts *PrinterOptions) {
 		opts.Domain = domain
@@ -176,7 +332,7 @@ func PrintDomain(domain bool) PrinterOption {
 
 // PrintExitCode enables or disables printing the process exit code.
 //
-// Example: print.PrintExitCode(false)
+// Example: fail.PrintExitCode(false)
 func PrintExitCode(exitCode bool) PrinterOption {
 	return func(opts *PrinterOptions) {
 		opts.ExitCode = exitCode
@@ -185,7 +341,7 @@ func PrintExitCode(exitCode bool) PrinterOption {
 
 // PrintHttpStatusCode enables or disables printing the HTTP status code.
 //
-// Example: print.PrintHttpStatusCode(false)
+// Example: fail.PrintHttpStatusCode(false)
 func PrintHttpStatusCode(httpStatusCode bool) PrinterOption {
 	return func(opts *PrinterOptions) {
 		opts.HttpStatusCode = httpStatusCode
@@ -194,7 +350,7 @@ func PrintHttpStatusCode(httpStatusCode bool) PrinterOption {
 
 // PrintUserMsg enables or disables printing the user-facing message.
 //
-// Example: print.PrintUserMsg(false)
+// Example: fail.PrintUserMsg(false)
 func PrintUserMsg(userMsg bool) PrinterOption {
 	return func(opts *PrinterOptions) {
 		opts.UserMsg = userMsg
@@ -203,7 +359,7 @@ func PrintUserMsg(userMsg bool) PrinterOption {
 
 // PrintTraceId enables or disables printing the trace ID.
 //
-// Example: print.PrintTraceId(false)
+// Example: fail.PrintTraceId(false)
 func PrintTraceId(traceId bool) PrinterOption {
 	return func(opts *PrinterOptions) {
 		opts.TraceId = traceId
@@ -212,9 +368,180 @@ func PrintTraceId(traceId bool) PrinterOption {
 
 // PrintSpanId enables or disables printing the span ID.
 //
-// Example: print.PrintSpanId(false)
+// Example: fail.PrintSpanId(false)
 func PrintSpanId(spanId bool) PrinterOption {
 	return func(opts *PrinterOptions) {
 		opts.SpanId = spanId
 	}
 }
+
+// PrintCorrelationId enables or disables printing the correlation ID.
+//
+// Example: fail.PrintCorrelationId(false)
+func PrintCorrelationId(correlationId bool) PrinterOption {
+	return func(opts *PrinterOptions) {
+		opts.CorrelationId = correlationId
+	}
+}
+
+// PrintId enables or disables printing the unique ID of the error instance.
+//
+// Example: fail.PrintId(false)
+func PrintId(id bool) PrinterOption {
+	return func(opts *PrinterOptions) {
+		opts.Id = id
+	}
+}
+
+// PrintSource enables or disables printing the source location of the error.
+//
+// Example: fail.PrintSource(false)
+func PrintSource(source bool) PrinterOption {
+	return func(opts *PrinterOptions) {
+		opts.Source = source
+	}
+}
+
+// PrintDuration enables or disables printing the duration of the failed operation.
+//
+// Example: fail.PrintDuration(false)
+func PrintDuration(duration bool) PrinterOption {
+	return func(opts *PrinterOptions) {
+		opts.Duration = duration
+	}
+}
+
+// PrintOp enables or disables printing the name of the failed operation.
+//
+// Example: fail.PrintOp(false)
+func PrintOp(op bool) PrinterOption {
+	return func(opts *PrinterOptions) {
+		opts.Op = op
+	}
+}
+
+// PrintRetryable enables or disables printing whether the error is retryable.
+//
+// Example: fail.PrintRetryable(false)
+func PrintRetryable(retryable bool) PrinterOption {
+	return func(opts *PrinterOptions) {
+		opts.Retryable = retryable
+	}
+}
+
+// PrintRetryAfter enables or disables printing the retry backoff.
+//
+// Example: fail.PrintRetryAfter(false)
+func PrintRetryAfter(retryAfter bool) PrinterOption {
+	return func(opts *PrinterOptions) {
+		opts.RetryAfter = retryAfter
+	}
+}
+
+// PrintHelpURL enables or disables printing the documentation link.
+//
+// Example: fail.PrintHelpURL(false)
+func PrintHelpURL(helpURL bool) PrinterOption {
+	return func(opts *PrinterOptions) {
+		opts.HelpURL = helpURL
+	}
+}
+
+// PrintFields enables or disables printing the field-level details of validation failures.
+//
+// Example: fail.PrintFields(false)
+func PrintFields(fields bool) PrinterOption {
+	return func(opts *PrinterOptions) {
+		opts.Fields = fields
+	}
+}
+
+// PrintOnlyDomains restricts the printed causes and associated errors to those of the provided domains.
+//
+// Causes and associated errors of other domains, as determined by Domain, are omitted together with
+// their own causes. The printed error itself is always printed. Calling PrintOnlyDomains without
+// domains removes the restriction.
+//
+// Example: fail.PrintOnlyDomains(fail.DomainDatabase, fail.DomainValidation)
+func PrintOnlyDomains(domains ...string) PrinterOption {
+	return func(opts *PrinterOptions) {
+		opts.OnlyDomains = slices.Clone(domains)
+	}
+}
+
+// PrintExcludeTags omits the causes and associated errors carrying any of the provided tags from printing.
+//
+// Omitted errors are omitted together with their own causes. The printed error itself is always printed.
+// This makes large aggregated errors readable by filtering out infrastructure noise.
+//
+// Example: fail.PrintExcludeTags(fail.TagNetwork, fail.TagTimeout)
+func PrintExcludeTags(tags ...string) PrinterOption {
+	return func(opts *PrinterOptions) {
+		opts.ExcludeTags = slices.Clone(tags)
+	}
+}
+
+// PrintValueFormatter sets the formatter rendering attribute values as text.
+//
+// Passing nil restores the default formatter, FormatValue.
+//
+// Example: fail.PrintValueFormatter(myFormatter)
+func PrintValueFormatter(formatter ValueFormatter) PrinterOption {
+	return func(opts *PrinterOptions) {
+		opts.ValueFormatter = formatter
+	}
+}
+
+// PrintMaxValueLength sets the maximum length, in bytes, of rendered attribute values.
+//
+// Longer values are cut at a UTF-8 character boundary and TruncationMarker is appended.
+// A length of 0 disables truncation.
+//
+// Example: fail.PrintMaxValueLength(80)
+func PrintMaxValueLength(length int) PrinterOption {
+	return func(opts *PrinterOptions) {
+		opts.MaxValueLength = max(length, 0)
+	}
+}
+
+// filterPrinted returns the errors of errs that are printed according to the OnlyDomains and ExcludeTags
+// options, together with their aligned labels.
+//
+// If no error is filtered, errs and labels are returned as-is; otherwise the provided slices are not modified.
+func filterPrinted(errs []error, labels labelList, opts PrinterOptions) ([]error, labelList) {
+	if len(opts.OnlyDomains) == 0 && len(opts.ExcludeTags) == 0 {
+		return errs, labels
+	}
+
+	res := make([]error, 0, len(errs))
+	var resLabels labelList
+	for i, err := range errs {
+		if !printed(err, opts) {
+			continue
+		}
+
+		resLabels = resLabels.with(len(res), labels.at(i))
+		res = append(res, err)
+	}
+
+	if len(res) == len(errs) {
+		return errs, labels
+	}
+
+	return res, resLabels
+}
+
+// printed reports whether err passes the OnlyDomains and ExcludeTags options.
+func printed(err error, opts PrinterOptions) bool {
+	if len(opts.OnlyDomains) > 0 && !slices.Contains(opts.OnlyDomains, Domain(err)) {
+		return false
+	}
+
+	for _, tag := range opts.ExcludeTags {
+		if hasOwnTag(err, tag) {
+			return false
+		}
+	}
+
+	return true
+}
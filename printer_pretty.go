@@ -1,21 +1,28 @@
 package fail
 
 import (
+	"cmp"
+	"io"
+	"path/filepath"
+	"slices"
+	"strconv"
 	"strings"
+	"unicode/utf8"
 )
 
 // PrintPretty prints a human-readable string representation of the provided error to standard output.
 //
 // This function uses the default PrettyPrinter to format the error. It is suitable
 // for displaying errors in logs, user interfaces, or diagnostics where a readable
-// format is desired.
+// format is desired. The printer options set for the domain of the error using SetDomainPrinterOptions
+// are applied before the provided options.
 //
 // Example:
 //
 //	err := fail.New().Msg("something went wrong")
-//	print.PrintPretty(err)
+//	fail.PrintPretty(err)
 func PrintPretty(err error, opts ...PrinterOption) {
-	println(PrintsPretty(err, opts...))
+	println(PrintsPretty(err, withDomainPrinterOptions(err, opts)...))
 }
 
 // PrintsPretty returns a human-readable string representation of the provided error.
@@ -27,47 +34,303 @@ func PrintPretty(err error, opts ...PrinterOption) {
 // Example:
 //
 //	err := fail.New().Msg("something went wrong")
-//	out := print.PrintsPretty(err)
+//	out := fail.PrintsPretty(err)
 func PrintsPretty(err error, opts ...PrinterOption) string {
 	return PrettyPrinter(opts...).Print(err)
 }
 
+// FprintPretty writes a human-readable representation of the provided error to w.
+//
+// The output is streamed to the writer and is identical to the result of PrintsPretty.
+// It returns the first error encountered while writing.
+//
+// Example:
+//
+//	err := fail.FprintPretty(os.Stderr, someErr)
+func FprintPretty(w io.Writer, err error, opts ...PrinterOption) error {
+	return PrettyPrinter(opts...).PrintTo(w, err)
+}
+
 // PrettyPrinter returns a Printer that formats errors in a human-readable way.
 //
 // The returned Printer uses the provided PrinterOptions to control which fields
 // are included in the output, such as causes, associated errors, codes, tags, etc.
 // This is useful for customizing error output for logs or user interfaces.
+// The returned Printer also implements WriterPrinter.
 //
 // Example:
 //
-//	printer := print.PrettyPrinter(print.WithoutColor())
+//	printer := fail.PrettyPrinter(fail.PrintColor(false))
 //	out := printer.Print(err)
-func PrettyPrinter(opts ...PrinterOption) Printer {
+func PrettyPrinter(opts ...PrinterOption) WriterPrinter {
 	o := DefaultOptions()
 	for _, opt := range opts {
 		opt(&o)
 	}
 
-	return PrinterFunc(func(err error) string {
-		sb := strings.Builder{}
-		printPretty(&sb, 0, err, o)
+	return prettyPrinter{opts: o}
+}
 
-		return sb.String()
-	})
+// prettyPrinter is the WriterPrinter returned by PrettyPrinter.
+type prettyPrinter struct {
+	opts PrinterOptions
+}
+
+// Print returns the human-readable representation of err.
+func (p prettyPrinter) Print(err error) string {
+	sb := strings.Builder{}
+	_ = p.PrintTo(&sb, err)
+
+	return sb.String()
+}
+
+// PrintTo writes the human-readable representation of err to w.
+func (p prettyPrinter) PrintTo(w io.Writer, err error) error {
+	opts := p.opts
+	if opts.Stable {
+		stabilize(&opts)
+	}
+
+	pw := &printWriter{w: w}
+	if opts.Compact {
+		printCompact(pw, 0, "", err, opts, newVisitSet())
+		return pw.err
+	}
+
+	printPretty(pw, 0, "", err, opts, newVisitSet())
+
+	return pw.err
 }
 
 // printPretty formats the provided error as a human-readable string according to the given PrinterOptions.
 //
-// This is an internal helper used by PrettyPrinter and PrintPretty. Currently, it returns only the error message.
-// In the future, it may be extended to include more error metadata.
+// This is an internal helper used by PrettyPrinter and PrintPretty. It writes the error message followed by
+// the enabled metadata, each on its own indented line, and then recurses into the causes.
+// If label is not empty, it is printed in brackets before the message.
+// Causes are not printed beyond MaxDepth, and cycles in the cause graph are not followed.
 // TODO: improve logging
-func printPretty(sb *strings.Builder, depth int, err error, opts PrinterOptions) {
-	sb.WriteString(strings.Repeat("  ", depth) + Message(err))
+func printPretty(pw *printWriter, depth int, label string, err error, opts PrinterOptions, visited visitSet) {
+	head := ""
+	if label != "" {
+		head += "[" + label + "] "
+	}
+	if opts.Op {
+		if op := Op(err); op != "" {
+			head += op + ": "
+		}
+	}
+	pw.WriteString(wrapLine(strings.Repeat("  ", depth), head+Message(err), opts.Width))
+
+	if opts.Source {
+		if source := Source(err); !source.IsZero() {
+			if opts.Stable {
+				source.File = filepath.Base(source.File)
+			}
+			printPrettyLine(pw, opts, depth+1, "at "+source.String())
+		}
+	}
+
+	if opts.CorrelationId {
+		if c, ok := err.(ErrorCorrelationId); ok && c.ErrorCorrelationId() != "" {
+			printPrettyLine(pw, opts, depth+1, "correlation id: "+c.ErrorCorrelationId())
+		}
+	}
+
+	if opts.Id && depth == 0 {
+		if id := Id(err); id != "" {
+			printPrettyLine(pw, opts, depth+1, "error id: "+id)
+		}
+	}
+
+	if opts.Fields {
+		for _, field := range Fields(err) {
+			line := "field " + field.String()
+			if field.Code != "" {
+				line += " (" + field.Code + ")"
+			}
+			printPrettyLine(pw, opts, depth+1, line)
+		}
+	}
+
+	if opts.HelpURL {
+		if helpURL := HelpURL(err); helpURL != "" && opts.Hyperlinks {
+			pw.WriteString("\n" + strings.Repeat("  ", depth+1) + "see " + hyperlink(helpURL, helpURL))
+		} else if helpURL != "" {
+			printPrettyLine(pw, opts, depth+1, "see "+helpURL)
+		}
+	}
+
+	if opts.Duration {
+		if duration := Duration(err); duration > 0 {
+			printPrettyLine(pw, opts, depth+1, "duration: "+duration.String())
+		}
+	}
+
+	if opts.Retryable {
+		if r, ok := err.(ErrorRetryable); ok && r.ErrorRetryable() {
+			printPrettyLine(pw, opts, depth+1, "retryable")
+		} else if t, ok := err.(ErrorTransience); ok && t.ErrorTransience() == TransiencePermanent {
+			printPrettyLine(pw, opts, depth+1, "permanent")
+		}
+	}
+
+	if opts.RetryAfter {
+		if r, ok := err.(ErrorRetryAfter); ok && r.ErrorRetryAfter() > 0 {
+			printPrettyLine(pw, opts, depth+1, "retry after: "+r.ErrorRetryAfter().String())
+		}
+	}
+
+	if depth >= MaxDepth() || !visited.enter(err) {
+		return
+	}
+	defer visited.leave(err)
+
+	if opts.Causes && (opts.CauseDepth == 0 || depth < opts.CauseDepth) {
+		var labels labelList
+		if opts.CauseLabels {
+			labels = CauseLabels(err)
+		}
 
-	if opts.Causes && (opts.CauseDepth == 0 || depth <= opts.CauseDepth) {
-		for _, cause := range Causes(err) {
-			sb.WriteRune('\n')
-			printPretty(sb, depth+1, cause, opts)
+		causes, labels := filterPrinted(limitWidth(Causes(err)), labels, opts)
+		if opts.Stable {
+			causes, labels = sortCauses(causes, labels)
+		}
+
+		for i, cause := range causes {
+			pw.WriteString("\n")
+			printPretty(pw, depth+1, labels.at(i), cause, opts, visited)
+		}
+	}
+}
+
+// printCompact formats the provided error and its causes on a single line according to the given PrinterOptions.
+//
+// This is an internal helper used by PrettyPrinter in compact mode. The message of each error is followed
+// by its key metadata in brackets, and causes are appended depth-first, separated by " <- ".
+// Causes are not printed beyond MaxDepth, and cycles in the cause graph are not followed.
+func printCompact(pw *printWriter, depth int, label string, err error, opts PrinterOptions, visited visitSet) {
+	if label != "" {
+		pw.WriteString("[" + label + "] ")
+	}
+	if opts.Op {
+		if op := Op(err); op != "" {
+			pw.WriteString(op + ": ")
+		}
+	}
+	pw.WriteString(Message(err))
+
+	var meta []string
+	if c, ok := err.(ErrorCode); opts.Code && ok && c.ErrorCode() != "" && c.ErrorCode() != ErrCodeUnspecified {
+		meta = append(meta, "code="+c.ErrorCode())
+	}
+	if d, ok := err.(ErrorDomain); opts.Domain && ok && d.ErrorDomain() != "" {
+		meta = append(meta, "domain="+d.ErrorDomain())
+	}
+	if h, ok := err.(ErrorHttpStatusCode); opts.HttpStatusCode && ok && h.ErrorHttpStatusCode() != 0 && h.ErrorHttpStatusCode() != DefaultHttpStatusCode {
+		meta = append(meta, "status="+strconv.Itoa(h.ErrorHttpStatusCode()))
+	}
+	if t, ok := err.(ErrorTraceId); opts.TraceId && ok && t.ErrorTraceId() != "" {
+		meta = append(meta, "trace_id="+t.ErrorTraceId())
+	}
+	if c, ok := err.(ErrorCorrelationId); opts.CorrelationId && ok && c.ErrorCorrelationId() != "" {
+		meta = append(meta, "correlation_id="+c.ErrorCorrelationId())
+	}
+	if id := Id(err); opts.Id && depth == 0 && id != "" {
+		meta = append(meta, "error_id="+id)
+	}
+	if r, ok := err.(ErrorRetryable); opts.Retryable && ok && r.ErrorRetryable() {
+		meta = append(meta, "retryable")
+	}
+	if len(meta) > 0 {
+		pw.WriteString(" [" + strings.Join(meta, " ") + "]")
+	}
+
+	if depth >= MaxDepth() || !visited.enter(err) {
+		return
+	}
+	defer visited.leave(err)
+
+	if opts.Causes && (opts.CauseDepth == 0 || depth < opts.CauseDepth) {
+		var labels labelList
+		if opts.CauseLabels {
+			labels = CauseLabels(err)
+		}
+
+		causes, labels := filterPrinted(limitWidth(Causes(err)), labels, opts)
+		if opts.Stable {
+			causes, labels = sortCauses(causes, labels)
+		}
+
+		for i, cause := range causes {
+			pw.WriteString(" <- ")
+			printCompact(pw, depth+1, labels.at(i), cause, opts, visited)
 		}
 	}
 }
+
+// sortCauses returns causes and their aligned labels sorted by label and message, for deterministic output.
+//
+// The provided slices are not modified.
+func sortCauses(causes []error, labels labelList) ([]error, labelList) {
+	order := make([]int, len(causes))
+	for i := range order {
+		order[i] = i
+	}
+	slices.SortStableFunc(order, func(a, b int) int {
+		return cmp.Or(cmp.Compare(labels.at(a), labels.at(b)), cmp.Compare(Message(causes[a]), Message(causes[b])))
+	})
+
+	sorted := make([]error, len(causes))
+	var sortedLabels labelList
+	for i, j := range order {
+		sorted[i] = causes[j]
+		sortedLabels = sortedLabels.with(i, labels.at(j))
+	}
+
+	return sorted, sortedLabels
+}
+
+// printPrettyLine writes line on a new line, indented according to depth and wrapped to opts.Width.
+func printPrettyLine(pw *printWriter, opts PrinterOptions, depth int, line string) {
+	pw.WriteString("\n" + wrapLine(strings.Repeat("  ", depth), line, opts.Width))
+}
+
+// wrapLine prefixes line with indent and wraps it at word boundaries so that no line exceeds width characters.
+//
+// Continuation lines are indented by two more spaces than the first line. Words longer than the
+// available width are not split. A width of zero or less disables wrapping.
+func wrapLine(indent, line string, width int) string {
+	if width <= 0 || utf8.RuneCountInString(indent+line) <= width {
+		return indent + line
+	}
+
+	sb := strings.Builder{}
+	sb.WriteString(indent)
+	col := utf8.RuneCountInString(indent)
+	lineStart := true
+
+	for _, word := range strings.Fields(line) {
+		n := utf8.RuneCountInString(word)
+		if !lineStart && col+1+n > width {
+			sb.WriteString("\n" + indent + "  ")
+			col = utf8.RuneCountInString(indent) + 2
+			lineStart = true
+		}
+
+		if !lineStart {
+			sb.WriteString(" ")
+			col++
+		}
+
+		sb.WriteString(word)
+		col += n
+		lineStart = false
+	}
+
+	return sb.String()
+}
+
+// hyperlink returns text as an OSC 8 terminal hyperlink pointing to url.
+func hyperlink(url, text string) string {
+	return "\x1b]8;;" + url + "\x1b\\" + text + "\x1b]8;;\x1b\\"
+}
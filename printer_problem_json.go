@@ -0,0 +1,265 @@
+package fail
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"regexp"
+	"strings"
+)
+
+// ProblemJsonContentType is the media type of RFC 7807 problem details documents.
+const ProblemJsonContentType = "application/problem+json"
+
+// Attribute keys used to preserve RFC 7807 members that have no dedicated error field.
+const (
+	// ProblemTypeAttribute is the attribute key for a problem type URI that is not a valid error code.
+	ProblemTypeAttribute = "problem_type"
+	// ProblemInstanceAttribute is the attribute key for the problem instance URI.
+	ProblemInstanceAttribute = "problem_instance"
+)
+
+// problemMembers are the members defined by RFC 7807, which must not be overwritten by extension members.
+var problemMembers = map[string]struct{}{
+	"type":     {},
+	"title":    {},
+	"status":   {},
+	"detail":   {},
+	"instance": {},
+}
+
+// codePattern matches valid error codes, consisting only of letters, numbers, and underscores.
+var codePattern = regexp.MustCompile(`^[A-Za-z0-9_]+$`)
+
+// PrintProblemJson prints an RFC 7807 application/problem+json representation of the provided error to standard output.
+//
+// Example:
+//
+//	err := fail.New().Code(fail.ErrCodeNotFound).HttpStatusCode(404).Msg("user not found")
+//	fail.PrintProblemJson(err)
+func PrintProblemJson(err error, opts ...PrinterOption) {
+	println(PrintsProblemJson(err, opts...))
+}
+
+// PrintsProblemJson returns an RFC 7807 application/problem+json representation of the provided error.
+//
+// If the error is nil, this function returns the string "null" (the JSON null value).
+//
+// Example:
+//
+//	body := fail.PrintsProblemJson(err)
+func PrintsProblemJson(err error, opts ...PrinterOption) string {
+	return ProblemJsonPrinter(opts...).Print(err)
+}
+
+// FprintProblemJson writes an RFC 7807 application/problem+json representation of the provided error to w.
+//
+// It returns the first error encountered while encoding or writing.
+//
+// Example:
+//
+//	w.Header().Set("Content-Type", fail.ProblemJsonContentType)
+//	w.WriteHeader(fail.HttpStatusCode(err))
+//	_ = fail.FprintProblemJson(w, err)
+func FprintProblemJson(w io.Writer, err error, opts ...PrinterOption) error {
+	return ProblemJsonPrinter(opts...).PrintTo(w, err)
+}
+
+// ProblemJsonPrinter returns a Printer that formats errors as RFC 7807 problem details documents.
+//
+// The members of the document are derived from the error as follows:
+//   - type: the error code, or "about:blank" if no code is set
+//   - title: the user-facing message, or the standard HTTP status text if there is none
+//   - status: the HTTP status code
+//   - detail: the developer-facing message
+//   - instance: the ProblemInstanceAttribute attribute, if set
+//   - help_url: the documentation link, if set
+//   - fields: the field errors of a validation failure, if any, see Builder.FieldErrors
+//   - error_id: the unique ID of the error instance, see fail.Id
+//   - extension members: the remaining attributes
+//
+// The PrinterOptions Code, UserMsg, HttpStatusCode, HelpURL, Fields, Id and Attributes control whether the corresponding
+// members are derived from the error. The returned Printer also implements WriterPrinter.
+//
+// Example:
+//
+//	printer := fail.ProblemJsonPrinter(fail.PrintAttributes(false))
+//	out := printer.Print(err)
+func ProblemJsonPrinter(opts ...PrinterOption) WriterPrinter {
+	o := DefaultOptions()
+	for _, opt := range opts {
+		opt(&o)
+	}
+
+	return problemJsonPrinter{opts: o}
+}
+
+// problemJsonPrinter is the WriterPrinter returned by ProblemJsonPrinter.
+type problemJsonPrinter struct {
+	opts PrinterOptions
+}
+
+// Print returns the problem details representation of err.
+func (p problemJsonPrinter) Print(err error) string {
+	sb := strings.Builder{}
+	if wErr := p.PrintTo(&sb, err); wErr != nil {
+		panic(wErr)
+	}
+
+	return sb.String()
+}
+
+// PrintTo encodes the problem details representation of err into w.
+func (p problemJsonPrinter) PrintTo(w io.Writer, err error) error {
+	if err == nil {
+		_, wErr := io.WriteString(w, "null")
+		return wErr
+	}
+
+	enc := json.NewEncoder(&trimNewlineWriter{w: w})
+	enc.SetIndent("", strings.Repeat(" ", p.opts.Indent))
+
+	return enc.Encode(printProblemJson(err, p.opts))
+}
+
+// printProblemJson collects the problem details members of the provided error into a map according to the given PrinterOptions.
+func printProblemJson(err error, o PrinterOptions) map[string]any {
+	data := map[string]any{}
+	attrs := Attributes(err)
+
+	if o.Attributes {
+		for k, v := range attrs {
+			if _, reserved := problemMembers[k]; !reserved && k != ProblemTypeAttribute && k != ProblemInstanceAttribute {
+				data[k] = v
+			}
+		}
+
+		if instance, ok := attrs[ProblemInstanceAttribute].(string); ok && instance != "" {
+			data["instance"] = instance
+		}
+	}
+
+	data["type"] = "about:blank"
+	if o.Code {
+		if code := Code(err); code != "" && code != ErrCodeUnspecified {
+			data["type"] = code
+		} else if problemType, ok := attrs[ProblemTypeAttribute].(string); ok && problemType != "" {
+			data["type"] = problemType
+		}
+	}
+
+	status := HttpStatusCode(err)
+	if o.HttpStatusCode {
+		data["status"] = status
+	}
+
+	title := ""
+	if o.UserMsg {
+		if userMsg, ok := err.(ErrorUserMessage); ok {
+			title = userMsg.ErrorUserMessage()
+		}
+	}
+	if title == "" {
+		title = http.StatusText(status)
+	}
+	if title != "" {
+		data["title"] = title
+	}
+
+	data["detail"] = Message(err)
+
+	if o.HelpURL {
+		if helpURL := HelpURL(err); helpURL != "" {
+			data["help_url"] = helpURL
+		}
+	}
+
+	if o.Fields {
+		if fields := Fields(err); len(fields) > 0 {
+			data["fields"] = fields
+		}
+	}
+
+	if o.Id {
+		if id := Id(err); id != "" {
+			data["error_id"] = id
+		}
+	}
+
+	return data
+}
+
+// FromProblemJson parses an RFC 7807 problem details document into a Fail.
+//
+// The members of the document are mapped back to the error as follows:
+//   - detail: the developer-facing message (or title, if detail is missing)
+//   - title: the user-facing message
+//   - status: the HTTP status code
+//   - type: the error code if it is a valid code, otherwise the ProblemTypeAttribute attribute
+//   - instance: the ProblemInstanceAttribute attribute
+//   - help_url: the documentation link
+//   - fields: the field errors, see Builder.FieldErrors
+//   - error_id: the unique ID of the error instance
+//   - extension members: attributes
+//
+// Example:
+//
+//	f, err := fail.FromProblemJson(body)
+func FromProblemJson(data []byte) (Fail, error) {
+	var members map[string]any
+	if err := json.Unmarshal(data, &members); err != nil {
+		return Fail{}, err
+	}
+
+	title, _ := members["title"].(string)
+	detail, _ := members["detail"].(string)
+	problemType, _ := members["type"].(string)
+	instance, _ := members["instance"].(string)
+	status, _ := members["status"].(float64)
+	helpURL, _ := members["help_url"].(string)
+	id, _ := members["error_id"].(string)
+
+	// Field errors that cannot be decoded are dropped rather than failing the whole document.
+	var fields struct {
+		Fields []FieldError `json:"fields"`
+	}
+	_ = json.Unmarshal(data, &fields)
+
+	extensions := make(map[string]any, len(members))
+	for k, v := range members {
+		if _, reserved := problemMembers[k]; !reserved && k != "help_url" && k != "fields" && k != "error_id" {
+			extensions[k] = v
+		}
+	}
+
+	b := New().
+		UserMsg(title).
+		HttpStatusCode(int(status)).
+		HelpURL(helpURL).
+		FieldErrors(fields.Fields...).
+		Id(id).
+		AttributeMap(extensions)
+
+	if problemType != "" && problemType != "about:blank" {
+		if codePattern.MatchString(problemType) {
+			b = b.Code(problemType)
+		} else {
+			b = b.Attribute(ProblemTypeAttribute, problemType)
+		}
+	}
+
+	if instance != "" {
+		b = b.Attribute(ProblemInstanceAttribute, instance)
+	}
+
+	switch {
+	case detail != "":
+		b.msg = detail
+	case title != "":
+		b.msg = title
+	default:
+		b.msg = EmptyMessage
+	}
+
+	return b.asFail(), nil
+}
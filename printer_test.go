@@ -0,0 +1,45 @@
+package fail_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/FlowSeer/fail"
+)
+
+func TestPrintCauseDepth(t *testing.T) {
+	err := fail.New().
+		Cause(fail.New().Cause(fail.New().Msg("second level")).Msg("first level")).
+		Msg("root")
+
+	printers := []struct {
+		name  string
+		print func(err error, opts ...fail.PrinterOption) string
+	}{
+		{name: "pretty", print: fail.PrintsPretty},
+		{name: "compact", print: func(err error, opts ...fail.PrinterOption) string {
+			return fail.PrintsPretty(err, append(opts, fail.PrintCompact(true))...)
+		}},
+		{name: "cli", print: func(err error, opts ...fail.PrinterOption) string {
+			// The summary line is the full error chain; only the details below it honor the depth.
+			_, details, _ := strings.Cut(fail.PrintsCLI(err, opts...), "\n\n")
+			return details
+		}},
+		{name: "json", print: fail.PrintsJson},
+		{name: "markdown", print: fail.PrintsMarkdown},
+		{name: "html", print: fail.PrintsHTML},
+		{name: "dot", print: fail.PrintsDot},
+	}
+
+	for _, p := range printers {
+		t.Run(p.name, func(t *testing.T) {
+			got := p.print(err, fail.PrintCauseDepth(1))
+			if !strings.Contains(got, "first level") {
+				t.Errorf("direct cause missing from output:\n%s", got)
+			}
+			if strings.Contains(got, "second level") {
+				t.Errorf("cause beyond depth 1 printed:\n%s", got)
+			}
+		})
+	}
+}
@@ -0,0 +1,113 @@
+package fail
+
+import (
+	"encoding/hex"
+	"fmt"
+	"reflect"
+	"time"
+	"unicode"
+	"unicode/utf8"
+)
+
+// DefaultMaxValueLength is the default maximum length, in bytes, of attribute values rendered by the printers.
+const DefaultMaxValueLength = 256
+
+// ValueFormatter renders an attribute value as text for the printers.
+//
+// The result is truncated to PrinterOptions.MaxValueLength by the printers, so formatters do not need to
+// limit its length. Formatters may call FormatValue for the values they do not handle themselves.
+//
+// Example:
+//
+//	fail.PrintsMarkdown(err, fail.PrintValueFormatter(func(value any) string {
+//		if u, ok := value.(*url.URL); ok {
+//			return u.Redacted()
+//		}
+//
+//		return fail.FormatValue(value)
+//	}))
+type ValueFormatter func(value any) string
+
+// FormatValue renders an attribute value as text; it is the ValueFormatter used by the printers by default.
+//
+// Values are rendered as follows:
+//   - strings as-is
+//   - byte slices as text if they hold printable UTF-8, and in hexadecimal otherwise
+//   - time.Time in RFC 3339 format, or in PrinterOptions.TimeFormat when rendered by a printer
+//   - time.Duration, fmt.Stringer and error values using their String, Error or Message methods
+//   - structs and pointers to structs prefixed by their type, as in "main.User{ID:42 Name:gopher}"
+//   - all other values using fmt.Sprint
+func FormatValue(value any) string {
+	return formatValue(value, time.RFC3339)
+}
+
+// formatValue implements FormatValue, rendering times using timeFormat.
+func formatValue(value any, timeFormat string) string {
+	switch v := value.(type) {
+	case nil:
+		return "<nil>"
+	case string:
+		return v
+	case []byte:
+		if printableUTF8(v) {
+			return string(v)
+		}
+
+		return "0x" + hex.EncodeToString(v)
+	case time.Time:
+		return v.Format(timeFormat)
+	case time.Duration:
+		return v.String()
+	case error:
+		return Message(v)
+	case fmt.Stringer:
+		return v.String()
+	}
+
+	rv := reflect.ValueOf(value)
+	switch {
+	case rv.Kind() == reflect.Struct:
+		return fmt.Sprintf("%T%+v", value, value)
+	case rv.Kind() == reflect.Pointer && rv.IsNil():
+		return "<nil>"
+	case rv.Kind() == reflect.Pointer && rv.Elem().Kind() == reflect.Struct:
+		return fmt.Sprintf("&%T%+v", rv.Elem().Interface(), rv.Elem().Interface())
+	}
+
+	return fmt.Sprint(value)
+}
+
+// printableUTF8 reports whether b holds valid UTF-8 text without control characters other than whitespace.
+func printableUTF8(b []byte) bool {
+	if !utf8.Valid(b) {
+		return false
+	}
+
+	for _, r := range string(b) {
+		if !unicode.IsPrint(r) && !unicode.IsSpace(r) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// printValue renders an attribute value using the ValueFormatter of o, truncated to its MaxValueLength.
+func printValue(value any, o PrinterOptions) string {
+	var s string
+	if o.ValueFormatter != nil {
+		s = o.ValueFormatter(value)
+	} else {
+		timeFormat := time.RFC3339
+		if o.TimeFormat != "" {
+			timeFormat = o.TimeFormat
+		}
+		s = formatValue(value, timeFormat)
+	}
+
+	if o.MaxValueLength > 0 {
+		s, _ = truncateString(s, o.MaxValueLength)
+	}
+
+	return s
+}
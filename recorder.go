@@ -0,0 +1,167 @@
+package fail
+
+import (
+	"encoding/json"
+	"io"
+	"sync"
+	"time"
+)
+
+// DefaultRecorderSize is the number of errors kept by a Recorder created with a non-positive size.
+const DefaultRecorderSize = 100
+
+// Recorder is an in-memory flight recorder keeping the most recent errors of a long-running service.
+//
+// A Recorder is a ring buffer: once it is full, recording an error discards the oldest one. The recorded
+// errors can be dumped as text using Dump or as JSON using DumpJson or encoding/json, for example from
+// an HTTP debug endpoint. A Recorder is safe for concurrent use and must be
+// created using NewRecorder.
+//
+// Example:
+//
+//	recorder := fail.NewRecorder(200)
+//
+//	if err := handle(req); err != nil {
+//		recorder.Record(err)
+//	}
+//
+//	_ = recorder.Dump(os.Stderr, nil)
+type Recorder struct {
+	mu      sync.Mutex
+	entries []RecordedError
+	next    int  // Index of the slot the next error is recorded in
+	full    bool // Whether all slots hold an error
+}
+
+// RecordedError is an error kept by a Recorder, along with the time it was recorded.
+type RecordedError struct {
+	// Time is the time the error was recorded.
+	Time time.Time
+	// Err is the recorded error.
+	Err error
+}
+
+// MarshalJSON encodes the recorded error as an object with the fields "time", and "error"
+// holding the document produced by the JSON printer.
+func (r RecordedError) MarshalJSON() ([]byte, error) {
+	return json.Marshal(map[string]any{
+		"time":  r.Time.Format(time.RFC3339Nano),
+		"error": printJson(r.Err, DefaultOptions()),
+	})
+}
+
+// NewRecorder creates a Recorder keeping the last size errors.
+//
+// If size is not positive, DefaultRecorderSize is used.
+//
+// Example:
+//
+//	recorder := fail.NewRecorder(50)
+func NewRecorder(size int) *Recorder {
+	if size <= 0 {
+		size = DefaultRecorderSize
+	}
+
+	return &Recorder{entries: make([]RecordedError, size)}
+}
+
+// Size returns the maximum number of errors kept by the recorder.
+func (r *Recorder) Size() int {
+	return len(r.entries)
+}
+
+// Record records err, discarding the oldest recorded error if the recorder is full.
+//
+// Nil errors are ignored.
+func (r *Recorder) Record(err error) {
+	if err == nil {
+		return
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.entries[r.next] = RecordedError{Time: time.Now(), Err: err}
+	r.next++
+	if r.next == len(r.entries) {
+		r.next = 0
+		r.full = true
+	}
+}
+
+// Errors returns the recorded errors, oldest first.
+func (r *Recorder) Errors() []RecordedError {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if !r.full {
+		return append(make([]RecordedError, 0, r.next), r.entries[:r.next]...)
+	}
+
+	res := make([]RecordedError, 0, len(r.entries))
+	res = append(res, r.entries[r.next:]...)
+	return append(res, r.entries[:r.next]...)
+}
+
+// Len returns the number of recorded errors.
+func (r *Recorder) Len() int {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.full {
+		return len(r.entries)
+	}
+
+	return r.next
+}
+
+// Reset discards all recorded errors.
+func (r *Recorder) Reset() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	clear(r.entries)
+	r.next = 0
+	r.full = false
+}
+
+// Dump writes the recorded errors to w, oldest first, each preceded by the time it was recorded.
+//
+// The errors are formatted using p. If p is nil, the PrettyPrinter with default options is used.
+// It returns the first error encountered while writing.
+//
+// Example:
+//
+//	_ = recorder.Dump(os.Stderr, fail.PrettyPrinter(fail.PrintSource(false)))
+func (r *Recorder) Dump(w io.Writer, p Printer) error {
+	if p == nil {
+		p = PrettyPrinter()
+	}
+
+	pw := &printWriter{w: w}
+	for _, e := range r.Errors() {
+		pw.WriteString("[" + e.Time.Format(time.RFC3339Nano) + "]\n")
+		if pw.err == nil {
+			pw.err = PrintTo(w, p, e.Err)
+		}
+		pw.WriteString("\n\n")
+	}
+
+	return pw.err
+}
+
+// DumpJson writes the recorded errors to w as a JSON array, oldest first.
+//
+// Each element is encoded as described by RecordedError.MarshalJSON.
+//
+// Example:
+//
+//	_ = recorder.DumpJson(w)
+func (r *Recorder) DumpJson(w io.Writer) error {
+	return json.NewEncoder(w).Encode(r)
+}
+
+// MarshalJSON encodes the recorded errors as a JSON array, oldest first.
+func (r *Recorder) MarshalJSON() ([]byte, error) {
+	return json.Marshal(r.Errors())
+}
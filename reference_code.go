@@ -0,0 +1,54 @@
+package fail
+
+import (
+	"crypto/sha256"
+	"encoding/binary"
+)
+
+// referenceAlphabet is the Crockford base32 alphabet, which omits the easily confused letters I, L, O and U.
+const referenceAlphabet = "0123456789ABCDEFGHJKMNPQRSTVWXYZ"
+
+// ReferenceCode returns a short, human-friendly reference to the error instance described by the provided error.
+//
+// The reference is derived from the instance ID of the error (see Id) and consists of 8 characters of the
+// Crockford base32 alphabet in two groups, as in "E7K2-9QW1". Unlike the ID, it is short enough to be read
+// out over the phone or typed into a support form, and it avoids characters that are easily confused, such as
+// O and 0. The same ID always yields the same reference, and the reference is recorded in logs alongside the
+// ID, so the reference a user reports can be used to find the occurrence in the logs. References are not
+// guaranteed to be unique, but collisions are rare enough to identify an occurrence in practice.
+// If the error has no ID, ReferenceCode returns the empty string.
+//
+// Example:
+//
+//	fmt.Fprintf(w, "Something went wrong. Please contact support with reference %s.", fail.ReferenceCode(err))
+func ReferenceCode(err error) string {
+	return referenceCode(Id(err))
+}
+
+// ReferenceCode returns the reference code of the error instance described by the snapshot, see fail.ReferenceCode.
+func (s Snapshot) ReferenceCode() string {
+	return referenceCode(s.Id)
+}
+
+// referenceCode returns the reference code derived from the provided error instance ID.
+func referenceCode(id string) string {
+	if id == "" {
+		return ""
+	}
+
+	sum := sha256.Sum256([]byte(id))
+	bits := binary.BigEndian.Uint64(sum[:8]) >> 24
+
+	code := make([]byte, 9)
+	for i := len(code) - 1; i >= 0; i-- {
+		if i == 4 {
+			code[i] = '-'
+			continue
+		}
+
+		code[i] = referenceAlphabet[bits&31]
+		bits >>= 5
+	}
+
+	return string(code)
+}
@@ -0,0 +1,236 @@
+package fail
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"sync"
+)
+
+// SeverityAttribute is the attribute key under which the severity of a registered code is recorded.
+const SeverityAttribute = "severity"
+
+// Predefined severities for registered error codes.
+const (
+	// SeverityInfo indicates an expected error that requires no attention.
+	SeverityInfo = "info"
+	// SeverityWarning indicates an error that may require attention if it occurs frequently.
+	SeverityWarning = "warning"
+	// SeverityError indicates an error that requires attention.
+	SeverityError = "error"
+	// SeverityCritical indicates an error that requires immediate attention.
+	SeverityCritical = "critical"
+)
+
+// CodeInfo describes a known error code and the defaults applied to errors carrying it.
+//
+// Zero values mean that no default is applied for the respective field.
+type CodeInfo struct {
+	// Code is the error code described by this entry.
+	Code string `json:"code"`
+	// Description is a human-readable explanation of the code, intended for documentation.
+	Description string `json:"description,omitempty"`
+	// Domain is the default domain of errors with this code.
+	Domain string `json:"domain,omitempty"`
+	// HttpStatusCode is the default HTTP status code of errors with this code.
+	HttpStatusCode int `json:"http_status_code,omitempty"`
+	// ExitCode is the default process exit code of errors with this code.
+	ExitCode int `json:"exit_code,omitempty"`
+	// Severity is the severity of errors with this code, recorded under SeverityAttribute.
+	Severity string `json:"severity,omitempty"`
+	// UserMsg is the default user-facing message of errors with this code.
+	//
+	// The message may contain placeholders of the form {key}, which are replaced by the
+	// value of the error's attribute with the same key. Unknown placeholders are kept as-is.
+	UserMsg string `json:"user_msg,omitempty"`
+	// DocsURL is a link to the documentation of this code, used as the help URL of errors with this code.
+	DocsURL string `json:"docs_url,omitempty"`
+}
+
+// Registry holds the known error codes of an application.
+//
+// Errors built with a code present in the registry are completed with the registered defaults
+// for every field that has not been set or still holds its package default
+// (DefaultHttpStatusCode, DefaultExitCode). The zero value is an empty registry ready to use.
+// A Registry is safe for concurrent use.
+type Registry struct {
+	mu    sync.RWMutex
+	codes map[string]CodeInfo
+}
+
+// NewRegistry returns an empty Registry.
+func NewRegistry() *Registry {
+	return &Registry{}
+}
+
+// Register adds the provided code descriptions to the registry.
+//
+// Registering a code that is already present replaces the previous description.
+// Register panics if a description has an empty code, since this is always a programming error.
+//
+// Example:
+//
+//	registry.Register(fail.CodeInfo{
+//		Code:           "ERR_QUOTA_EXCEEDED",
+//		HttpStatusCode: 429,
+//		Severity:       fail.SeverityWarning,
+//		UserMsg:        "You have exceeded your quota of {quota} requests.",
+//		DocsURL:        "https://docs.example.com/errors/quota",
+//	})
+func (r *Registry) Register(infos ...CodeInfo) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for _, info := range infos {
+		if info.Code == "" {
+			panic("cannot register an empty error code")
+		}
+
+		if r.codes == nil {
+			r.codes = make(map[string]CodeInfo)
+		}
+
+		r.codes[info.Code] = info
+	}
+}
+
+// Lookup returns the description of the provided code and whether it is registered.
+func (r *Registry) Lookup(code string) (CodeInfo, bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	info, ok := r.codes[code]
+	return info, ok
+}
+
+// Catalog returns the descriptions of all registered codes, sorted by code.
+//
+// The result can be used to generate documentation of the errors an application may return.
+func (r *Registry) Catalog() []CodeInfo {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	res := make([]CodeInfo, 0, len(r.codes))
+	for _, info := range r.codes {
+		res = append(res, info)
+	}
+
+	slices.SortFunc(res, func(a, b CodeInfo) int {
+		return strings.Compare(a.Code, b.Code)
+	})
+
+	return res
+}
+
+// defaultRegistry is the registry consulted when errors are built.
+var defaultRegistry = NewRegistry()
+
+// DefaultRegistry returns the registry consulted when errors are built.
+func DefaultRegistry() *Registry {
+	return defaultRegistry
+}
+
+// Register adds the provided code descriptions to the default registry.
+//
+// Errors built with a registered code are completed with the registered defaults when Msg or Msgf
+// is called, for every field that has not been set or still holds its package default.
+// See Registry.Register for details.
+//
+// Example:
+//
+//	func init() {
+//		fail.Register(fail.CodeInfo{Code: "ERR_USER_NOT_FOUND", HttpStatusCode: 404, UserMsg: "User {user_id} not found."})
+//	}
+//
+//	err := fail.New().Code("ERR_USER_NOT_FOUND").Attribute("user_id", id).Msg("user lookup failed")
+//	// fail.HttpStatusCode(err) == 404
+func Register(infos ...CodeInfo) {
+	defaultRegistry.Register(infos...)
+}
+
+// Lookup returns the description of the provided code in the default registry and whether it is registered.
+func Lookup(code string) (CodeInfo, bool) {
+	return defaultRegistry.Lookup(code)
+}
+
+// Catalog returns the descriptions of all codes in the default registry, sorted by code.
+//
+// Example:
+//
+//	for _, info := range fail.Catalog() {
+//		fmt.Printf("| %s | %d | %s |\n", info.Code, info.HttpStatusCode, info.Description)
+//	}
+func Catalog() []CodeInfo {
+	return defaultRegistry.Catalog()
+}
+
+// applyCodeInfo completes the builder with the registered defaults of its code.
+func (b Builder) applyCodeInfo() Builder {
+	if b.code == "" {
+		return b
+	}
+
+	info, ok := defaultRegistry.Lookup(b.code)
+	if !ok {
+		return b
+	}
+
+	if b.domain == "" {
+		b = b.Domain(info.Domain)
+	}
+
+	if !b.httpStatusCodeSet {
+		b = b.HttpStatusCode(info.HttpStatusCode)
+	}
+
+	if !b.exitCodeSet {
+		b = b.ExitCode(info.ExitCode)
+	}
+
+	if _, ok := b.attrs.get(SeverityAttribute); !ok && info.Severity != "" {
+		b = b.Attribute(SeverityAttribute, info.Severity)
+	}
+
+	if b.helpURL == "" {
+		b = b.HelpURL(info.DocsURL)
+	}
+
+	if b.userMsg == "" && info.UserMsg != "" {
+		b.userMsg = expandTemplate(info.UserMsg, b.attrs)
+	}
+
+	return b
+}
+
+// expandTemplate replaces placeholders of the form {key} in tmpl with the values of the attributes with the same key.
+func expandTemplate(tmpl string, attrs attrList) string {
+	if attrs.len() == 0 || !strings.Contains(tmpl, "{") {
+		return tmpl
+	}
+
+	var sb strings.Builder
+	for {
+		start := strings.IndexByte(tmpl, '{')
+		if start < 0 {
+			break
+		}
+
+		end := strings.IndexByte(tmpl[start:], '}')
+		if end < 0 {
+			break
+		}
+		end += start
+
+		sb.WriteString(tmpl[:start])
+		if value, ok := attrs.get(tmpl[start+1 : end]); ok {
+			sb.WriteString(fmt.Sprint(value))
+		} else {
+			sb.WriteString(tmpl[start : end+1])
+		}
+
+		tmpl = tmpl[end+1:]
+	}
+
+	sb.WriteString(tmpl)
+	return sb.String()
+}
@@ -0,0 +1,154 @@
+package fail
+
+// Result holds either a value of type T or a Fail error, for result-style control flow in pipelines.
+//
+// Results are created using Ok, Err or ResultOf, and are converted back into the usual (T, error) pair
+// using Unwrap, so that they interoperate with functions such as WrapResult. The error of a failed
+// Result is always a Fail. The zero value is a successful Result holding the zero value of T.
+//
+// Example:
+//
+//	r := fail.ResultOf(fail.WrapResult(loadUser, "failed to load user"))
+//	name := fail.Map(r, func(u User) string { return u.Name }).UnwrapOr("anonymous")
+type Result[T any] struct {
+	value T
+	err   *Fail
+}
+
+// Ok returns a successful Result holding v.
+//
+// Example:
+//
+//	r := fail.Ok(42)
+func Ok[T any](v T) Result[T] {
+	return Result[T]{value: v}
+}
+
+// Err returns a failed Result holding err converted to a Fail.
+//
+// If err is already a Fail, it is used as-is; otherwise, its details are extracted using From.
+// Panics if err is nil.
+//
+// Example:
+//
+//	r := fail.Err[User](fail.New().Code(fail.ErrCodeNotFound).Msg("user not found"))
+func Err[T any](err error) Result[T] {
+	if err == nil {
+		panic("cannot create a failed Result from a nil error")
+	}
+
+	f, ok := err.(Fail)
+	if !ok {
+		f = From(err).asFail()
+	}
+
+	return Result[T]{err: &f}
+}
+
+// ResultOf returns a Result from a (T, error) pair, as returned by most Go functions.
+//
+// If err is nil, the Result is successful and holds v; otherwise, it is failed as if created using Err.
+//
+// Example:
+//
+//	r := fail.ResultOf(strconv.Atoi(s))
+func ResultOf[T any](v T, err error) Result[T] {
+	if err != nil {
+		return Err[T](err)
+	}
+
+	return Ok(v)
+}
+
+// IsOk reports whether the Result is successful.
+func (r Result[T]) IsOk() bool {
+	return r.err == nil
+}
+
+// IsErr reports whether the Result is failed.
+func (r Result[T]) IsErr() bool {
+	return r.err != nil
+}
+
+// Err returns the error of a failed Result, or nil if the Result is successful.
+func (r Result[T]) Err() error {
+	if r.err == nil {
+		return nil
+	}
+
+	return *r.err
+}
+
+// Unwrap returns the value and the error of the Result, as a (T, error) pair.
+//
+// The value is the zero value of T if the Result is failed.
+//
+// Example:
+//
+//	func loadUser(id string) (User, error) {
+//		return fail.Map(fetch(id), toUser).Unwrap()
+//	}
+func (r Result[T]) Unwrap() (T, error) {
+	return r.value, r.Err()
+}
+
+// UnwrapOr returns the value of a successful Result, or def if the Result is failed.
+func (r Result[T]) UnwrapOr(def T) T {
+	if r.err != nil {
+		return def
+	}
+
+	return r.value
+}
+
+// Must returns the value of a successful Result, and panics like Must if the Result is failed.
+func (r Result[T]) Must() T {
+	if r.err != nil {
+		panic(mustPanic{mustFail(*r.err)})
+	}
+
+	return r.value
+}
+
+// OrElse returns r if it is successful, and the result of fn called with its error otherwise.
+//
+// Example:
+//
+//	r := fail.ResultOf(cache.Get(key)).OrElse(func(err error) fail.Result[Item] {
+//		return fail.ResultOf(db.Get(key))
+//	})
+func (r Result[T]) OrElse(fn func(err error) Result[T]) Result[T] {
+	if r.err == nil {
+		return r
+	}
+
+	return fn(*r.err)
+}
+
+// Map returns a Result holding fn applied to the value of r if r is successful, and the error of r otherwise.
+//
+// Example:
+//
+//	names := fail.Map(users, func(us []User) []string { return namesOf(us) })
+func Map[T, U any](r Result[T], fn func(T) U) Result[U] {
+	if r.err != nil {
+		return Result[U]{err: r.err}
+	}
+
+	return Ok(fn(r.value))
+}
+
+// AndThen returns the Result of fn called with the value of r if r is successful, and the error of r otherwise.
+//
+// Unlike Map, fn may fail. This allows chaining steps of a pipeline that each return a (U, error) pair.
+//
+// Example:
+//
+//	port := fail.AndThen(fail.ResultOf(readSetting("port")), strconv.Atoi)
+func AndThen[T, U any](r Result[T], fn func(T) (U, error)) Result[U] {
+	if r.err != nil {
+		return Result[U]{err: r.err}
+	}
+
+	return ResultOf(fn(r.value))
+}
@@ -0,0 +1,153 @@
+package fail
+
+import (
+	"context"
+	"time"
+)
+
+// Attribute keys under which Retry records metadata about the attempts made.
+const (
+	// AttemptsAttribute is the attribute key under which Retry records the number of attempts made.
+	AttemptsAttribute = "attempts"
+	// ElapsedAttribute is the attribute key under which Retry records the total time spent, as a time.Duration.
+	ElapsedAttribute = "elapsed"
+)
+
+// Default values used by Retry for unset RetryOptions fields.
+const (
+	// DefaultRetryMaxAttempts is the default maximum number of attempts made by Retry.
+	DefaultRetryMaxAttempts = 3
+	// DefaultRetryInitialBackoff is the default duration Retry waits before the second attempt.
+	DefaultRetryInitialBackoff = 100 * time.Millisecond
+	// DefaultRetryMaxBackoff is the default upper bound for the duration Retry waits between attempts.
+	DefaultRetryMaxBackoff = 10 * time.Second
+	// DefaultRetryMultiplier is the default factor by which the backoff grows after each attempt.
+	DefaultRetryMultiplier = 2.0
+)
+
+// RetryOptions configures how Retry retries a failing operation.
+//
+// Zero values are replaced by the corresponding DefaultRetry* constants.
+type RetryOptions struct {
+	// MaxAttempts is the maximum number of times the operation is called, including the first call.
+	MaxAttempts int
+	// InitialBackoff is the duration to wait before the second attempt.
+	InitialBackoff time.Duration
+	// MaxBackoff is the upper bound for the duration to wait between attempts.
+	MaxBackoff time.Duration
+	// Multiplier is the factor by which the backoff grows after each attempt.
+	Multiplier float64
+	// ShouldRetry decides whether an attempt that failed with the given error is retried.
+	// If nil, fail.Retryable is used.
+	ShouldRetry func(err error) bool
+}
+
+// withDefaults returns a copy of the options with zero values replaced by their defaults.
+func (o RetryOptions) withDefaults() RetryOptions {
+	if o.MaxAttempts <= 0 {
+		o.MaxAttempts = DefaultRetryMaxAttempts
+	}
+
+	if o.InitialBackoff <= 0 {
+		o.InitialBackoff = DefaultRetryInitialBackoff
+	}
+
+	if o.MaxBackoff <= 0 {
+		o.MaxBackoff = DefaultRetryMaxBackoff
+	}
+
+	if o.Multiplier < 1 {
+		o.Multiplier = DefaultRetryMultiplier
+	}
+
+	if o.ShouldRetry == nil {
+		o.ShouldRetry = Retryable
+	}
+
+	return o
+}
+
+// backoff returns the duration to wait after the given attempt, starting at 1.
+//
+// If the error requests a longer wait using RetryAfter, that duration is used instead.
+func (o RetryOptions) backoff(attempt int, err error) time.Duration {
+	d := o.exponentialBackoff(attempt)
+	if retryAfter := RetryAfter(err); retryAfter > d {
+		return retryAfter
+	}
+
+	return d
+}
+
+// exponentialBackoff returns the exponential backoff after the given attempt, starting at 1.
+func (o RetryOptions) exponentialBackoff(attempt int) time.Duration {
+	d := float64(o.InitialBackoff)
+	for i := 1; i < attempt; i++ {
+		d *= o.Multiplier
+		if d >= float64(o.MaxBackoff) {
+			return o.MaxBackoff
+		}
+	}
+
+	return time.Duration(d)
+}
+
+// Retry calls fn until it succeeds, returns an error that should not be retried, or the attempts are exhausted.
+//
+// The attempt number, starting at 1, is passed to fn. By default, only errors classified as transient
+// by fail.Transience (and therefore reported as retryable by fail.Retryable) are retried; permanent
+// and unclassified errors are returned immediately. Use RetryOptions.ShouldRetry to change this.
+//
+// Between attempts, Retry waits with exponential backoff as configured by policy. If an error requests
+// a longer wait using RetryAfter, that duration is honored instead. Retry stops early if ctx is done,
+// or if the wait would end after the deadline of ctx.
+//
+// If fn never succeeds, the last error is returned as a Fail with the number of attempts recorded under
+// AttemptsAttribute and the total time spent under ElapsedAttribute. The errors of the earlier attempts
+// are attached as associated errors. If ctx is done, the context's error is added as a cause.
+// If fn succeeds, Retry returns nil.
+//
+// Example:
+//
+//	err := fail.Retry(ctx, func(attempt int) error {
+//		return client.Send(ctx, msg)
+//	}, fail.RetryOptions{MaxAttempts: 5})
+func Retry(ctx context.Context, fn func(attempt int) error, policy RetryOptions) error {
+	policy = policy.withDefaults()
+	start := time.Now()
+
+	var previous []error
+	for attempt := 1; ; attempt++ {
+		err := fn(attempt)
+		if err == nil {
+			return nil
+		}
+
+		final := func(b Builder) error {
+			return b.
+				AssociateSlice(previous).
+				Attribute(AttemptsAttribute, attempt).
+				Attribute(ElapsedAttribute, time.Since(start)).
+				asFail()
+		}
+
+		if attempt >= policy.MaxAttempts || !policy.ShouldRetry(err) {
+			return final(From(err))
+		}
+
+		wait := policy.backoff(attempt, err)
+		if deadline, ok := ctx.Deadline(); ok && time.Now().Add(wait).After(deadline) {
+			return final(From(err).Context(ctx))
+		}
+
+		timer := time.NewTimer(wait)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return final(From(err).Context(ctx))
+		case <-timer.C:
+		}
+
+		previous = append(previous, err)
+	}
+}
@@ -0,0 +1,95 @@
+package fail
+
+import "time"
+
+// ErrorRetryAfter is an error type that provides how long callers should wait before retrying.
+//
+// Implementations of this interface should return the minimum duration after which the failed
+// operation may be retried, such as the backoff requested by a rate limiter or an unavailable
+// service. The returned duration may be zero if no backoff is known.
+//
+// Example usage:
+//
+//	type MyError struct{}
+//	func (e *MyError) Error() string { return "rate limit exceeded" }
+//	func (e *MyError) ErrorRetryAfter() time.Duration { return 30 * time.Second }
+//
+//	err := &MyError{}
+//	d := fail.RetryAfter(err) // returns 30s
+type ErrorRetryAfter interface {
+	error
+
+	// ErrorRetryAfter returns the minimum duration to wait before retrying the failed operation.
+	//
+	// The returned duration may be zero if no backoff is known.
+	ErrorRetryAfter() time.Duration
+}
+
+// RetryAfter returns how long callers should wait before retrying the operation that produced the provided error.
+//
+// This function determines the duration as follows:
+//  1. If err is nil, it returns zero.
+//  2. If err implements ErrorRetryAfter and ErrorRetryAfter() returns a positive duration, it returns that duration.
+//  3. Otherwise, it recursively examines the causes of err (using Causes(err)) and returns the longest
+//     duration found among them, or zero if there is none.
+//
+// The recursion is bounded by MaxDepth and MaxWidth, and cycles in the cause graph are ignored.
+func RetryAfter(err error) time.Duration {
+	return retryAfter(err, 0, newVisitSet())
+}
+
+// retryAfter implements RetryAfter, tracking the current depth and the visited errors to guard against cycles.
+func retryAfter(err error, depth int, visited visitSet) time.Duration {
+	if err == nil {
+		return 0
+	}
+
+	if r, ok := err.(ErrorRetryAfter); ok {
+		if d := r.ErrorRetryAfter(); d > 0 {
+			return d
+		}
+	}
+
+	if depth >= MaxDepth() || !visited.enter(err) {
+		return 0
+	}
+	defer visited.leave(err)
+
+	var maxRetryAfter time.Duration
+	for _, cause := range limitWidth(Causes(err)) {
+		maxRetryAfter = max(maxRetryAfter, retryAfter(cause, depth+1, visited))
+	}
+
+	return maxRetryAfter
+}
+
+// WithRetryAfter returns a new error with the specified retry backoff attached.
+//
+// This function takes an existing error and a duration, and returns a new error that includes
+// the provided backoff. If the provided error is nil, it returns nil. If the duration is less
+// than or equal to zero, the original error is returned unchanged.
+//
+// The returned error will implement the ErrorRetryAfter interface, and the backoff can be
+// retrieved using the fail.RetryAfter function.
+//
+// Example:
+//
+//	err := fail.WithRetryAfter(primaryErr, 30*time.Second)
+//
+// Parameters:
+//   - err: The original error to which the backoff will be attached.
+//   - d:   The minimum duration to wait before retrying.
+//
+// Returns:
+//   - A new error with the backoff attached, or nil if err is nil. If d <= 0, returns the original error.
+func WithRetryAfter(err error, d time.Duration) error {
+	if err == nil {
+		return nil
+	}
+
+	if d <= 0 {
+		return err
+	}
+
+	return From(err).RetryAfter(d).asFail()
+}
@@ -0,0 +1,61 @@
+package fail
+
+// ErrorRetryable is an error type that reports whether the failed operation may succeed when retried.
+//
+// Implementations of this interface should return true for transient failures, such as timeouts,
+// dropped connections or serialization conflicts, and false otherwise.
+//
+// Example usage:
+//
+//	type MyError struct{}
+//	func (e *MyError) Error() string { return "connection reset" }
+//	func (e *MyError) ErrorRetryable() bool { return true }
+//
+//	err := &MyError{}
+//	retry := fail.Retryable(err) // returns true
+type ErrorRetryable interface {
+	error
+
+	// ErrorRetryable reports whether the failed operation may succeed when retried.
+	ErrorRetryable() bool
+}
+
+// Retryable reports whether the operation that produced the provided error may succeed when retried.
+//
+// An error is retryable if Transience classifies it as TransienceTransient. In particular:
+//  1. If err is nil, it returns false.
+//  2. If err is classified as permanent using ErrorTransience, it returns false.
+//  3. If err implements ErrorRetryable and ErrorRetryable() returns true, it returns true.
+//  4. Otherwise, it recursively examines the causes of err (using Causes(err)) and returns true
+//     if any of them is retryable and none of them is permanent.
+//
+// The recursion is bounded by MaxDepth and MaxWidth, and cycles in the cause graph are ignored.
+func Retryable(err error) bool {
+	return Transience(err) == TransienceTransient
+}
+
+// WithRetryable returns a new error marked as retryable or not.
+//
+// This function takes an existing error and returns a new error that reports the provided
+// retryability. If the provided error is nil, it returns nil.
+//
+// The returned error will implement the ErrorRetryable interface, and the retryability can be
+// retrieved using the fail.Retryable function.
+//
+// Example:
+//
+//	err := fail.WithRetryable(primaryErr, true)
+//
+// Parameters:
+//   - err:       The original error to mark.
+//   - retryable: Whether the failed operation may succeed when retried.
+//
+// Returns:
+//   - A new error with the retryability attached, or nil if err is nil.
+func WithRetryable(err error, retryable bool) error {
+	if err == nil {
+		return nil
+	}
+
+	return From(err).Retryable(retryable).asFail()
+}
@@ -0,0 +1,194 @@
+package fail
+
+import (
+	"reflect"
+	"regexp"
+	"sync"
+)
+
+// Matcher selects a class of errors for a Router.
+//
+// Every non-zero field must match for the Matcher to match. The fields are checked against the
+// error and its cause tree: Code using IsCode, Domain using IsDomain, Tag by looking for the tag
+// on any error in the tree, Kind by looking for an error whose dynamic type is Kind, or
+// implements Kind if it is an interface type, Is by looking for an error in the tree that
+// errors.Is reports as Is, and Message against the result of err.Error(). The zero Matcher matches
+// every error.
+type Matcher struct {
+	// Code is the error code to match, see IsCode.
+	Code string
+	// Domain is the domain to match, see IsDomain.
+	Domain string
+	// Tag is a tag that must be present on an error in the tree.
+	Tag string
+	// Kind is the type of an error that must be present in the tree, see KindOf.
+	Kind reflect.Type
+	// Is is a sentinel error, such as context.Canceled, that must be present in the tree.
+	Is error
+	// Message is a regular expression the result of err.Error() must match.
+	Message *regexp.Regexp
+}
+
+// KindOf returns the reflect.Type of T, for use as Matcher.Kind.
+//
+// Example:
+//
+//	fail.Matcher{Kind: fail.KindOf[*net.OpError]()}
+func KindOf[T any]() reflect.Type {
+	return reflect.TypeFor[T]()
+}
+
+// Match reports whether err matches all non-zero fields of the Matcher.
+func (m Matcher) Match(err error) bool {
+	if err == nil {
+		return false
+	}
+
+	if m.Code != "" && !IsCode(err, m.Code) {
+		return false
+	}
+
+	if m.Domain != "" && !IsDomain(err, m.Domain) {
+		return false
+	}
+
+	if m.Tag != "" && !search(err, func(err error) bool { return hasOwnTag(err, m.Tag) }) {
+		return false
+	}
+
+	if m.Kind != nil && !search(err, m.isKind) {
+		return false
+	}
+
+	if m.Is != nil && !search(err, func(err error) bool { return isOwn(err, m.Is) }) {
+		return false
+	}
+
+	if m.Message != nil && !m.Message.MatchString(err.Error()) {
+		return false
+	}
+
+	return true
+}
+
+// isKind reports whether the dynamic type of err is m.Kind, or implements it if it is an interface type.
+func (m Matcher) isKind(err error) bool {
+	t := reflect.TypeOf(err)
+	if m.Kind.Kind() == reflect.Interface {
+		return t.Implements(m.Kind)
+	}
+
+	return t == m.Kind
+}
+
+// specificity returns the number of non-zero fields of the Matcher.
+func (m Matcher) specificity() int {
+	n := 0
+	for _, set := range []bool{m.Code != "", m.Domain != "", m.Tag != "", m.Kind != nil, m.Is != nil, m.Message != nil} {
+		if set {
+			n++
+		}
+	}
+
+	return n
+}
+
+// route is a Matcher registered in a Router together with its handler.
+type route struct {
+	matcher Matcher
+	handler func(err error)
+}
+
+// Router dispatches errors to handlers depending on their class.
+//
+// A Router centralizes the logic deciding what to do with a class of errors, such as logging
+// them at a certain level, mapping them to a response, or exiting the process. Handlers are
+// registered for a Matcher using On, and Handle calls the handler of the most specific Matcher
+// that matches the error, i.e. the one with the most non-zero fields. Among equally specific
+// matches, the handler registered first wins. The zero value is an empty router ready to use.
+// A Router is safe for concurrent use.
+//
+// Example:
+//
+//	router := fail.NewRouter().
+//		On(fail.Matcher{Domain: fail.DomainDatabase}, alertOnCall).
+//		On(fail.Matcher{Code: fail.ErrCodeNotFound}, ignore).
+//		Fallback(logError)
+//
+//	router.Handle(err)
+type Router struct {
+	mu       sync.RWMutex
+	routes   []route
+	fallback func(err error)
+}
+
+// NewRouter returns an empty Router.
+func NewRouter() *Router {
+	return &Router{}
+}
+
+// On registers handler for errors matching m and returns the router to allow chaining.
+//
+// On panics if handler is nil, since this is always a programming error.
+func (r *Router) On(m Matcher, handler func(err error)) *Router {
+	if handler == nil {
+		panic("cannot register a nil error handler")
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.routes = append(r.routes, route{matcher: m, handler: handler})
+	return r
+}
+
+// Fallback sets the handler called for errors that match no registered Matcher and returns the router.
+//
+// Passing nil removes the fallback.
+func (r *Router) Fallback(handler func(err error)) *Router {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.fallback = handler
+	return r
+}
+
+// Handle calls the handler of the most specific Matcher matching err and reports whether a handler was called.
+//
+// If no Matcher matches, the fallback handler is called, if any. If err is nil, no handler is called
+// and Handle returns false. The handler is called without holding the router's lock, so it may
+// register further routes.
+func (r *Router) Handle(err error) bool {
+	if err == nil {
+		return false
+	}
+
+	handler := r.lookup(err)
+	if handler == nil {
+		return false
+	}
+
+	handler(err)
+	return true
+}
+
+// lookup returns the handler of the most specific Matcher matching err, or the fallback handler.
+func (r *Router) lookup(err error) func(err error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	best := -1
+	var handler func(err error)
+	for _, rt := range r.routes {
+		if s := rt.matcher.specificity(); s > best && rt.matcher.Match(err) {
+			best = s
+			handler = rt.handler
+		}
+	}
+
+	if handler == nil {
+		return r.fallback
+	}
+
+	return handler
+}
@@ -0,0 +1,114 @@
+package fail
+
+import (
+	"math/rand/v2"
+	"sync/atomic"
+)
+
+// Sampler decides whether expensive enrichments are applied to an error being built.
+//
+// Expensive enrichments, capturing the source location and running the hooks registered using
+// AddHook, are only applied if the configured Sampler returns true for the error's code. Errors are always created, even when
+// they are not sampled; they merely lack the expensive metadata. This allows hot paths that
+// create thousands of errors per second to keep their overhead low.
+type Sampler interface {
+	// Sample reports whether expensive enrichments should be applied to an error with the given code.
+	Sample(code string) bool
+}
+
+// SamplerFunc is an adapter to allow the use of ordinary functions as Samplers.
+type SamplerFunc func(code string) bool
+
+// Sample calls the underlying function.
+func (f SamplerFunc) Sample(code string) bool {
+	return f(code)
+}
+
+// samplerHolder wraps a Sampler so it can be stored in an atomic.Pointer.
+type samplerHolder struct {
+	sampler Sampler
+}
+
+// sampler is the Sampler consulted before expensive enrichments, or nil if every error is sampled.
+var sampler atomic.Pointer[samplerHolder]
+
+// SetSampler sets the Sampler consulted before applying expensive enrichments to errors.
+//
+// Passing nil removes the sampler, so that every error is enriched (the default).
+// It is safe to call SetSampler concurrently.
+//
+// Example:
+//
+//	fail.SetSampler(fail.CodeSampler(map[string]float64{
+//		fail.ErrCodeNotFound: 0.01,
+//	}, 1))
+func SetSampler(s Sampler) {
+	if s == nil {
+		sampler.Store(nil)
+		return
+	}
+
+	sampler.Store(&samplerHolder{sampler: s})
+}
+
+// sample reports whether expensive enrichments should be applied to an error with the given code.
+func sample(code string) bool {
+	h := sampler.Load()
+	if h == nil {
+		return true
+	}
+
+	return h.sampler.Sample(code)
+}
+
+// RateSampler returns a Sampler that samples errors with the given probability.
+//
+// A rate of 1 or more samples every error, a rate of 0 or less samples none.
+//
+// Example:
+//
+//	fail.SetSampler(fail.RateSampler(0.1))
+func RateSampler(rate float64) Sampler {
+	return SamplerFunc(func(string) bool {
+		return sampleRate(rate)
+	})
+}
+
+// CodeSampler returns a Sampler that samples errors with a probability depending on their code.
+//
+// Errors whose code is a key of rates are sampled with the associated probability, all other
+// errors with defaultRate. The rates map is copied.
+//
+// Example:
+//
+//	fail.SetSampler(fail.CodeSampler(map[string]float64{
+//		fail.ErrCodeNotFound:    0.01,
+//		fail.ErrCodeRateLimited: 0.1,
+//	}, 1))
+func CodeSampler(rates map[string]float64, defaultRate float64) Sampler {
+	ratesCopy := make(map[string]float64, len(rates))
+	for code, rate := range rates {
+		ratesCopy[code] = rate
+	}
+
+	return SamplerFunc(func(code string) bool {
+		rate, ok := ratesCopy[code]
+		if !ok {
+			rate = defaultRate
+		}
+
+		return sampleRate(rate)
+	})
+}
+
+// sampleRate returns true with the given probability.
+func sampleRate(rate float64) bool {
+	switch {
+	case rate >= 1:
+		return true
+	case rate <= 0:
+		return false
+	default:
+		return rand.Float64() < rate
+	}
+}
@@ -0,0 +1,16 @@
+package fail
+
+// SchemaVersion is the version of the format in which errors are serialized by this package.
+//
+// It is written under SchemaVersionKey by the JSON printer, the NDJSONWriter and the Recorder, and
+// stored in the Snapshot returned by Details, and therefore in the output of MarshalBinary. It is
+// incremented whenever a field changes its meaning or encoding in an incompatible way; adding fields
+// does not change the version.
+//
+// FromJson, FromSnapshot and UnmarshalBinary accept documents of any version, including newer ones:
+// unknown fields are ignored and fields with an unexpected type are skipped, so that services using
+// different versions of this package can still exchange errors.
+const SchemaVersion = 1
+
+// SchemaVersionKey is the key under which the SchemaVersion is written to serialized errors.
+const SchemaVersionKey = "schema_version"
@@ -0,0 +1,53 @@
+package fail
+
+import "context"
+
+// ScopeAttribute is the attribute key under which the scope from a context.Context is attached to errors.
+const ScopeAttribute = "scope"
+
+// ScopeSeparator is the separator used to concatenate nested scopes.
+const ScopeSeparator = "."
+
+// scopeContextKey is an unexported type used as the key for storing
+// and retrieving the error scope in a context.Context.
+type scopeContextKey struct{}
+
+// ContextWithScope returns a new context.Context that carries the provided error scope.
+//
+// A scope is a short name for the operation being performed, such as "payments.charge".
+// If a scope is already set in the context, the new scope is appended to it, separated by
+// ScopeSeparator, so that nested operations produce breadcrumbs like "payments.charge.validate".
+// If scope is empty, the context is returned unchanged.
+//
+// Errors built using NewC, MsgC, WrapC or Builder.Context carry the scope as the ScopeAttribute attribute.
+//
+// Example usage:
+//
+//	ctx := ContextWithScope(context.Background(), "payments")
+//	ctx = ContextWithScope(ctx, "charge") // scope is now "payments.charge"
+func ContextWithScope(ctx context.Context, scope string) context.Context {
+	if scope == "" {
+		return ctx
+	}
+
+	if existing := ScopeFromContext(ctx); existing != "" {
+		scope = existing + ScopeSeparator + scope
+	}
+
+	return context.WithValue(ctx, scopeContextKey{}, scope)
+}
+
+// ScopeFromContext extracts the error scope from the provided context.
+// If no scope is set in the context, ScopeFromContext returns an empty string.
+//
+// Example usage:
+//
+//	scope := ScopeFromContext(ctx)
+func ScopeFromContext(ctx context.Context) string {
+	scope, ok := ctx.Value(scopeContextKey{}).(string)
+	if !ok {
+		return ""
+	}
+
+	return scope
+}
@@ -0,0 +1,111 @@
+package fail
+
+import (
+	"encoding/json"
+	"fmt"
+	"log/slog"
+)
+
+// Redacted is the placeholder rendered in place of the value of a SecretValue.
+const Redacted = "[REDACTED]"
+
+// SecretValue holds a sensitive value that is never rendered.
+//
+// A SecretValue renders as Redacted when formatted using the fmt package, logged using slog,
+// or encoded as JSON, text or CBOR, so that it can be attached to an error as an attribute
+// without leaking into logs or API responses. The wrapped value is only available through
+// Reveal or RevealAttribute.
+type SecretValue struct {
+	value any
+}
+
+// Secret wraps v in a SecretValue, so that it always renders as Redacted.
+//
+// This is useful for attaching credentials, tokens or personal data to an error for
+// programmatic use, such as retrying a request, without exposing them in Error(), the
+// printers, LogValue or serialized output.
+//
+// Example:
+//
+//	err := fail.New().
+//		Attribute("api_key", fail.Secret(apiKey)).
+//		Msg("authentication failed")
+//
+//	key, _ := fail.RevealAttribute(err, "api_key")
+func Secret(v any) SecretValue {
+	if s, ok := v.(SecretValue); ok {
+		return s
+	}
+
+	return SecretValue{value: v}
+}
+
+// Reveal returns the wrapped value.
+func (s SecretValue) Reveal() any {
+	return s.value
+}
+
+// String returns Redacted.
+func (s SecretValue) String() string {
+	return Redacted
+}
+
+// GoString returns Redacted, so that the value is also hidden when formatted using %#v.
+func (s SecretValue) GoString() string {
+	return Redacted
+}
+
+// Format writes Redacted for every verb, so that the value is never formatted using the fmt package.
+func (s SecretValue) Format(f fmt.State, _ rune) {
+	_, _ = f.Write([]byte(Redacted))
+}
+
+// LogValue returns Redacted as a slog.Value.
+//
+// Implements slog.LogValuer interface.
+func (s SecretValue) LogValue() slog.Value {
+	return slog.StringValue(Redacted)
+}
+
+// MarshalText returns Redacted.
+//
+// Implements encoding.TextMarshaler interface.
+func (s SecretValue) MarshalText() ([]byte, error) {
+	return []byte(Redacted), nil
+}
+
+// MarshalJSON returns Redacted as a JSON string.
+//
+// Implements json.Marshaler interface.
+func (s SecretValue) MarshalJSON() ([]byte, error) {
+	return json.Marshal(Redacted)
+}
+
+// MarshalCBOR returns Redacted as a CBOR text string.
+//
+// Implements the Marshaler interface of CBOR libraries such as github.com/fxamacker/cbor, so that
+// encoding a SecretValue as CBOR does not encode the wrapped value.
+func (s SecretValue) MarshalCBOR() ([]byte, error) {
+	return cborEncode(nil, Redacted)
+}
+
+// RevealAttribute returns the value of the attribute key of the provided error, unwrapping a SecretValue.
+//
+// The second return value reports whether the attribute is present. Attributes that are not
+// secret are returned as-is.
+//
+// Example:
+//
+//	token, ok := fail.RevealAttribute(err, "token")
+func RevealAttribute(err error, key string) (any, bool) {
+	value, ok := Attributes(err)[key]
+	if !ok {
+		return nil, false
+	}
+
+	if s, ok := value.(SecretValue); ok {
+		return s.Reveal(), true
+	}
+
+	return value, true
+}
@@ -93,7 +93,7 @@ func WrapResult[T any](fn func() (T, error), msg string) (T, error) {
 // WrapC creates a new Fail error with the given message, wrapping the provided error as its cause and context.
 //
 // If err is nil, WrapC returns nil.
-// Equivalent to: fail.NewC(ctx).Cause(err).Msg(msg).
+// Equivalent to: fail.New().Cause(err).Context(ctx).Msg(msg).
 //
 // Example:
 //
@@ -103,7 +103,7 @@ func WrapC(ctx context.Context, err error, msg string) error {
 		return nil
 	}
 
-	return NewC(ctx).Cause(err).Msg(msg)
+	return New().Cause(err).Context(ctx).Msg(msg)
 }
 
 // WrapCResult executes the provided function fn, and if it returns a non-nil error,
@@ -145,13 +145,13 @@ func WrapfResult[T any](fn func() (T, error), format string, args ...any) (T, er
 // WrapCf creates a new Fail error with a formatted message, wrapping the provided error as its cause and context.
 //
 // If err is nil, WrapCf returns nil.
-// Equivalent to: fail.NewC(ctx).Cause(err).Msgf(format, args...).
+// Equivalent to: fail.New().Cause(err).Context(ctx).Msgf(format, args...).
 //
 // Example:
 //
 //	err := fail.WrapCf(ctx, io.EOF, "failed to read file %q", filename)
 func WrapCf(ctx context.Context, err error, format string, args ...any) error {
-	return NewC(ctx).Cause(err).Msgf(format, args...)
+	return New().Cause(err).Context(ctx).Msgf(format, args...)
 }
 
 // WrapCfResult executes the provided function fn, and if it returns a non-nil error,
@@ -194,7 +194,7 @@ func WrapMany(msg string, errs ...error) error {
 // WrapManyC creates a new Fail error with the given message, wrapping multiple errors as its causes and context.
 //
 // If errs is empty, WrapManyC returns nil.
-// Equivalent to: fail.NewC(ctx).CauseSlice(errs).Msg(msg).
+// Equivalent to: fail.New().CauseSlice(errs).Context(ctx).Msg(msg).
 //
 // Example:
 //
@@ -204,7 +204,7 @@ func WrapManyC(ctx context.Context, msg string, errs ...error) error {
 		return nil
 	}
 
-	return NewC(ctx).CauseSlice(errs).Msg(msg)
+	return New().CauseSlice(errs).Context(ctx).Msg(msg)
 }
 
 // WithContext adds information from the provided context to the error.
@@ -217,3 +217,69 @@ func WithContext(err error, ctx context.Context) error {
 
 	return From(err).Context(ctx).asFail()
 }
+
+// WrapPtr wraps the error pointed to by errp in place with the given message, if it is not nil.
+//
+// It is designed to be deferred in functions with a named error result, adding context to every
+// error returned by the function without wrapping each return statement. The provided cleanup functions,
+// such as the Close method of a resource, are called first. If the function failed, their errors are
+// associated with the wrapped error using the RoleCleanup role; otherwise, the first cleanup error
+// becomes the cause of the wrapped error and the remaining ones are associated with it.
+// If errp is nil, WrapPtr only calls the cleanup functions.
+//
+// Example:
+//
+//	func readConfig(path string) (cfg Config, err error) {
+//		f, err := os.Open(path)
+//		if err != nil {
+//			return cfg, err
+//		}
+//		defer fail.WrapPtr(&err, "failed to read config", f.Close)
+//
+//		return cfg, json.NewDecoder(f).Decode(&cfg)
+//	}
+func WrapPtr(errp *error, msg string, cleanups ...func() error) {
+	var cleanupErrs []error
+	for _, cleanup := range cleanups {
+		if cErr := cleanup(); cErr != nil {
+			cleanupErrs = append(cleanupErrs, cErr)
+		}
+	}
+
+	if errp == nil {
+		return
+	}
+
+	cause := *errp
+	if cause == nil {
+		if len(cleanupErrs) == 0 {
+			return
+		}
+		cause, cleanupErrs = cleanupErrs[0], cleanupErrs[1:]
+	}
+
+	b := New().Cause(cause)
+	for _, cErr := range cleanupErrs {
+		b = b.AssociateRole(RoleCleanup, cErr)
+	}
+
+	*errp = b.Msg(msg)
+}
+
+// WrapPtrf wraps the error pointed to by errp in place with a formatted message, if it is not nil.
+//
+// It is the formatted variant of WrapPtr, without cleanup functions. If errp is nil, WrapPtrf does nothing.
+//
+// Example:
+//
+//	func deleteUser(id string) (err error) {
+//		defer fail.WrapPtrf(&err, "failed to delete user %s", id)
+//		...
+//	}
+func WrapPtrf(errp *error, format string, args ...any) {
+	if errp == nil || *errp == nil {
+		return
+	}
+
+	*errp = New().Cause(*errp).Msgf(format, args...)
+}
@@ -0,0 +1,68 @@
+package fail
+
+import (
+	"context"
+	"os"
+	"os/signal"
+	"syscall"
+)
+
+// FromSignal returns an error describing the termination of the program by the provided signal.
+//
+// The returned Fail is tagged with TagCanceled, records the name of the signal under SignalAttribute,
+// and has the exit code conventionally used by shells for processes terminated by a signal, 128 plus
+// the signal number, such as 130 for SIGINT and 143 for SIGTERM. Signals without a number use
+// DefaultExitCode. If sig is nil, FromSignal returns nil.
+//
+// Example:
+//
+//	sig := <-signals
+//	fail.Fatal(fail.FromSignal(sig))
+func FromSignal(sig os.Signal) error {
+	if sig == nil {
+		return nil
+	}
+
+	exitCode := DefaultExitCode
+	if s, ok := sig.(syscall.Signal); ok {
+		exitCode = 128 + int(s)
+	}
+
+	return New().
+		Tag(TagCanceled).
+		Attribute(SignalAttribute, sig.String()).
+		ExitCode(exitCode).
+		Msg("received signal " + sig.String())
+}
+
+// ExitOnSignal exits the program when one of the provided signals is received, until ctx is done.
+//
+// When a signal is received, the error returned by FromSignal is printed and the program exits with
+// its exit code, see Fatal. If no signals are provided, os.Interrupt and syscall.SIGTERM are handled.
+// ExitOnSignal returns immediately; the signals are handled in the background, and are no longer
+// handled once ctx is done.
+//
+// Example:
+//
+//	func main() {
+//		fail.ExitOnSignal(context.Background())
+//		fail.Fatal(run())
+//	}
+func ExitOnSignal(ctx context.Context, signals ...os.Signal) {
+	if len(signals) == 0 {
+		signals = []os.Signal{os.Interrupt, syscall.SIGTERM}
+	}
+
+	ch := make(chan os.Signal, 1)
+	signal.Notify(ch, signals...)
+
+	go func() {
+		defer signal.Stop(ch)
+
+		select {
+		case sig := <-ch:
+			Fatal(FromSignal(sig))
+		case <-ctx.Done():
+		}
+	}()
+}
@@ -0,0 +1,146 @@
+package fail
+
+import (
+	"fmt"
+	"reflect"
+	"runtime"
+	"strings"
+	"sync/atomic"
+)
+
+// SourceLocation describes the place in the source code where an error was created.
+type SourceLocation struct {
+	// File is the full path of the source file.
+	File string `json:"file"`
+	// Line is the line number within File.
+	Line int `json:"line"`
+	// Function is the fully qualified name of the function.
+	Function string `json:"function,omitempty"`
+}
+
+// IsZero reports whether the source location is empty.
+func (s SourceLocation) IsZero() bool {
+	return s.File == "" && s.Line == 0 && s.Function == ""
+}
+
+// String returns the source location in the form "function (file:line)".
+//
+// If the function is unknown, only "file:line" is returned.
+// If the source location is empty, the empty string is returned.
+func (s SourceLocation) String() string {
+	if s.IsZero() {
+		return ""
+	}
+
+	if s.Function == "" {
+		return fmt.Sprintf("%s:%d", s.File, s.Line)
+	}
+
+	return fmt.Sprintf("%s (%s:%d)", s.Function, s.File, s.Line)
+}
+
+// ErrorSource is an error type that provides the source location where the error was created.
+//
+// Implementations of this interface should return the file, line and function in which the
+// error was created. The returned SourceLocation may be zero if the location is unknown.
+//
+// Example usage:
+//
+//	type MyError struct{}
+//	func (e *MyError) Error() string { return "something went wrong" }
+//	func (e *MyError) ErrorSource() fail.SourceLocation { return fail.SourceLocation{File: "main.go", Line: 42} }
+//
+//	err := &MyError{}
+//	src := fail.Source(err) // returns fail.SourceLocation{File: "main.go", Line: 42}
+type ErrorSource interface {
+	error
+
+	// ErrorSource returns the source location where this error was created.
+	//
+	// The returned SourceLocation may be zero if the location is unknown.
+	ErrorSource() SourceLocation
+}
+
+// Source returns the source location where the provided error was created, if any.
+//
+// This function attempts to extract the source location from the error as follows:
+//  1. If err is nil, it returns the zero SourceLocation.
+//  2. If err implements ErrorSource, it returns the result of ErrorSource().
+//  3. Otherwise, it returns the zero SourceLocation.
+func Source(err error) SourceLocation {
+	if err == nil {
+		return SourceLocation{}
+	}
+
+	if s, ok := err.(ErrorSource); ok {
+		return s.ErrorSource()
+	}
+
+	return SourceLocation{}
+}
+
+// captureSource controls whether errors built using a Builder automatically capture their source location.
+var captureSource atomic.Bool
+
+// packagePath is the import path of this package, used to skip its frames when capturing the source location.
+var packagePath = reflect.TypeOf(Fail{}).PkgPath()
+
+func init() {
+	captureSource.Store(true)
+}
+
+// SetCaptureSource enables or disables the automatic capture of source locations.
+//
+// If enabled (the default), every error completed with Builder.Msg or Builder.Msgf records the
+// file, line and function of the first caller outside of this package and its subpackages, unless a source location
+// was already set using Builder.Caller or the error is not sampled by the Sampler set using SetSampler.
+// It is safe to call SetCaptureSource concurrently.
+//
+// Example:
+//
+//	fail.SetCaptureSource(false)
+func SetCaptureSource(enabled bool) {
+	captureSource.Store(enabled)
+}
+
+// CaptureSource reports whether source locations are captured automatically.
+func CaptureSource() bool {
+	return captureSource.Load()
+}
+
+// callerSource returns the source location of the caller skip frames above its own caller.
+func callerSource(skip int) SourceLocation {
+	var pcs [1]uintptr
+	if runtime.Callers(skip+2, pcs[:]) == 0 {
+		return SourceLocation{}
+	}
+
+	frame, _ := runtime.CallersFrames(pcs[:]).Next()
+	return SourceLocation{
+		File:     frame.File,
+		Line:     frame.Line,
+		Function: frame.Function,
+	}
+}
+
+// externalSource returns the source location of the first caller outside of this package and its subpackages.
+func externalSource() SourceLocation {
+	var pcs [32]uintptr
+	n := runtime.Callers(2, pcs[:])
+
+	frames := runtime.CallersFrames(pcs[:n])
+	for {
+		frame, more := frames.Next()
+		if !strings.HasPrefix(frame.Function, packagePath+".") && !strings.HasPrefix(frame.Function, packagePath+"/") {
+			return SourceLocation{
+				File:     frame.File,
+				Line:     frame.Line,
+				Function: frame.Function,
+			}
+		}
+
+		if !more {
+			return SourceLocation{}
+		}
+	}
+}
@@ -32,17 +32,38 @@ type ErrorSpanId interface {
 //
 // This function attempts to extract the span ID from the error as follows:
 //  1. If err is nil, it returns an empty string.
-//  2. If err implements ErrorSpanId, it returns the result of ErrorSpanId().
-//  3. Otherwise, it returns an empty string.
+//  2. If err implements ErrorSpanId and the result of ErrorSpanId() is not empty, it returns the result.
+//  3. Otherwise, it recursively examines the causes of err (using Causes(err)) and returns the
+//     first non-empty span ID found, so that a span ID carried only by a nested cause is not lost.
 //
+// The recursion is bounded by MaxDepth and MaxWidth, and cycles in the cause graph are ignored.
+// Use TraceLink to obtain a span ID that is guaranteed to belong to the returned trace ID.
 // The returned string may be empty if no span ID is set.
 func SpanId(err error) string {
+	return spanId(err, 0, newVisitSet())
+}
+
+// spanId implements SpanId, tracking the current depth and the visited errors to guard against cycles.
+func spanId(err error, depth int, visited visitSet) string {
 	if err == nil {
 		return ""
 	}
 
 	if span, ok := err.(ErrorSpanId); ok {
-		return span.ErrorSpanId()
+		if id := span.ErrorSpanId(); id != "" {
+			return id
+		}
+	}
+
+	if depth >= MaxDepth() || !visited.enter(err) {
+		return ""
+	}
+	defer visited.leave(err)
+
+	for _, cause := range limitWidth(Causes(err)) {
+		if id := spanId(cause, depth+1, visited); id != "" {
+			return id
+		}
 	}
 
 	return ""
@@ -0,0 +1,144 @@
+package fail
+
+import (
+	"reflect"
+	"runtime"
+)
+
+// ErrorStackTrace is an error type that provides the stack trace of the place where the error was created.
+//
+// Implementations of this interface should return the frames of the call stack, innermost frame first.
+// The returned slice may be empty if no stack trace was recorded.
+type ErrorStackTrace interface {
+	error
+
+	// ErrorStackTrace returns the stack trace of the error, innermost frame first.
+	ErrorStackTrace() []SourceLocation
+}
+
+// StackTrace returns the stack trace of the provided error, innermost frame first.
+//
+// Stack traces are extracted from err and its causes from any of the following:
+//   - errors implementing ErrorStackTrace
+//   - errors with a StackTrace() method returning a slice of program counters, such as those
+//     created by github.com/pkg/errors
+//   - errors with a Callers() []uintptr method, such as those created by github.com/go-errors/errors
+//
+// The most deeply nested stack trace, which is closest to the origin of the error, comes first.
+// Frames of the stack traces of wrapping errors that it does not already contain are appended,
+// so that traces are not lost when errors from different libraries are mixed.
+// If no stack trace is found, the source locations of err and its causes are returned instead,
+// innermost first. The traversal is bounded by MaxDepth and MaxWidth, and cycles are ignored.
+// If err is nil, StackTrace returns nil.
+//
+// Example:
+//
+//	for _, frame := range fail.StackTrace(err) {
+//		fmt.Println(frame)
+//	}
+func StackTrace(err error) []SourceLocation {
+	if err == nil {
+		return nil
+	}
+
+	var traces, sources [][]SourceLocation
+	collectStackTraces(err, 0, newVisitSet(), &traces, &sources)
+
+	if len(traces) == 0 {
+		traces = sources
+	}
+
+	var res []SourceLocation
+	seen := make(map[SourceLocation]struct{})
+
+	// Traces are collected outermost first; the innermost trace is the most informative.
+	for i := len(traces) - 1; i >= 0; i-- {
+		for _, frame := range traces[i] {
+			if _, ok := seen[frame]; !ok {
+				seen[frame] = struct{}{}
+				res = append(res, frame)
+			}
+		}
+	}
+
+	return res
+}
+
+// collectStackTraces appends the stack traces and source locations of err and its causes, outermost first.
+func collectStackTraces(err error, depth int, visited visitSet, traces, sources *[][]SourceLocation) {
+	if err == nil {
+		return
+	}
+
+	if trace := errorStackTrace(err); len(trace) > 0 {
+		*traces = append(*traces, trace)
+	}
+
+	if source := Source(err); !source.IsZero() {
+		*sources = append(*sources, []SourceLocation{source})
+	}
+
+	if depth >= MaxDepth() || !visited.enter(err) {
+		return
+	}
+	defer visited.leave(err)
+
+	for _, cause := range limitWidth(Causes(err)) {
+		collectStackTraces(cause, depth+1, visited, traces, sources)
+	}
+}
+
+// errorStackTrace returns the stack trace recorded by err itself, if any.
+func errorStackTrace(err error) []SourceLocation {
+	if s, ok := err.(ErrorStackTrace); ok {
+		return s.ErrorStackTrace()
+	}
+
+	if s, ok := err.(interface{ Callers() []uintptr }); ok {
+		return framesFromPCs(s.Callers())
+	}
+
+	// github.com/pkg/errors returns errors.StackTrace, a slice of errors.Frame, which is a uintptr.
+	// The types are matched by reflection to avoid depending on the package.
+	m := reflect.ValueOf(err).MethodByName("StackTrace")
+	if !m.IsValid() || m.Type().NumIn() != 0 || m.Type().NumOut() != 1 {
+		return nil
+	}
+
+	out := m.Type().Out(0)
+	if out.Kind() != reflect.Slice || out.Elem().Kind() != reflect.Uintptr {
+		return nil
+	}
+
+	v := m.Call(nil)[0]
+	pcs := make([]uintptr, v.Len())
+	for i := range pcs {
+		pcs[i] = uintptr(v.Index(i).Uint())
+	}
+
+	return framesFromPCs(pcs)
+}
+
+// framesFromPCs resolves program counters as returned by runtime.Callers into source locations.
+func framesFromPCs(pcs []uintptr) []SourceLocation {
+	if len(pcs) == 0 {
+		return nil
+	}
+
+	var res []SourceLocation
+	frames := runtime.CallersFrames(pcs)
+	for {
+		frame, more := frames.Next()
+		if frame.Function != "" || frame.File != "" {
+			res = append(res, SourceLocation{
+				File:     frame.File,
+				Line:     frame.Line,
+				Function: frame.Function,
+			})
+		}
+
+		if !more {
+			return res
+		}
+	}
+}
@@ -0,0 +1,206 @@
+package fail
+
+import (
+	"sync"
+	"time"
+)
+
+// DefaultStatsBuckets is the number of buckets a Stats collector divides its window into.
+const DefaultStatsBuckets = 60
+
+// Stats is a lightweight, in-process collector of error statistics over a sliding time window.
+//
+// Stats counts recorded outcomes and aggregates errors by code, domain and tag. It can be used
+// to drive circuit breakers and health endpoints without a metrics backend.
+// The window is divided into DefaultStatsBuckets buckets; outcomes expire bucket by bucket as
+// the window slides. A Stats is safe for concurrent use and must be created using NewStats.
+//
+// Example:
+//
+//	stats := fail.NewStats(time.Minute)
+//
+//	err := callDependency()
+//	stats.Record(err)
+//
+//	if s := stats.Snapshot(); s.Total >= 20 && s.ErrorRate() > 0.5 {
+//		openCircuit()
+//	}
+type Stats struct {
+	mu         sync.Mutex
+	resolution time.Duration
+	buckets    []statsBucket
+}
+
+// statsBucket holds the outcomes recorded during one slice of the window.
+type statsBucket struct {
+	epoch   int64 // Index of the time slice this bucket currently holds
+	total   int
+	errors  int
+	codes   map[string]int
+	domains map[string]int
+	tags    map[string]int
+}
+
+// StatsSnapshot is a point-in-time view of the outcomes recorded by a Stats collector.
+type StatsSnapshot struct {
+	// Window is the duration covered by the snapshot.
+	Window time.Duration `json:"window"`
+	// Total is the number of outcomes recorded, including successes.
+	Total int `json:"total"`
+	// Errors is the number of errors recorded.
+	Errors int `json:"errors"`
+	// Codes counts the errors by code.
+	Codes map[string]int `json:"codes,omitempty"`
+	// Domains counts the errors by domain.
+	Domains map[string]int `json:"domains,omitempty"`
+	// Tags counts the errors by tag. Errors with several tags are counted once for each tag.
+	Tags map[string]int `json:"tags,omitempty"`
+}
+
+// ErrorRate returns the fraction of recorded outcomes that were errors, or 0 if nothing was recorded.
+func (s StatsSnapshot) ErrorRate() float64 {
+	if s.Total == 0 {
+		return 0
+	}
+
+	return float64(s.Errors) / float64(s.Total)
+}
+
+// NewStats creates a Stats collector aggregating outcomes over the provided sliding window.
+//
+// Windows shorter than DefaultStatsBuckets nanoseconds are raised to that minimum.
+//
+// Example:
+//
+//	stats := fail.NewStats(5 * time.Minute)
+func NewStats(window time.Duration) *Stats {
+	resolution := window / DefaultStatsBuckets
+	if resolution <= 0 {
+		resolution = 1
+	}
+
+	return &Stats{
+		resolution: resolution,
+		buckets:    make([]statsBucket, DefaultStatsBuckets),
+	}
+}
+
+// Window returns the duration of the sliding window of the collector.
+func (s *Stats) Window() time.Duration {
+	return s.resolution * time.Duration(len(s.buckets))
+}
+
+// Record records the outcome of an operation.
+//
+// A nil error is recorded as a success and only counts towards the total. A non-nil error is
+// additionally counted by its code, domain (if set) and tags.
+func (s *Stats) Record(err error) {
+	var code, domain string
+	var tags []string
+	if err != nil {
+		code, domain, tags = Code(err), Domain(err), Tags(err)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	b := s.bucket(time.Now())
+	b.total++
+	if err == nil {
+		return
+	}
+
+	b.errors++
+	countKey(&b.codes, code)
+	if domain != "" {
+		countKey(&b.domains, domain)
+	}
+	for _, tag := range tags {
+		countKey(&b.tags, tag)
+	}
+}
+
+// Snapshot returns the outcomes recorded within the full window of the collector.
+func (s *Stats) Snapshot() StatsSnapshot {
+	return s.SnapshotWithin(s.Window())
+}
+
+// SnapshotWithin returns the outcomes recorded within the most recent duration d.
+//
+// The duration is rounded up to the resolution of the collector and capped at its window.
+// This allows a single collector to answer questions about several sliding windows, such as
+// the error rate of the last 10 seconds and of the last minute.
+func (s *Stats) SnapshotWithin(d time.Duration) StatsSnapshot {
+	n := int((d + s.resolution - 1) / s.resolution)
+	if n > len(s.buckets) {
+		n = len(s.buckets)
+	}
+
+	res := StatsSnapshot{
+		Window:  s.resolution * time.Duration(n),
+		Codes:   make(map[string]int),
+		Domains: make(map[string]int),
+		Tags:    make(map[string]int),
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := s.epoch(time.Now())
+	for i := range s.buckets {
+		b := &s.buckets[i]
+		if b.epoch <= now-int64(n) || b.epoch > now {
+			continue
+		}
+
+		res.Total += b.total
+		res.Errors += b.errors
+		mergeCounts(res.Codes, b.codes)
+		mergeCounts(res.Domains, b.domains)
+		mergeCounts(res.Tags, b.tags)
+	}
+
+	return res
+}
+
+// Reset discards all recorded outcomes.
+func (s *Stats) Reset() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	clear(s.buckets)
+}
+
+// epoch returns the index of the time slice containing t.
+func (s *Stats) epoch(t time.Time) int64 {
+	return t.UnixNano() / int64(s.resolution)
+}
+
+// bucket returns the bucket for the time slice containing t, resetting it if it holds an expired slice.
+//
+// The caller must hold s.mu.
+func (s *Stats) bucket(t time.Time) *statsBucket {
+	epoch := s.epoch(t)
+	b := &s.buckets[epoch%int64(len(s.buckets))]
+	if b.epoch != epoch {
+		*b = statsBucket{epoch: epoch}
+	}
+
+	return b
+}
+
+// countKey increments the count of key in the lazily allocated map m.
+func countKey(m *map[string]int, key string) {
+	if *m == nil {
+		*m = make(map[string]int)
+	}
+
+	(*m)[key]++
+}
+
+// mergeCounts adds the counts of src to dst.
+func mergeCounts(dst, src map[string]int) {
+	for k, v := range src {
+		dst[k] += v
+	}
+}
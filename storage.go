@@ -0,0 +1,282 @@
+package fail
+
+import "sync"
+
+// smallListSize is the number of entries up to which tagList and attrList look up keys by a linear scan.
+//
+// Errors usually carry only a handful of tags and attributes, for which a scan is cheaper than a map,
+// both in allocations and in time. Lists with more entries maintain a map from keys to positions.
+const smallListSize = 8
+
+// listStore is the storage shared by a keyedList and the lists derived from it by appending.
+//
+// Lists hold a prefix of items and never modify it. A list whose prefix covers all of items may
+// append to it in place; any other list copies its prefix into a new store first. This way, a chain
+// of builder calls appends in amortized constant time, while builders derived from the same error
+// never see each other's entries.
+type listStore[T any] struct {
+	mu    sync.Mutex
+	items []T            // Entries of the longest list sharing the store
+	index map[string]int // Positions of the keys in items, once there are more than smallListSize
+}
+
+// keyedList is an insertion-ordered list of entries with unique keys.
+//
+// The zero value is an empty list and does not allocate. A keyedList is immutable; all methods
+// returning a list leave the receiver unchanged, so lists may be shared between errors.
+type keyedList[T any] struct {
+	items []T // Entries of the list, never modified
+	store *listStore[T]
+}
+
+// len returns the number of entries in the list.
+func (l keyedList[T]) len() int {
+	return len(l.items)
+}
+
+// indexOf returns the position of key in the list, or -1 if it is not present.
+func (l keyedList[T]) indexOf(key string, keyOf func(T) string) int {
+	if len(l.items) <= smallListSize {
+		for i, item := range l.items {
+			if keyOf(item) == key {
+				return i
+			}
+		}
+
+		return -1
+	}
+
+	l.store.mu.Lock()
+	i, ok := l.store.index[key]
+	l.store.mu.Unlock()
+
+	// The store may hold keys appended by lists derived from this one.
+	if !ok || i >= len(l.items) {
+		return -1
+	}
+
+	return i
+}
+
+// appended returns a list with item, whose key is not yet present, added at the end.
+func (l keyedList[T]) appended(item T, key string, keyOf func(T) string) keyedList[T] {
+	n := len(l.items)
+	if l.store != nil {
+		l.store.mu.Lock()
+		if len(l.store.items) == n {
+			l.store.items = append(l.store.items, item)
+			l.store.indexLocked(n, key, keyOf)
+			items := l.store.items[: n+1 : n+1]
+			l.store.mu.Unlock()
+
+			return keyedList[T]{items: items, store: l.store}
+		}
+		l.store.mu.Unlock()
+	}
+
+	items := append(make([]T, 0, max(2*n, 4)), l.items...)
+	return newKeyedList(append(items, item), keyOf)
+}
+
+// replaced returns a list with the entry at position i replaced by item, which has the same key.
+func (l keyedList[T]) replaced(i int, item T, keyOf func(T) string) keyedList[T] {
+	items := append(make([]T, 0, len(l.items)), l.items...)
+	items[i] = item
+
+	return newKeyedList(items, keyOf)
+}
+
+// newKeyedList returns a list of items in a new store, which takes ownership of items.
+func newKeyedList[T any](items []T, keyOf func(T) string) keyedList[T] {
+	store := &listStore[T]{items: items}
+	if len(items) > smallListSize {
+		store.index = make(map[string]int, cap(items))
+		for i, item := range items {
+			store.index[keyOf(item)] = i
+		}
+	}
+
+	return keyedList[T]{items: items[:len(items):len(items)], store: store}
+}
+
+// indexLocked records the key of the item at position i, building the index once the store has
+// more than smallListSize entries. The store must be locked.
+func (s *listStore[T]) indexLocked(i int, key string, keyOf func(T) string) {
+	if s.index != nil {
+		s.index[key] = i
+		return
+	}
+
+	if len(s.items) > smallListSize {
+		s.index = make(map[string]int, cap(s.items))
+		for j, item := range s.items {
+			s.index[keyOf(item)] = j
+		}
+	}
+}
+
+// tagList is a deduplicated, insertion-ordered list of tags.
+//
+// The zero value is an empty list and does not allocate. See keyedList.
+type tagList struct {
+	keyedList[string]
+}
+
+// tagKey returns the key of a tag, which is the tag itself.
+func tagKey(tag string) string {
+	return tag
+}
+
+// has reports whether the list contains tag.
+func (l tagList) has(tag string) bool {
+	return l.indexOf(tag, tagKey) >= 0
+}
+
+// add returns a list containing all tags of l followed by the given tags that are not yet present.
+//
+// Empty tags are ignored. If no tag is added, l is returned as-is.
+func (l tagList) add(tags ...string) tagList {
+	for _, tag := range tags {
+		if tag == "" || l.has(tag) {
+			continue
+		}
+
+		l.keyedList = l.appended(tag, tag, tagKey)
+	}
+
+	return l
+}
+
+// head returns a list of the first n tags of l.
+func (l tagList) head(n int) tagList {
+	if n >= len(l.items) {
+		return l
+	}
+
+	return tagList{newKeyedList(append([]string(nil), l.items[:n]...), tagKey)}
+}
+
+// slice returns a copy of the tags as a plain slice, or nil if there are no tags.
+func (l tagList) slice() []string {
+	if len(l.items) == 0 {
+		return nil
+	}
+
+	return append([]string(nil), l.items...)
+}
+
+// attr is a single key-value attribute.
+type attr struct {
+	key   string
+	value any
+}
+
+// attrKey returns the key of an attribute.
+func attrKey(a attr) string {
+	return a.key
+}
+
+// attrList is an insertion-ordered list of attributes with unique keys.
+//
+// The zero value is an empty list and does not allocate. See keyedList.
+type attrList struct {
+	keyedList[attr]
+}
+
+// all returns the attributes of the list in insertion order. The returned slice must not be modified.
+func (l attrList) all() []attr {
+	return l.items
+}
+
+// get returns the value stored for key and whether it was present.
+func (l attrList) get(key string) (any, bool) {
+	if i := l.indexOf(key, attrKey); i >= 0 {
+		return l.items[i].value, true
+	}
+
+	return nil, false
+}
+
+// set returns a list with the given attributes added, overwriting the values of existing keys.
+//
+// Empty keys and nil values are ignored. If no attribute is set, l is returned as-is.
+func (l attrList) set(attrs map[string]any) attrList {
+	for key, value := range attrs {
+		l = l.with(key, value)
+	}
+
+	return l
+}
+
+// with returns a list with the attribute key set to value, overwriting an existing value.
+//
+// An empty key or a nil value is ignored, in which case l is returned as-is.
+func (l attrList) with(key string, value any) attrList {
+	if key == "" || value == nil {
+		return l
+	}
+
+	a := attr{key: key, value: value}
+	if i := l.indexOf(key, attrKey); i >= 0 {
+		return attrList{l.replaced(i, a, attrKey)}
+	}
+
+	return attrList{l.appended(a, key, attrKey)}
+}
+
+// toMap returns the attributes as a newly allocated, non-nil map.
+func (l attrList) toMap() map[string]any {
+	m := make(map[string]any, len(l.items))
+	for _, a := range l.items {
+		m[a.key] = a.value
+	}
+
+	return m
+}
+
+// labelList holds optional labels aligned by index with a list of errors, such as the causes of an error.
+//
+// The list may be shorter than the list of errors it belongs to; missing labels are empty. The zero
+// value has no labels and does not allocate. A labelList is never modified in place once shared.
+type labelList []string
+
+// at returns the label at index i, or an empty string if there is none.
+func (l labelList) at(i int) string {
+	if i < 0 || i >= len(l) {
+		return ""
+	}
+
+	return l[i]
+}
+
+// with returns a list with the label at index i set to label.
+//
+// An empty label is ignored unless it replaces an existing label, in which case l is returned as-is.
+func (l labelList) with(i int, label string) labelList {
+	if label == l.at(i) {
+		return l
+	}
+
+	res := make(labelList, max(len(l), i+1))
+	copy(res, l)
+	res[i] = label
+
+	return res
+}
+
+// aligned returns the labels as a slice of length n, or nil if none of the first n labels is set.
+func (l labelList) aligned(n int) []string {
+	if len(l) > n {
+		l = l[:n]
+	}
+
+	for _, label := range l {
+		if label != "" {
+			res := make([]string, n)
+			copy(res, l)
+			return res
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,188 @@
+package fail
+
+import (
+	"cmp"
+	"encoding/json"
+	"fmt"
+	"io"
+	"slices"
+	"strings"
+	"time"
+)
+
+// DefaultSummaryMessages is the maximum number of distinct messages listed in a Summary.
+const DefaultSummaryMessages = 10
+
+// Summary is an aggregate view of a set of errors, such as those collected by a batch job.
+//
+// Summaries are created with Summarize and can be rendered as text using String or WriteText,
+// or as JSON using WriteJson or encoding/json.
+type Summary struct {
+	// Total is the number of errors summarized.
+	Total int `json:"total"`
+	// Domains counts the errors by domain. Errors without a domain are not counted.
+	Domains map[string]int `json:"domains,omitempty"`
+	// Codes counts the errors by code.
+	Codes map[string]int `json:"codes,omitempty"`
+	// Messages lists the most frequent messages, most frequent first.
+	// At most DefaultSummaryMessages messages are listed.
+	Messages []MessageCount `json:"messages,omitempty"`
+	// Earliest is the earliest timestamp of the errors, or the zero time if none has a timestamp.
+	Earliest time.Time `json:"earliest,omitzero"`
+	// Latest is the latest timestamp of the errors, or the zero time if none has a timestamp.
+	Latest time.Time `json:"latest,omitzero"`
+}
+
+// MessageCount is the number of times a message occurred in a summarized set of errors.
+type MessageCount struct {
+	Message string `json:"msg"`
+	Count   int    `json:"count"`
+}
+
+// Summarize aggregates the provided errors into a Summary.
+//
+// The summary counts the errors by domain and code, lists the most frequent messages and records
+// the earliest and latest timestamps. Nil errors are ignored.
+//
+// Example:
+//
+//	var errs []error
+//	for _, job := range jobs {
+//		errs = append(errs, job.Run())
+//	}
+//
+//	if summary := fail.Summarize(errs); summary.Total > 0 {
+//		fmt.Println(summary) // 17 errors occurred between ...
+//	}
+func Summarize(errs []error) Summary {
+	s := Summary{
+		Domains: make(map[string]int),
+		Codes:   make(map[string]int),
+	}
+
+	messages := make(map[string]int)
+	for _, err := range errs {
+		if err == nil {
+			continue
+		}
+
+		s.Total++
+		s.Codes[Code(err)]++
+		messages[Message(err)]++
+
+		if domain := Domain(err); domain != "" {
+			s.Domains[domain]++
+		}
+
+		if t := Time(err); !t.IsZero() {
+			if s.Earliest.IsZero() || t.Before(s.Earliest) {
+				s.Earliest = t
+			}
+			if t.After(s.Latest) {
+				s.Latest = t
+			}
+		}
+	}
+
+	for msg, count := range messages {
+		s.Messages = append(s.Messages, MessageCount{Message: msg, Count: count})
+	}
+
+	slices.SortFunc(s.Messages, func(a, b MessageCount) int {
+		if c := cmp.Compare(b.Count, a.Count); c != 0 {
+			return c
+		}
+		return strings.Compare(a.Message, b.Message)
+	})
+
+	if len(s.Messages) > DefaultSummaryMessages {
+		s.Messages = s.Messages[:DefaultSummaryMessages]
+	}
+
+	return s
+}
+
+// String returns a human-readable text rendering of the summary.
+//
+// Example output:
+//
+//	17 errors occurred between 2024-01-02T03:04:05Z and 2024-01-02T03:09:41Z
+//	by domain: database=12, network=5
+//	by code: ERR_TIMEOUT=15, ERR_CONNECTION=2
+//	most frequent:
+//	  12x query timed out
+//	  5x connection refused
+func (s Summary) String() string {
+	var sb strings.Builder
+	_ = s.WriteText(&sb)
+	return sb.String()
+}
+
+// WriteText writes a human-readable text rendering of the summary to w.
+//
+// It returns the first error encountered while writing.
+func (s Summary) WriteText(w io.Writer) error {
+	pw := &printWriter{w: w}
+
+	switch s.Total {
+	case 0:
+		pw.WriteString("no errors occurred")
+		return pw.err
+	case 1:
+		pw.WriteString("1 error occurred")
+	default:
+		pw.WriteString(fmt.Sprintf("%d errors occurred", s.Total))
+	}
+
+	if !s.Earliest.IsZero() {
+		pw.WriteString(" between " + s.Earliest.Format(time.RFC3339) + " and " + s.Latest.Format(time.RFC3339))
+	}
+
+	if len(s.Domains) > 0 {
+		pw.WriteString("\nby domain: " + formatCounts(s.Domains))
+	}
+
+	if len(s.Codes) > 0 {
+		pw.WriteString("\nby code: " + formatCounts(s.Codes))
+	}
+
+	if len(s.Messages) > 0 {
+		pw.WriteString("\nmost frequent:")
+		for _, m := range s.Messages {
+			pw.WriteString(fmt.Sprintf("\n  %dx %s", m.Count, m.Message))
+		}
+	}
+
+	return pw.err
+}
+
+// WriteJson writes an indented JSON rendering of the summary to w.
+//
+// It returns the first error encountered while encoding or writing.
+func (s Summary) WriteJson(w io.Writer) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(s)
+}
+
+// formatCounts formats counts as comma-separated key=count pairs, highest count first.
+func formatCounts(counts map[string]int) string {
+	keys := make([]string, 0, len(counts))
+	for k := range counts {
+		keys = append(keys, k)
+	}
+
+	slices.SortFunc(keys, func(a, b string) int {
+		if c := cmp.Compare(counts[b], counts[a]); c != 0 {
+			return c
+		}
+		return strings.Compare(a, b)
+	})
+
+	parts := make([]string, len(keys))
+	for i, k := range keys {
+		parts[i] = fmt.Sprintf("%s=%d", k, counts[k])
+	}
+
+	return strings.Join(parts, ", ")
+}
@@ -39,6 +39,14 @@ const (
 	TagInternal = DomainInternal
 	// TagAPI represents errors related to API usage or responses.
 	TagAPI = DomainAPI
+	// TagCanceled represents errors caused by a canceled operation or context.
+	TagCanceled = "canceled"
+	// TagDNS represents errors caused by failed DNS lookups.
+	TagDNS = "dns"
+	// TagTLS represents errors caused by TLS handshakes or certificate verification.
+	TagTLS = "tls"
+	// TagPartial represents aggregate errors of operations that partially succeeded, see PartialSuccess.
+	TagPartial = "partial"
 )
 
 // ErrorTags is an error type that provides a set of tags associated with the error.
@@ -0,0 +1,184 @@
+package fail
+
+import (
+	"context"
+	"log/slog"
+	"sync"
+	"time"
+)
+
+// maxThrottleEntries is the number of tracked fingerprints above which a ThrottledLogger discards expired entries.
+const maxThrottleEntries = 10000
+
+// ThrottledLogger logs errors like LogIfError, but limits how often identical errors are logged.
+//
+// Errors are considered identical if they have the same Fingerprint. Within a fixed window that starts
+// with the first occurrence of an error, only the first Limit occurrences are logged at full detail;
+// further occurrences are counted but not logged. Once an occurrence of the error starts a new window,
+// or Flush is called, a summary record with the number of suppressed occurrences is logged at the level
+// of the error (see LogLevel). The summary has the message "repeated error suppressed" and records the
+// fingerprint under FingerprintAttribute, the number of suppressed occurrences under "suppressed", and
+// the message of the last suppressed occurrence under "error".
+//
+// A ThrottledLogger is safe for concurrent use and must be created using NewThrottledLogger.
+//
+// Example:
+//
+//	throttled := fail.NewThrottledLogger(logger, 5, time.Minute)
+//	for msg := range messages {
+//		throttled.LogIfError(handle(msg), "failed to handle message")
+//	}
+//	throttled.Flush()
+type ThrottledLogger struct {
+	logger *slog.Logger
+	limit  int
+	window time.Duration
+
+	mu      sync.Mutex
+	entries map[string]*throttleEntry
+}
+
+// throttleEntry counts the occurrences of a fingerprint within the current window.
+type throttleEntry struct {
+	start      time.Time
+	count      int
+	suppressed int
+	last       error
+}
+
+// throttleSummary describes the occurrences of a fingerprint suppressed within a window.
+type throttleSummary struct {
+	fingerprint string
+	suppressed  int
+	last        error
+}
+
+// NewThrottledLogger creates a ThrottledLogger logging each error at most limit times per window to logger.
+//
+// A limit below 1 is raised to 1. If logger is nil, slog.Default() is used.
+//
+// Example:
+//
+//	throttled := fail.NewThrottledLogger(slog.Default(), 3, 10*time.Second)
+func NewThrottledLogger(logger *slog.Logger, limit int, window time.Duration) *ThrottledLogger {
+	return &ThrottledLogger{
+		logger:  logger,
+		limit:   max(limit, 1),
+		window:  window,
+		entries: make(map[string]*throttleEntry),
+	}
+}
+
+// LogIfError logs the provided error with the given message like fail.LogIfError, unless it is throttled.
+//
+// Nil errors are ignored.
+func (t *ThrottledLogger) LogIfError(err error, msg string, args ...any) {
+	if err == nil {
+		return
+	}
+
+	if t.allow(err, time.Now()) {
+		logError(t.logger, err, msg, args)
+	}
+}
+
+// LogAndReturn logs the provided error like LogIfError and returns it unchanged.
+//
+// If err is nil, nothing is logged and nil is returned.
+func (t *ThrottledLogger) LogAndReturn(err error, msg string, args ...any) error {
+	if err == nil {
+		return nil
+	}
+
+	if t.allow(err, time.Now()) {
+		logError(t.logger, err, msg, args)
+	}
+
+	return err
+}
+
+// Flush logs a summary for every error with suppressed occurrences and discards all counted occurrences.
+//
+// Flush should be called before the program exits, so that suppressed occurrences are not lost.
+func (t *ThrottledLogger) Flush() {
+	t.mu.Lock()
+	var summaries []throttleSummary
+	for fingerprint, entry := range t.entries {
+		if entry.suppressed > 0 {
+			summaries = append(summaries, throttleSummary{fingerprint, entry.suppressed, entry.last})
+		}
+	}
+	clear(t.entries)
+	t.mu.Unlock()
+
+	t.logSummaries(summaries)
+}
+
+// allow counts an occurrence of err at now and reports whether it should be logged.
+//
+// Summaries of windows that ended are logged before allow returns.
+func (t *ThrottledLogger) allow(err error, now time.Time) bool {
+	fingerprint := Fingerprint(err)
+
+	t.mu.Lock()
+	var summaries []throttleSummary
+	entry, ok := t.entries[fingerprint]
+	if !ok || now.Sub(entry.start) >= t.window {
+		if ok && entry.suppressed > 0 {
+			summaries = append(summaries, throttleSummary{fingerprint, entry.suppressed, entry.last})
+		}
+		if !ok && len(t.entries) >= maxThrottleEntries {
+			summaries = append(summaries, t.prune(now)...)
+		}
+
+		entry = &throttleEntry{start: now}
+		t.entries[fingerprint] = entry
+	}
+
+	entry.count++
+	allowed := entry.count <= t.limit
+	if !allowed {
+		entry.suppressed++
+		entry.last = err
+	}
+	t.mu.Unlock()
+
+	t.logSummaries(summaries)
+
+	return allowed
+}
+
+// prune discards the entries whose window has expired and returns the summaries of their suppressed occurrences.
+func (t *ThrottledLogger) prune(now time.Time) []throttleSummary {
+	var summaries []throttleSummary
+	for fingerprint, entry := range t.entries {
+		if now.Sub(entry.start) >= t.window {
+			if entry.suppressed > 0 {
+				summaries = append(summaries, throttleSummary{fingerprint, entry.suppressed, entry.last})
+			}
+			delete(t.entries, fingerprint)
+		}
+	}
+
+	return summaries
+}
+
+// logSummaries logs a summary record for each of the provided summaries.
+func (t *ThrottledLogger) logSummaries(summaries []throttleSummary) {
+	if len(summaries) == 0 {
+		return
+	}
+
+	logger := t.logger
+	if logger == nil {
+		logger = slog.Default()
+	}
+
+	for _, s := range summaries {
+		logger.Log(context.Background(), LogLevel(s.last), "repeated error suppressed",
+			FingerprintAttribute, s.fingerprint,
+			"suppressed", s.suppressed,
+			"error", Message(s.last),
+		)
+	}
+}
@@ -0,0 +1,52 @@
+package fail
+
+import (
+	"context"
+	"errors"
+	"time"
+)
+
+// TimeoutAttribute is the attribute key under which WithinTimeout records the configured timeout, as a time.Duration.
+const TimeoutAttribute = "timeout"
+
+// WithinTimeout calls fn with a context that expires after d, and reports expiry of that deadline as a timeout error.
+//
+// fn must honor the cancellation of the provided context; WithinTimeout does not return before fn does.
+// If fn returns an error after the deadline of d expired, WithinTimeout returns a Fail with DomainTimeout,
+// ErrCodeTimeout, the timeout recorded under TimeoutAttribute, the time spent as its duration, and the
+// details of the expired context (see Builder.Context). The error returned by fn, if it is not just the
+// context's error, is kept as an associated error. Errors returned before the deadline, and errors
+// caused by ctx itself being done, are returned unchanged. If fn succeeds, WithinTimeout returns nil.
+//
+// Example:
+//
+//	err := fail.WithinTimeout(ctx, 2*time.Second, func(ctx context.Context) error {
+//		return client.Ping(ctx)
+//	})
+func WithinTimeout(ctx context.Context, d time.Duration, fn func(ctx context.Context) error) error {
+	tctx, cancel := context.WithTimeout(ctx, d)
+	defer cancel()
+
+	start := time.Now()
+	err := fn(tctx)
+	if err == nil {
+		return nil
+	}
+
+	if ctx.Err() != nil || !errors.Is(tctx.Err(), context.DeadlineExceeded) {
+		return err
+	}
+
+	b := New().
+		Domain(DomainTimeout).
+		Code(ErrCodeTimeout).
+		Attribute(TimeoutAttribute, d).
+		Duration(time.Since(start)).
+		Context(tctx)
+
+	if !errors.Is(err, context.DeadlineExceeded) {
+		b = b.Associate(err)
+	}
+
+	return b.Msgf("operation timed out after %s", d)
+}
@@ -32,22 +32,89 @@ type ErrorTraceId interface {
 //
 // This function attempts to extract the trace ID from the error as follows:
 //  1. If err is nil, it returns an empty string.
-//  2. If err implements ErrorTraceId, it returns the result of ErrorTraceId().
-//  3. Otherwise, it returns an empty string.
+//  2. If err implements ErrorTraceId and the result of ErrorTraceId() is not empty, it returns the result.
+//  3. Otherwise, it recursively examines the causes of err (using Causes(err)) and returns the
+//     first non-empty trace ID found, so that a trace ID carried only by a nested cause is not lost.
 //
+// The recursion is bounded by MaxDepth and MaxWidth, and cycles in the cause graph are ignored.
 // The returned string may be empty if no trace ID is set.
 func TraceId(err error) string {
+	return traceId(err, 0, newVisitSet())
+}
+
+// traceId implements TraceId, tracking the current depth and the visited errors to guard against cycles.
+func traceId(err error, depth int, visited visitSet) string {
 	if err == nil {
 		return ""
 	}
 
 	if t, ok := err.(ErrorTraceId); ok {
-		return t.ErrorTraceId()
+		if id := t.ErrorTraceId(); id != "" {
+			return id
+		}
+	}
+
+	if depth >= MaxDepth() || !visited.enter(err) {
+		return ""
+	}
+	defer visited.leave(err)
+
+	for _, cause := range limitWidth(Causes(err)) {
+		if id := traceId(cause, depth+1, visited); id != "" {
+			return id
+		}
 	}
 
 	return ""
 }
 
+// TraceLink returns the best available pair of trace ID and span ID for the provided error.
+//
+// The pair is taken from the first error in the cause tree of err (err itself first, then its causes
+// depth-first) that carries both a trace ID and a span ID, so that the span always belongs to the trace.
+// If no error carries both, the first trace ID found is returned with an empty span ID.
+// If no trace ID is found, both results are empty.
+//
+// Example:
+//
+//	traceId, spanId := fail.TraceLink(err)
+//	log.Printf("see trace %s (span %s)", traceId, spanId)
+func TraceLink(err error) (traceId, spanId string) {
+	if traceId, spanId = traceLink(err, 0, newVisitSet()); traceId != "" {
+		return traceId, spanId
+	}
+
+	return TraceId(err), ""
+}
+
+// traceLink returns the trace and span ID of the first error in the cause tree of err that carries both.
+func traceLink(err error, depth int, visited visitSet) (string, string) {
+	if err == nil {
+		return "", ""
+	}
+
+	t, tOk := err.(ErrorTraceId)
+	s, sOk := err.(ErrorSpanId)
+	if tOk && sOk {
+		if traceId, spanId := t.ErrorTraceId(), s.ErrorSpanId(); traceId != "" && spanId != "" {
+			return traceId, spanId
+		}
+	}
+
+	if depth >= MaxDepth() || !visited.enter(err) {
+		return "", ""
+	}
+	defer visited.leave(err)
+
+	for _, cause := range limitWidth(Causes(err)) {
+		if traceId, spanId := traceLink(cause, depth+1, visited); traceId != "" {
+			return traceId, spanId
+		}
+	}
+
+	return "", ""
+}
+
 // WithTraceId returns a new error with the specified trace ID attached.
 //
 // This function wraps an existing error with a trace ID string for distributed tracing.
@@ -0,0 +1,104 @@
+package fail
+
+import (
+	"encoding/hex"
+	"strings"
+
+	"go.opentelemetry.io/otel/trace"
+)
+
+// TraceFlagsAttribute is the attribute key under which the trace flags of a W3C traceparent header are recorded.
+//
+// The flags are stored as a two-character lowercase hex string, such as "01" for a sampled trace.
+const TraceFlagsAttribute = "trace_flags"
+
+// Traceparent sets the trace ID, span ID and trace flags from a W3C traceparent header.
+//
+// The header has the form "version-traceid-parentid-flags", for example
+// "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01". The trace and parent IDs become
+// the trace and span IDs of the error, and the flags are recorded as the TraceFlagsAttribute attribute.
+// Malformed headers, the invalid version "ff" and all-zero IDs are ignored.
+// This is useful for HTTP services that only have the raw header rather than an OpenTelemetry span.
+//
+// Example:
+//
+//	err := fail.New().
+//		Traceparent(r.Header.Get("traceparent")).
+//		Msg("request failed")
+func (b Builder) Traceparent(header string) Builder {
+	traceId, spanId, flags, ok := parseTraceparent(header)
+	if !ok {
+		return b
+	}
+
+	return b.TraceId(traceId).SpanId(spanId).Attribute(TraceFlagsAttribute, flags)
+}
+
+// Traceparent returns the W3C traceparent header for the trace context of the provided error.
+//
+// The trace and span IDs are taken from TraceLink, and the flags from the TraceFlagsAttribute
+// attribute, defaulting to "00" (not sampled). If the error does not carry both a trace ID and
+// a span ID, Traceparent returns an empty string.
+//
+// Example:
+//
+//	if header := fail.Traceparent(err); header != "" {
+//		req.Header.Set("traceparent", header)
+//	}
+func Traceparent(err error) string {
+	traceId, spanId := TraceLink(err)
+	if traceId == "" || spanId == "" {
+		return ""
+	}
+
+	flags, ok := Attributes(err)[TraceFlagsAttribute].(string)
+	if !ok || len(flags) != 2 || !isLowerHex(flags) {
+		flags = "00"
+	}
+
+	return "00-" + traceId + "-" + spanId + "-" + flags
+}
+
+// parseTraceparent parses a W3C traceparent header into its trace ID, span ID and flags.
+func parseTraceparent(header string) (traceId, spanId, flags string, ok bool) {
+	parts := strings.Split(strings.TrimSpace(header), "-")
+	if len(parts) < 4 {
+		return "", "", "", false
+	}
+
+	version := parts[0]
+	if len(version) != 2 || !isLowerHex(version) || version == "ff" {
+		return "", "", "", false
+	}
+
+	// Version 00 has exactly four fields; future versions may append more.
+	if version == "00" && len(parts) != 4 {
+		return "", "", "", false
+	}
+
+	traceId, spanId, flags = parts[1], parts[2], parts[3]
+	if len(flags) != 2 || !isLowerHex(traceId) || !isLowerHex(spanId) || !isLowerHex(flags) {
+		return "", "", "", false
+	}
+
+	t, err := trace.TraceIDFromHex(traceId)
+	if err != nil || !t.IsValid() {
+		return "", "", "", false
+	}
+
+	s, err := trace.SpanIDFromHex(spanId)
+	if err != nil || !s.IsValid() {
+		return "", "", "", false
+	}
+
+	return traceId, spanId, flags, true
+}
+
+// isLowerHex reports whether s consists only of lowercase hexadecimal digits.
+func isLowerHex(s string) bool {
+	if _, err := hex.DecodeString(s); err != nil {
+		return false
+	}
+
+	return strings.ToLower(s) == s
+}
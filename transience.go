@@ -0,0 +1,146 @@
+package fail
+
+import "fmt"
+
+// TransienceKind classifies whether a failure is expected to go away when the operation is retried.
+type TransienceKind int
+
+const (
+	// TransienceUnknown indicates that nothing is known about whether the failure is transient.
+	TransienceUnknown TransienceKind = iota
+	// TransienceTransient indicates a temporary failure, such as a timeout or a dropped connection,
+	// that may not occur again when the operation is retried.
+	TransienceTransient
+	// TransiencePermanent indicates a failure, such as a validation error, that will occur again
+	// when the operation is retried unchanged.
+	TransiencePermanent
+)
+
+// String returns the name of the transience kind: "unknown", "transient" or "permanent".
+func (t TransienceKind) String() string {
+	switch t {
+	case TransienceTransient:
+		return "transient"
+	case TransiencePermanent:
+		return "permanent"
+	default:
+		return "unknown"
+	}
+}
+
+// MarshalText implements encoding.TextMarshaler.
+func (t TransienceKind) MarshalText() ([]byte, error) {
+	return []byte(t.String()), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler.
+func (t *TransienceKind) UnmarshalText(text []byte) error {
+	switch string(text) {
+	case "", "unknown":
+		*t = TransienceUnknown
+	case "transient":
+		*t = TransienceTransient
+	case "permanent":
+		*t = TransiencePermanent
+	default:
+		return fmt.Errorf("unknown transience %q", text)
+	}
+
+	return nil
+}
+
+// ErrorTransience is an error type that classifies the failure as transient or permanent.
+//
+// Implementations of this interface should return TransienceTransient for failures that may
+// go away when retried, TransiencePermanent for failures that will not, and TransienceUnknown
+// if neither is known.
+//
+// Example usage:
+//
+//	type MyError struct{}
+//	func (e *MyError) Error() string { return "invalid argument" }
+//	func (e *MyError) ErrorTransience() fail.TransienceKind { return fail.TransiencePermanent }
+//
+//	err := &MyError{}
+//	t := fail.Transience(err) // returns fail.TransiencePermanent
+type ErrorTransience interface {
+	error
+
+	// ErrorTransience classifies the failure as transient or permanent.
+	ErrorTransience() TransienceKind
+}
+
+// Transience classifies the provided error as transient, permanent or unknown.
+//
+// This function determines the transience as follows:
+//  1. If err is nil, it returns TransienceUnknown.
+//  2. If err implements ErrorTransience and the result is not TransienceUnknown, it returns the result.
+//  3. If err implements ErrorRetryable and ErrorRetryable() returns true, it returns TransienceTransient.
+//  4. Otherwise, it recursively examines the causes of err. If any cause is permanent, it returns
+//     TransiencePermanent, since retrying cannot succeed. If any cause is transient, it returns
+//     TransienceTransient. Otherwise, it returns TransienceUnknown.
+//
+// The recursion is bounded by MaxDepth and MaxWidth, and cycles in the cause graph are ignored.
+func Transience(err error) TransienceKind {
+	return transience(err, 0, newVisitSet())
+}
+
+// transience implements Transience, tracking the current depth and the visited errors to guard against cycles.
+func transience(err error, depth int, visited visitSet) TransienceKind {
+	if err == nil {
+		return TransienceUnknown
+	}
+
+	if t, ok := err.(ErrorTransience); ok {
+		if kind := t.ErrorTransience(); kind != TransienceUnknown {
+			return kind
+		}
+	}
+
+	if r, ok := err.(ErrorRetryable); ok && r.ErrorRetryable() {
+		return TransienceTransient
+	}
+
+	if depth >= MaxDepth() || !visited.enter(err) {
+		return TransienceUnknown
+	}
+	defer visited.leave(err)
+
+	res := TransienceUnknown
+	for _, cause := range limitWidth(Causes(err)) {
+		switch transience(cause, depth+1, visited) {
+		case TransiencePermanent:
+			return TransiencePermanent
+		case TransienceTransient:
+			res = TransienceTransient
+		}
+	}
+
+	return res
+}
+
+// WithTransience returns a new error with the specified transience.
+//
+// This function takes an existing error and a transience kind, and returns a new error
+// that reports the provided transience. If the provided error is nil, it returns nil.
+//
+// The returned error will implement the ErrorTransience interface, and the transience can be
+// retrieved using the fail.Transience function.
+//
+// Example:
+//
+//	err := fail.WithTransience(validationErr, fail.TransiencePermanent)
+//
+// Parameters:
+//   - err: The original error to classify.
+//   - transience: The transience of the error.
+//
+// Returns:
+//   - A new error with the transience attached, or nil if err is nil.
+func WithTransience(err error, transience TransienceKind) error {
+	if err == nil {
+		return nil
+	}
+
+	return From(err).Transience(transience).asFail()
+}
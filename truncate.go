@@ -0,0 +1,237 @@
+package fail
+
+import (
+	"bytes"
+	"reflect"
+	"slices"
+	"strconv"
+	"strings"
+	"sync/atomic"
+	"unicode/utf8"
+)
+
+// TruncatedAttribute is the attribute key under which the fields shortened by the size limits are recorded.
+//
+// The value is a []string naming the truncated fields: "msg", "user_msg", "tags", or
+// "attributes.<key>" for an attribute value.
+const TruncatedAttribute = "truncated"
+
+// TruncationMarker is appended to messages and string attribute values that were shortened by the size limits.
+//
+// The marker is followed by the number of bytes removed and a closing bracket, e.g. "…[truncated 1024 bytes]".
+const TruncationMarker = "…[truncated "
+
+// DefaultMaxMessageLength is the default maximum length, in bytes, of the developer and user messages of an error.
+const DefaultMaxMessageLength = 64 << 10
+
+// DefaultMaxAttributeSize is the default maximum size, in bytes, of a string or byte slice attribute value.
+const DefaultMaxAttributeSize = 64 << 10
+
+// DefaultMaxTags is the default maximum number of tags kept per error.
+const DefaultMaxTags = 256
+
+var (
+	maxMessageLength atomic.Int64
+	maxAttributeSize atomic.Int64
+	maxTags          atomic.Int64
+)
+
+func init() {
+	maxMessageLength.Store(DefaultMaxMessageLength)
+	maxAttributeSize.Store(DefaultMaxAttributeSize)
+	maxTags.Store(DefaultMaxTags)
+}
+
+// SetMaxMessageLength sets the maximum length, in bytes, of the developer and user messages of an error.
+//
+// Longer messages are cut when the error is built, at a UTF-8 character boundary, and
+// TruncationMarker is appended. The truncation is recorded under TruncatedAttribute.
+// Values less than or equal to zero reset the limit to DefaultMaxMessageLength.
+// It is safe to call SetMaxMessageLength concurrently.
+//
+// Example:
+//
+//	fail.SetMaxMessageLength(4096)
+func SetMaxMessageLength(length int) {
+	if length <= 0 {
+		length = DefaultMaxMessageLength
+	}
+
+	maxMessageLength.Store(int64(length))
+}
+
+// MaxMessageLength returns the maximum length, in bytes, of the developer and user messages of an error.
+func MaxMessageLength() int {
+	return int(maxMessageLength.Load())
+}
+
+// SetMaxAttributeSize sets the maximum size, in bytes, of a string or byte slice attribute value.
+//
+// Larger values are cut when the error is built, so that a single error carrying a large payload
+// cannot blow up logs or serialized output. Strings are cut at a UTF-8 character boundary and
+// TruncationMarker is appended; byte slices are cut without a marker. Values of other types are
+// kept as-is. The truncation is recorded under TruncatedAttribute.
+// Values less than or equal to zero reset the limit to DefaultMaxAttributeSize.
+// It is safe to call SetMaxAttributeSize concurrently.
+//
+// Example:
+//
+//	fail.SetMaxAttributeSize(1024)
+func SetMaxAttributeSize(size int) {
+	if size <= 0 {
+		size = DefaultMaxAttributeSize
+	}
+
+	maxAttributeSize.Store(int64(size))
+}
+
+// MaxAttributeSize returns the maximum size, in bytes, of a string or byte slice attribute value.
+func MaxAttributeSize() int {
+	return int(maxAttributeSize.Load())
+}
+
+// SetMaxTags sets the maximum number of tags kept per error.
+//
+// Tags beyond the limit are dropped when the error is built, keeping the tags added first.
+// The truncation is recorded under TruncatedAttribute.
+// Values less than or equal to zero reset the limit to DefaultMaxTags.
+// It is safe to call SetMaxTags concurrently.
+//
+// Example:
+//
+//	fail.SetMaxTags(32)
+func SetMaxTags(tags int) {
+	if tags <= 0 {
+		tags = DefaultMaxTags
+	}
+
+	maxTags.Store(int64(tags))
+}
+
+// MaxTags returns the maximum number of tags kept per error.
+func MaxTags() int {
+	return int(maxTags.Load())
+}
+
+// applySizeLimits truncates the messages, tags and attribute values of the builder to the configured size limits.
+//
+// The truncated fields are recorded under TruncatedAttribute. Since the limits are applied whenever
+// an error is built, including when it is rebuilt using From, values that were already truncated
+// are left as they are.
+func (b Builder) applySizeLimits() Builder {
+	var truncated []string
+
+	msgLimit := MaxMessageLength()
+	if s, ok := truncateString(b.msg, msgLimit); ok {
+		b.msg = s
+		truncated = append(truncated, "msg")
+	}
+
+	if s, ok := truncateString(b.userMsg, msgLimit); ok {
+		b.userMsg = s
+		truncated = append(truncated, "user_msg")
+	}
+
+	if tagLimit := MaxTags(); b.tags.len() > tagLimit {
+		b.tags = b.tags.head(tagLimit)
+		truncated = append(truncated, "tags")
+	}
+
+	attrLimit := MaxAttributeSize()
+	for _, a := range b.attrs.all() {
+		if a.key == TruncatedAttribute {
+			continue
+		}
+
+		value, ok := truncateValue(a.value, attrLimit)
+		if !ok {
+			continue
+		}
+
+		b.attrs = b.attrs.with(a.key, value)
+		truncated = append(truncated, "attributes."+a.key)
+	}
+
+	return b.markTruncated(truncated...)
+}
+
+// markTruncated records the provided fields under TruncatedAttribute, skipping fields recorded already.
+func (b Builder) markTruncated(fields ...string) Builder {
+	previous, _ := b.attrs.get(TruncatedAttribute)
+	list, _ := previous.([]string)
+
+	merged := list[:len(list):len(list)]
+	for _, field := range fields {
+		if !slices.Contains(merged, field) {
+			merged = append(merged, field)
+		}
+	}
+
+	if len(merged) == len(list) {
+		return b
+	}
+
+	b.attrs = b.attrs.with(TruncatedAttribute, merged)
+
+	return b
+}
+
+// truncateValue truncates string and byte slice values longer than limit and reports whether it did.
+//
+// Byte slices are copied, so that the truncated value does not keep the original backing array alive.
+func truncateValue(value any, limit int) (any, bool) {
+	switch v := value.(type) {
+	case string:
+		return truncateString(v, limit)
+	case []byte:
+		if len(v) > limit {
+			return bytes.Clone(v[:limit]), true
+		}
+
+		return v, false
+	}
+
+	rv := reflect.ValueOf(value)
+	switch {
+	case rv.Kind() == reflect.String && rv.Len() > limit:
+		s, ok := truncateString(rv.String(), limit)
+		return reflect.ValueOf(s).Convert(rv.Type()).Interface(), ok
+	case rv.Kind() == reflect.Slice && rv.Type().Elem().Kind() == reflect.Uint8 && rv.Len() > limit:
+		res := reflect.MakeSlice(rv.Type(), limit, limit)
+		reflect.Copy(res, rv)
+		return res.Interface(), true
+	}
+
+	return value, false
+}
+
+// truncateString cuts s to at most limit bytes at a UTF-8 character boundary, appends TruncationMarker,
+// and reports whether s was truncated. Strings that were already truncated to limit are returned unchanged.
+func truncateString(s string, limit int) (string, bool) {
+	if len(s) <= limit || isTruncated(s, limit) {
+		return s, false
+	}
+
+	cut := limit
+	for cut > 0 && !utf8.RuneStart(s[cut]) {
+		cut--
+	}
+
+	return s[:cut] + TruncationMarker + strconv.Itoa(len(s)-cut) + " bytes]", true
+}
+
+// isTruncated reports whether s is the result of truncating a string to at most limit bytes using truncateString.
+func isTruncated(s string, limit int) bool {
+	i := strings.LastIndex(s, TruncationMarker)
+	if i < 0 || i > limit {
+		return false
+	}
+
+	removed, ok := strings.CutSuffix(s[i+len(TruncationMarker):], " bytes]")
+	if !ok {
+		return false
+	}
+
+	_, err := strconv.Atoi(removed)
+	return err == nil
+}
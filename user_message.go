@@ -1,5 +1,7 @@
 package fail
 
+import "context"
+
 // ErrorUserMessage is an error type that provides a user-facing message.
 //
 // Implementations of this interface should return a concise, human-readable message
@@ -46,6 +48,41 @@ func UserMessage(err error) string {
 	return err.Error()
 }
 
+// ExplicitUserMessage returns the user-facing message set explicitly on the provided error or its causes.
+//
+// Unlike UserMessage, it never falls back to err.Error(), so its result is safe to show to clients:
+//  1. If err is nil, it returns the empty string.
+//  2. If err implements ErrorUserMessage and the result of ErrorUserMessage() is not empty, it returns the result.
+//  3. Otherwise, it recursively examines the causes of err (using Causes(err)) and returns the
+//     first non-empty user-facing message found, or the empty string if there is none.
+//
+// The recursion is bounded by MaxDepth and MaxWidth, and cycles in the cause graph are ignored.
+//
+// Example:
+//
+//	msg := fail.ExplicitUserMessage(err)
+//	if msg == "" {
+//		msg = "Something went wrong. Please try again."
+//	}
+func ExplicitUserMessage(err error) string {
+	var msg string
+	search(err, func(err error) bool {
+		msg = ownUserMessage(err)
+		return msg != ""
+	})
+
+	return msg
+}
+
+// ownUserMessage returns the user-facing message of err if it implements ErrorUserMessage, ignoring its causes.
+func ownUserMessage(err error) string {
+	if u, ok := err.(ErrorUserMessage); ok {
+		return u.ErrorUserMessage()
+	}
+
+	return ""
+}
+
 // WithUserMessage returns a new error with the specified user-facing message attached.
 //
 // This function wraps an existing error with a user message string suitable for display to end users.
@@ -77,3 +114,35 @@ func WithUserMessage(err error, userMessage string) error {
 
 	return From(err).UserMsg(userMessage).asFail()
 }
+
+// userMsgContextKey is an unexported type used as the key for storing
+// and retrieving the default user-facing message in a context.Context.
+type userMsgContextKey struct{}
+
+// ContextWithUserMsg returns a new context.Context that carries the provided default user-facing message.
+// If a user message is already set in the context, it is overwritten with the new value.
+//
+// Errors built using NewC, MsgC, WrapC or Builder.Context use the user message from the context
+// unless a user message is set explicitly on the Builder.
+//
+// Example usage:
+//
+//	ctx := ContextWithUserMsg(context.Background(), "Checkout failed. Please try again.")
+func ContextWithUserMsg(ctx context.Context, userMsg string) context.Context {
+	return context.WithValue(ctx, userMsgContextKey{}, userMsg)
+}
+
+// UserMsgFromContext extracts the default user-facing message from the provided context.
+// If no user message is set in the context, UserMsgFromContext returns an empty string.
+//
+// Example usage:
+//
+//	userMsg := UserMsgFromContext(ctx)
+func UserMsgFromContext(ctx context.Context) string {
+	userMsg, ok := ctx.Value(userMsgContextKey{}).(string)
+	if !ok {
+		return ""
+	}
+
+	return userMsg
+}
@@ -0,0 +1,73 @@
+package fail
+
+import (
+	"strings"
+	"sync/atomic"
+)
+
+// verbose controls whether Fail.Error includes the messages of the causes in fmt wrapping style.
+var verbose atomic.Bool
+
+// SetVerbose enables or disables verbose error strings for all Fail errors.
+//
+// By default, Fail.Error renders the message of the error and the messages of its causes on separate,
+// indented lines. In verbose mode, Error renders them on a single line in the style of fmt.Errorf
+// wrapping, as "msg: cause1: cause2", which keeps the cause context available to tooling that only
+// looks at the result of Error. Multiple causes of the same error are rendered as "msg: [cause1; cause2]".
+// Verbose mode can also be enabled per error using Builder.Verbose.
+// It is safe to call SetVerbose concurrently.
+//
+// Example:
+//
+//	fail.SetVerbose(true)
+func SetVerbose(enabled bool) {
+	verbose.Store(enabled)
+}
+
+// Verbose reports whether verbose error strings are enabled for all Fail errors.
+func Verbose() bool {
+	return verbose.Load()
+}
+
+// verboseError renders err and its causes on a single line in the style of fmt.Errorf wrapping.
+//
+// Causes that are not Fail errors are rendered using their Error method, which already includes
+// their own wrapped errors.
+func verboseError(err error, depth int, visited visitSet) string {
+	f, ok := err.(Fail)
+	if !ok {
+		return err.Error()
+	}
+
+	msg := f.msg
+	if f.op != "" {
+		msg = f.op + ": " + msg
+	}
+
+	if depth >= MaxDepth() || !visited.enter(err) {
+		return msg
+	}
+	defer visited.leave(err)
+
+	var causes []string
+	for i, cause := range limitWidth(f.causes) {
+		if cause == nil {
+			continue
+		}
+
+		if label := f.causeLabels.at(i); label != "" {
+			causes = append(causes, "["+label+"] "+verboseError(cause, depth+1, visited))
+		} else {
+			causes = append(causes, verboseError(cause, depth+1, visited))
+		}
+	}
+
+	switch len(causes) {
+	case 0:
+		return msg
+	case 1:
+		return msg + ": " + causes[0]
+	default:
+		return msg + ": [" + strings.Join(causes, "; ") + "]"
+	}
+}